@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/simulation"
+)
+
+func main() {
+	games := flag.Int("games", 1000, "Number of games to simulate")
+	seed := flag.Int64("seed", 1, "Seed for shuffling and bot choices, so runs are reproducible")
+	bots := flag.String("bots", "greedy,set_completion,random", "Comma-separated bot strategies, one per seat (available: "+strings.Join(engine.BotStrategyNames(), ", ")+")")
+	numRounds := flag.Int("rounds", 3, "Number of rounds per game")
+	cardsPerHand := flag.Int("cards", 0, "Fixed number of cards dealt per hand as a house rule (default: 0, official hand size for the player count)")
+	format := flag.String("format", "json", "Output format: json or csv")
+	output := flag.String("o", "", "Write the report to this file (default: stdout)")
+	flag.Parse()
+
+	if *format != "json" && *format != "csv" {
+		fmt.Fprintf(os.Stderr, "Unknown format %q (use json or csv)\n", *format)
+		os.Exit(1)
+	}
+
+	cfg := simulation.Config{
+		Games:        *games,
+		Seed:         *seed,
+		Strategies:   strings.Split(*bots, ","),
+		NumRounds:    *numRounds,
+		CardsPerHand: *cardsPerHand,
+	}
+
+	start := time.Now()
+	report, err := simulation.Run(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Simulation failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Simulated %d games in %s\n", *games, time.Since(start).Round(time.Millisecond))
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", *output, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *format == "csv" {
+		err = report.WriteCSV(w)
+	} else {
+		err = report.WriteJSON(w)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+		os.Exit(1)
+	}
+}
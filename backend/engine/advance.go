@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// ErrEndedEarly is returned by Advance when the next round couldn't be dealt, so the game ended
+// with the rounds already scored; the result is still reported
+var ErrEndedEarly = errors.New("game ended early")
+
+// AdvanceResult reports what one call to Advance did
+type AdvanceResult struct {
+	BotsPlayed    []string    // Bots, and the dummy on a bot's turn, that picked a card or took a Menu card
+	WaitingOnMenu bool        // The turn was revealed, but a player still has to take one of their Menu cards
+	Revealed      bool        // Every pick was in, so the turn was revealed and the hands passed
+	RoundScored   int         // Round that was scored when the hands ran out, or 0
+	Result        *GameResult // Final result when the last round was scored
+}
+
+// Advance lets bots pick, then once every player has picked reveals the turn and passes the hands,
+// scoring the round when they run out and dealing the next round or ending the game
+// A revealed Menu holds the hands until its player takes a card; bots take theirs at once
+// Callers broadcast what it reports; calling it again after a reveal lets bots complete the next turn
+func (e *Engine) Advance(gameID string) (*AdvanceResult, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+
+	advance := &AdvanceResult{}
+	if game.RoundPhase != models.PhaseRevealing {
+		if err := checkPhase(game, ActionPlayCard); err != nil {
+			return nil, err
+		}
+		played, err := e.playBots(entry)
+		advance.BotsPlayed = played
+		if err != nil || !allSelected(game) {
+			return advance, err
+		}
+
+		if err := e.reveal(entry); err != nil {
+			return advance, err
+		}
+	}
+
+	picked, err := e.pickBotMenus(entry)
+	advance.BotsPlayed = append(advance.BotsPlayed, picked...)
+	if err != nil {
+		return advance, err
+	}
+	if menuPending(game) {
+		advance.WaitingOnMenu = true
+		return advance, nil
+	}
+	if err := passHands(game); err != nil {
+		return advance, err
+	}
+	if err := e.commit(entry, EventHandsPassed, nil); err != nil {
+		return advance, err
+	}
+	advance.Revealed = true
+
+	if game.RoundPhase != models.PhaseScoring {
+		return advance, nil
+	}
+	round := game.CurrentRound
+	if err := scoreRound(game); err != nil {
+		return advance, err
+	}
+	if err := e.commit(entry, EventRoundScored, nil); err != nil {
+		return advance, err
+	}
+	advance.RoundScored = round
+
+	if game.RoundPhase == models.PhaseRoundEnd {
+		dealErr := e.dealRound(entry)
+		if dealErr == nil {
+			return advance, nil
+		}
+		// Nothing else would move the game on, so it ends with the rounds already scored
+		result, err := e.endEarly(entry)
+		if err != nil {
+			return advance, fmt.Errorf("failed to deal round %d: %w; failed to end the game: %w", round+1, dealErr, err)
+		}
+		advance.Result = result
+		return advance, fmt.Errorf("%w after round %d: failed to deal the next round: %w", ErrEndedEarly, round, dealErr)
+	}
+
+	result, err := endGame(game)
+	if err != nil {
+		return advance, err
+	}
+	if err := e.commit(entry, EventGameEnded, nil); err != nil {
+		return advance, err
+	}
+	advance.Result = result
+	return advance, nil
+}
+
+// endEarly ends the game with the rounds already scored, when the next round can't be dealt
+// Callers must hold the game's lock
+func (e *Engine) endEarly(entry *gameEntry) (*GameResult, error) {
+	if err := endEarly(entry.game); err != nil {
+		return nil, err
+	}
+	if err := e.commit(entry, EventEndedEarly, nil); err != nil {
+		return nil, err
+	}
+
+	result, err := endGame(entry.game)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.commit(entry, EventGameEnded, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// endEarly makes the round just scored the game's last one
+func endEarly(game *models.Game) error {
+	if err := checkPhase(game, ActionEndEarly); err != nil {
+		return err
+	}
+
+	// Scoring already moved the counter past the round it scored
+	game.NumRounds = game.CurrentRound - 1
+	return setPhase(game, models.PhaseGameEnd)
+}
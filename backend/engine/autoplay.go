@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// AutoPlayPolicy chooses the card played on behalf of a player whose turn timed out
+type AutoPlayPolicy interface {
+	ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int
+}
+
+// FirstCardPolicy always plays the first card in the hand
+type FirstCardPolicy struct{}
+
+func (p FirstCardPolicy) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	return 0
+}
+
+// RandomCardPolicy plays a uniformly random card from the hand
+type RandomCardPolicy struct{}
+
+func (p RandomCardPolicy) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	return r.Intn(len(player.Hand))
+}
+
+// SetTurnTimeout sets the default time allowed per pick for new games (0 disables the timer)
+func (e *Engine) SetTurnTimeout(timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.turnTimeout = timeout
+}
+
+// setTurnDeadline starts the pick timer at start when the game is waiting on selections or Menu picks,
+// and clears it otherwise
+func setTurnDeadline(game *models.Game, start time.Time) {
+	if game.TurnTimeout <= 0 || (game.RoundPhase != models.PhaseSelecting && !menuPending(game)) {
+		game.TurnDeadline = nil
+		return
+	}
+
+	deadline := start.Add(game.TurnTimeout)
+	game.TurnDeadline = &deadline
+}
+
+// TurnDeadlines returns the pick deadline of every game waiting on one, by game ID
+// Games reloaded from a store keep their deadline, so a server can arm their timers again after a restart
+func (e *Engine) TurnDeadlines() map[string]time.Time {
+	deadlines := make(map[string]time.Time)
+	for _, entry := range e.entries() {
+		entry.mu.Lock()
+		if !entry.deleted && entry.game.TurnDeadline != nil {
+			deadlines[entry.game.ID] = *entry.game.TurnDeadline
+		}
+		entry.mu.Unlock()
+	}
+	return deadlines
+}
+
+// startsTurn reports whether an event begins a new pick; a reveal begins one when a Menu drew cards
+func startsTurn(eventType EventType) bool {
+	return eventType == EventRoundStarted || eventType == EventHandsPassed || eventType == EventRevealUndone ||
+		eventType == EventCardsRevealed
+}
+
+// AutoPlay selects a card for every player who hasn't picked yet, using the given policy,
+// or takes a Menu card for every player who hasn't taken theirs
+// Returns the IDs of the players a card was played for
+func (e *Engine) AutoPlay(gameID string, policy AutoPlayPolicy) ([]string, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+
+	played := []string{}
+	if menuPending(game) {
+		for _, player := range game.Players {
+			if len(player.MenuDraw) == 0 {
+				continue
+			}
+			if err := e.pickMenuFor(entry, player, policy, true); err != nil {
+				return played, err
+			}
+			played = append(played, player.ID)
+		}
+		return played, nil
+	}
+	if err := checkPhase(game, ActionPlayCard); err != nil {
+		return nil, err
+	}
+
+	for _, player := range game.Players {
+		if player.SelectedCard != nil || len(player.Hand) == 0 {
+			continue
+		}
+
+		if err := e.playFor(entry, player, policy, true); err != nil {
+			return played, err
+		}
+		played = append(played, player.ID)
+	}
+
+	return played, nil
+}
+
+// playFor plays the card the policy chooses on the player's behalf
+// An out-of-range choice falls back to the first card
+// Callers must hold the game's lock
+func (e *Engine) playFor(entry *gameEntry, player *models.Player, policy AutoPlayPolicy, autoPlayed bool) error {
+	game := entry.game
+	cardIndex := policy.ChooseCard(game, player, entry.rng)
+	if cardIndex < 0 || cardIndex >= len(player.Hand) {
+		cardIndex = 0
+	}
+
+	if err := playCard(game, player.ID, cardIndex, PlayOptions{}); err != nil {
+		return err
+	}
+	return e.commit(entry, EventCardPlayed, CardPlayedPayload{
+		PlayerID:   player.ID,
+		CardIndex:  cardIndex,
+		AutoPlayed: autoPlayed,
+	})
+}
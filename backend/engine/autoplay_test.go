@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestTurnDeadlineFollowsPhase tests that the pick timer only runs while players are selecting
+func TestTurnDeadlineFollowsPhase(t *testing.T) {
+	engine := NewEngine()
+	engine.SetTurnTimeout(30 * time.Second)
+
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	game := liveGame(t, engine, created.ID)
+	if game.TurnTimeout != 30*time.Second {
+		t.Fatalf("Expected turn timeout 30s, got %s", game.TurnTimeout)
+	}
+	if game.TurnDeadline != nil {
+		t.Error("Expected no deadline before the game starts")
+	}
+
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+	if game.TurnDeadline == nil {
+		t.Fatal("Expected a deadline once the round starts")
+	}
+	if remaining := time.Until(*game.TurnDeadline); remaining <= 0 || remaining > 30*time.Second {
+		t.Errorf("Expected deadline within 30s, got %s", remaining)
+	}
+
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	engine.PlayCard(game.ID, "p2", 0, false, nil)
+	engine.RevealCards(game.ID)
+	if game.TurnDeadline != nil {
+		t.Error("Expected the deadline to clear while cards are revealed")
+	}
+
+	engine.PassHands(game.ID)
+	if game.TurnDeadline == nil {
+		t.Error("Expected a new deadline after hands are passed")
+	}
+}
+
+// TestTurnTimeoutPerGame tests that a game can override the engine's turn timeout
+func TestTurnTimeoutPerGame(t *testing.T) {
+	engine := NewEngine()
+	engine.SetTurnTimeout(30 * time.Second)
+
+	off := time.Duration(0)
+	game, err := engine.CreateGameWithOptions([]string{"p1", "p2"}, GameOptions{TurnTimeout: &off})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+	if game.TurnDeadline != nil {
+		t.Error("Expected no deadline when the game disables the timer")
+	}
+
+	negative := -time.Second
+	if _, err := engine.CreateGameWithOptions([]string{"p3", "p4"}, GameOptions{TurnTimeout: &negative}); err == nil {
+		t.Error("Expected error for a negative turn timeout")
+	}
+}
+
+// lastCardPolicy always plays the last card, or an out-of-range index when invalid is set
+type lastCardPolicy struct {
+	invalid bool
+}
+
+func (p lastCardPolicy) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	if p.invalid {
+		return len(player.Hand)
+	}
+	return len(player.Hand) - 1
+}
+
+// TestAutoPlay tests that idle players get a card chosen by the policy
+func TestAutoPlay(t *testing.T) {
+	engine := NewEngine()
+	engine.SetTurnTimeout(time.Second)
+
+	created, _ := engine.CreateGame([]string{"p1", "p2", "p3"})
+	game := liveGame(t, engine, created.ID)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+
+	played, err := engine.AutoPlay(game.ID, lastCardPolicy{})
+	if err != nil {
+		t.Fatalf("AutoPlay failed: %v", err)
+	}
+	if len(played) != 2 || played[0] != "p2" || played[1] != "p3" {
+		t.Errorf("Expected auto-play for p2 and p3, got %v", played)
+	}
+
+	for _, player := range game.Players[1:] {
+		if player.SelectedCard == nil || *player.SelectedCard != len(player.Hand)-1 {
+			t.Errorf("Expected %s to play its last card, got %v", player.ID, player.SelectedCard)
+		}
+	}
+	if *game.Players[0].SelectedCard != 0 {
+		t.Error("Expected p1's own pick to be kept")
+	}
+
+	events, _ := engine.Events(game.ID)
+	autoPlayed := 0
+	for _, event := range events {
+		if event.Type != EventCardPlayed {
+			continue
+		}
+		var payload CardPlayedPayload
+		json.Unmarshal(event.Payload, &payload)
+		if payload.AutoPlayed {
+			autoPlayed++
+		}
+	}
+	if autoPlayed != 2 {
+		t.Errorf("Expected 2 auto-played events, got %d", autoPlayed)
+	}
+
+	// Replay reproduces the picks and the deadline
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay game: %v", err)
+	}
+	liveJSON, _ := json.Marshal(game)
+	replayedJSON, _ := json.Marshal(replayed)
+	if string(liveJSON) != string(replayedJSON) {
+		t.Errorf("Replayed game differs from live game\nlive:     %s\nreplayed: %s", liveJSON, replayedJSON)
+	}
+}
+
+// TestAutoPlayClampsInvalidChoice tests that a bad policy choice falls back to the first card
+func TestAutoPlayClampsInvalidChoice(t *testing.T) {
+	engine := NewEngine()
+
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	game := liveGame(t, engine, created.ID)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	if _, err := engine.AutoPlay(game.ID, lastCardPolicy{invalid: true}); err != nil {
+		t.Fatalf("AutoPlay failed: %v", err)
+	}
+	for _, player := range game.Players {
+		if player.SelectedCard == nil || *player.SelectedCard != 0 {
+			t.Errorf("Expected %s to fall back to the first card, got %v", player.ID, player.SelectedCard)
+		}
+	}
+}
+
+// TestAutoPlayRequiresSelectingPhase tests that auto-play is rejected outside of picking
+func TestAutoPlayRequiresSelectingPhase(t *testing.T) {
+	engine := NewEngine()
+
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	if _, err := engine.AutoPlay(game.ID, FirstCardPolicy{}); err == nil {
+		t.Error("Expected error auto-playing before the game starts")
+	}
+	if _, err := engine.AutoPlay("missing", FirstCardPolicy{}); err != ErrGameNotFound {
+		t.Errorf("Expected ErrGameNotFound, got %v", err)
+	}
+}
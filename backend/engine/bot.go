@@ -0,0 +1,303 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/sushi-go-game/backend/models"
+	"github.com/sushi-go-game/backend/scoring"
+)
+
+// Bot strategy names accepted by AddBot
+const (
+	BotStrategyRandom        = "random"
+	BotStrategyGreedy        = "greedy"
+	BotStrategySetCompletion = "set_completion"
+)
+
+// DefaultBotStrategy is used when no strategy is requested
+const DefaultBotStrategy = BotStrategyGreedy
+
+var (
+	ErrUnknownBotStrategy = errors.New("unknown bot strategy")
+	ErrGameAlreadyStarted = errors.New("game has already started")
+	ErrNotABot            = errors.New("player is not a bot")
+)
+
+// BotStrategy decides which card a bot plays each turn
+// Every strategy can also serve as the auto-play policy for idle humans
+type BotStrategy interface {
+	AutoPlayPolicy
+	Name() string
+}
+
+// botStrategies maps strategy names to constructors
+var botStrategies = map[string]func() BotStrategy{
+	BotStrategyRandom:        func() BotStrategy { return RandomStrategy{} },
+	BotStrategyGreedy:        func() BotStrategy { return GreedyStrategy{} },
+	BotStrategySetCompletion: func() BotStrategy { return SetCompletionStrategy{} },
+}
+
+// NewBotStrategy returns the strategy registered under name
+func NewBotStrategy(name string) (BotStrategy, error) {
+	constructor, exists := botStrategies[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBotStrategy, name)
+	}
+	return constructor(), nil
+}
+
+// BotStrategyNames returns the registered strategy names in sorted order
+func BotStrategyNames() []string {
+	names := make([]string, 0, len(botStrategies))
+	for name := range botStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bot is a computer player occupying a seat in a game
+type Bot struct {
+	PlayerID string
+	Strategy BotStrategy
+}
+
+// botFor returns the bot seated as player
+func botFor(player *models.Player) (*Bot, error) {
+	if !player.IsBot {
+		return nil, ErrNotABot
+	}
+	strategy, err := NewBotStrategy(player.BotStrategy)
+	if err != nil {
+		return nil, err
+	}
+	return &Bot{PlayerID: player.ID, Strategy: strategy}, nil
+}
+
+// ChooseCard picks the index of the card the bot plays this turn
+func (b *Bot) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	return b.Strategy.ChooseCard(game, player, r)
+}
+
+// AddBot seats a new bot using the named strategy in a game that hasn't started
+func (e *Engine) AddBot(gameID, strategyName string) (*models.Player, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+	if game.RoundPhase != models.PhaseWaitingForPlayers {
+		return nil, ErrGameAlreadyStarted
+	}
+
+	if strategyName == "" {
+		strategyName = DefaultBotStrategy
+	}
+	if _, err := NewBotStrategy(strategyName); err != nil {
+		return nil, err
+	}
+
+	bots := 0
+	for _, player := range game.Players {
+		if player.IsBot {
+			bots++
+		}
+	}
+
+	payload := PlayerPayload{
+		PlayerID: fmt.Sprintf("bot-%x", entry.rng.Int63()),
+		Name:     fmt.Sprintf("Bot %d (%s)", bots+1, strategyName),
+		Strategy: strategyName,
+	}
+	if err := addBot(game, payload.PlayerID, payload.Name, payload.Strategy); err != nil {
+		return nil, err
+	}
+	if err := e.commit(entry, EventBotAdded, payload); err != nil {
+		return nil, err
+	}
+
+	return findPlayer(game, payload.PlayerID), nil
+}
+
+// addBot seats a bot player in the game
+// Bots never become host
+func addBot(game *models.Game, playerID, name, strategy string) error {
+	player := newPlayer(playerID)
+	player.Name = name
+	player.IsBot = true
+	player.BotStrategy = strategy
+	player.Ready = true
+	return seatPlayer(game, player)
+}
+
+// PlayBots selects a card for every bot that hasn't picked yet, and for the dummy when a bot picks for it
+// Returns the IDs of the players a card was played for
+func (e *Engine) PlayBots(gameID string) ([]string, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+	if err := checkPhase(game, ActionPlayCard); err != nil {
+		return nil, err
+	}
+
+	return e.playBots(entry)
+}
+
+// playBots picks for the bots that haven't picked yet
+// Callers must hold the game's lock
+func (e *Engine) playBots(entry *gameEntry) ([]string, error) {
+	game := entry.game
+	played := []string{}
+	for _, player := range game.Players {
+		seat := player
+		if player.IsDummy {
+			seat = DummyPicker(game)
+		}
+		if seat == nil || !seat.IsBot || player.SelectedCard != nil || len(player.Hand) == 0 {
+			continue
+		}
+
+		bot, err := botFor(seat)
+		if err != nil {
+			return played, err
+		}
+		if err := e.playFor(entry, player, bot, false); err != nil {
+			return played, err
+		}
+		played = append(played, player.ID)
+	}
+
+	return played, nil
+}
+
+// RandomStrategy plays a uniformly random card
+type RandomStrategy struct{}
+
+func (s RandomStrategy) Name() string { return BotStrategyRandom }
+
+func (s RandomStrategy) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	return RandomCardPolicy{}.ChooseCard(game, player, r)
+}
+
+// GreedyStrategy plays the card that raises its round score the most right now
+// Ties go to the earliest card in the hand
+type GreedyStrategy struct{}
+
+func (s GreedyStrategy) Name() string { return BotStrategyGreedy }
+
+func (s GreedyStrategy) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	current := scoring.RegistryFor(game.TieMode).ScoreRound(player, game.Players).Total
+
+	best, bestGain := 0, -1
+	for i, card := range player.Hand {
+		gain := immediateScore(game, player, card) - current
+		if gain > bestGain {
+			best, bestGain = i, gain
+		}
+	}
+	return best
+}
+
+// immediateScore scores the player's round as if card had just been added to their collection
+func immediateScore(game *models.Game, player *models.Player, card models.Card) int {
+	trial := *player
+	trial.Collection = append(append([]models.Card{}, player.Collection...), card)
+
+	players := make([]*models.Player, len(game.Players))
+	for i, p := range game.Players {
+		if p.ID == player.ID {
+			players[i] = &trial
+		} else {
+			players[i] = p
+		}
+	}
+	return scoring.RegistryFor(game.TieMode).ScoreRound(&trial, players).Total
+}
+
+// SetCompletionStrategy values cards by how far they move its sets toward scoring,
+// so it will start a Sashimi set or hold a Wasabi even when they score nothing yet
+type SetCompletionStrategy struct{}
+
+func (s SetCompletionStrategy) Name() string { return BotStrategySetCompletion }
+
+func (s SetCompletionStrategy) ChooseCard(game *models.Game, player *models.Player, r *rand.Rand) int {
+	best, bestValue := 0, -1.0
+	for i, card := range player.Hand {
+		value := setValue(game, player, card)
+		if value > bestValue {
+			best, bestValue = i, value
+		}
+	}
+	return best
+}
+
+// setValue estimates the points a card is worth toward the player's sets
+func setValue(game *models.Game, player *models.Player, card models.Card) float64 {
+	counts := make(map[models.CardType]int)
+	unusedWasabi := 0
+	for _, c := range player.Collection {
+		counts[c.Type]++
+		if c.Type == models.CardTypeWasabi {
+			unusedWasabi++
+		} else if c.Type == models.CardTypeNigiri && unusedWasabi > 0 {
+			unusedWasabi--
+		}
+	}
+
+	// Picks still to come this round after this one
+	picksLeft := len(player.Hand) - 1
+
+	switch card.Type {
+	case models.CardTypeTempura:
+		// Completing a pair is worth 5; starting one is worth half that
+		if counts[models.CardTypeTempura]%2 == 1 {
+			return 5
+		}
+		return 2.5
+	case models.CardTypeSashimi:
+		// Each card in a set of three is worth more the closer it is to completion
+		switch counts[models.CardTypeSashimi] % 3 {
+		case 2:
+			return 10
+		case 1:
+			return 5
+		default:
+			return 10.0 / 3
+		}
+	case models.CardTypeDumpling:
+		// Marginal value of the next dumpling: 1, 2, 3, 4, 5, then nothing
+		if n := counts[models.CardTypeDumpling]; n < 5 {
+			return float64(n + 1)
+		}
+		return 0
+	case models.CardTypeNigiri:
+		if unusedWasabi > 0 {
+			return float64(card.Value * 3)
+		}
+		return float64(card.Value)
+	case models.CardTypeWasabi:
+		// Only useful if a nigiri can still follow it
+		if picksLeft > 0 {
+			return 3
+		}
+		return 0
+	case models.CardTypeMakiRoll:
+		return float64(card.Value)
+	case models.CardTypePudding:
+		return 1.5
+	case models.CardTypeChopsticks:
+		if picksLeft > 2 {
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
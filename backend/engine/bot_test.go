@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestAddBot tests seating bots in a waiting game
+func TestAddBot(t *testing.T) {
+	engine := NewEngine()
+	created, _ := engine.CreateGame([]string{"host"})
+	game := liveGame(t, engine, created.ID)
+
+	bot, err := engine.AddBot(game.ID, BotStrategySetCompletion)
+	if err != nil {
+		t.Fatalf("Failed to add bot: %v", err)
+	}
+	if !bot.IsBot || bot.BotStrategy != BotStrategySetCompletion {
+		t.Errorf("Expected a set_completion bot, got %+v", bot)
+	}
+	if bot.Name != "Bot 1 (set_completion)" {
+		t.Errorf("Expected bot name 'Bot 1 (set_completion)', got %s", bot.Name)
+	}
+
+	defaultBot, err := engine.AddBot(game.ID, "")
+	if err != nil {
+		t.Fatalf("Failed to add bot: %v", err)
+	}
+	if defaultBot.BotStrategy != DefaultBotStrategy {
+		t.Errorf("Expected default strategy %s, got %s", DefaultBotStrategy, defaultBot.BotStrategy)
+	}
+	if len(game.Players) != 3 {
+		t.Errorf("Expected 3 players, got %d", len(game.Players))
+	}
+
+	if _, err := engine.AddBot(game.ID, "psychic"); !errors.Is(err, ErrUnknownBotStrategy) {
+		t.Errorf("Expected ErrUnknownBotStrategy, got %v", err)
+	}
+
+	engine.StartGame(game.ID)
+	if _, err := engine.AddBot(game.ID, BotStrategyRandom); err != ErrGameAlreadyStarted {
+		t.Errorf("Expected ErrGameAlreadyStarted, got %v", err)
+	}
+}
+
+// TestAddBotRespectsPlayerLimit tests that bots can't overfill a game
+func TestAddBotRespectsPlayerLimit(t *testing.T) {
+	engine := NewEngine()
+	game, _ := engine.CreateGame([]string{"host"})
+
+	for i := 0; i < 4; i++ {
+		if _, err := engine.AddBot(game.ID, BotStrategyRandom); err != nil {
+			t.Fatalf("Failed to add bot %d: %v", i+1, err)
+		}
+	}
+	if _, err := engine.AddBot(game.ID, BotStrategyRandom); err != ErrGameFull {
+		t.Errorf("Expected ErrGameFull, got %v", err)
+	}
+}
+
+// TestPlayBots tests that only bots that haven't picked are played, through the normal event path
+func TestPlayBots(t *testing.T) {
+	engine := NewEngine()
+	game, _ := engine.CreateGame([]string{"host"})
+	engine.AddBot(game.ID, BotStrategyGreedy)
+	engine.AddBot(game.ID, BotStrategyRandom)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	played, err := engine.PlayBots(game.ID)
+	if err != nil {
+		t.Fatalf("PlayBots failed: %v", err)
+	}
+	if len(played) != 2 {
+		t.Errorf("Expected 2 bots to play, got %v", played)
+	}
+	if game.Players[0].SelectedCard != nil {
+		t.Error("Expected the human to still be picking")
+	}
+
+	// A second call has nothing left to do
+	played, _ = engine.PlayBots(game.ID)
+	if len(played) != 0 {
+		t.Errorf("Expected no bots to play twice, got %v", played)
+	}
+
+	events, _ := engine.Events(game.ID)
+	cardEvents := 0
+	for _, event := range events {
+		if event.Type == EventCardPlayed {
+			cardEvents++
+		}
+	}
+	if cardEvents != 2 {
+		t.Errorf("Expected 2 card_played events, got %d", cardEvents)
+	}
+}
+
+// TestBotOnlyGameReplays tests that a game played entirely by bots completes and replays exactly
+func TestBotOnlyGameReplays(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(5)
+
+	created, _ := engine.CreateGame(nil)
+	game := liveGame(t, engine, created.ID)
+	for _, strategy := range BotStrategyNames() {
+		if _, err := engine.AddBot(game.ID, strategy); err != nil {
+			t.Fatalf("Failed to add %s bot: %v", strategy, err)
+		}
+	}
+	engine.StartGame(game.ID)
+
+	for game.RoundPhase != models.PhaseGameEnd {
+		if err := engine.StartRound(game.ID); err != nil {
+			t.Fatalf("Failed to start round: %v", err)
+		}
+		for game.RoundPhase == models.PhaseSelecting {
+			if _, err := engine.PlayBots(game.ID); err != nil {
+				t.Fatalf("PlayBots failed: %v", err)
+			}
+			engine.RevealCards(game.ID)
+			engine.PassHands(game.ID)
+		}
+		engine.ScoreRound(game.ID)
+	}
+	if _, err := engine.EndGame(game.ID); err != nil {
+		t.Fatalf("Failed to end game: %v", err)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay game: %v", err)
+	}
+	liveJSON, _ := json.Marshal(game)
+	replayedJSON, _ := json.Marshal(replayed)
+	if string(liveJSON) != string(replayedJSON) {
+		t.Errorf("Replayed game differs from live game\nlive:     %s\nreplayed: %s", liveJSON, replayedJSON)
+	}
+}
+
+// botTestGame builds a selecting-phase game where the first player holds hand and has collected collection
+func botTestGame(hand, collection []models.Card) (*models.Game, *models.Player) {
+	player := &models.Player{ID: "bot", Hand: hand, Collection: collection}
+	other := &models.Player{ID: "other", Hand: hand, Collection: []models.Card{}}
+	game := &models.Game{
+		ID:         "g1",
+		Players:    []*models.Player{player, other},
+		RoundPhase: models.PhaseSelecting,
+	}
+	return game, player
+}
+
+// TestBotStrategies tests the card each strategy prefers in simple positions
+func TestBotStrategies(t *testing.T) {
+	tempura := models.Card{ID: "t", Type: models.CardTypeTempura}
+	sashimi := models.Card{ID: "s", Type: models.CardTypeSashimi}
+	squid := models.Card{ID: "sq", Type: models.CardTypeNigiri, Variant: "Squid", Value: 3}
+	egg := models.Card{ID: "e", Type: models.CardTypeNigiri, Variant: "Egg", Value: 1}
+	wasabi := models.Card{ID: "w", Type: models.CardTypeWasabi}
+	r := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		name       string
+		strategy   BotStrategy
+		hand       []models.Card
+		collection []models.Card
+		expected   int
+	}{
+		{"greedy completes tempura pair", GreedyStrategy{}, []models.Card{squid, tempura}, []models.Card{tempura}, 1},
+		{"greedy takes best nigiri", GreedyStrategy{}, []models.Card{egg, sashimi, squid}, nil, 2},
+		{"greedy uses wasabi", GreedyStrategy{}, []models.Card{tempura, egg}, []models.Card{wasabi}, 1},
+		{"set completion starts sashimi over egg", SetCompletionStrategy{}, []models.Card{egg, sashimi}, nil, 1},
+		{"set completion finishes sashimi", SetCompletionStrategy{}, []models.Card{squid, tempura, sashimi}, []models.Card{sashimi, sashimi}, 2},
+		{"set completion holds wasabi for later", SetCompletionStrategy{}, []models.Card{egg, wasabi, tempura}, nil, 1},
+		{"set completion squid on wasabi", SetCompletionStrategy{}, []models.Card{sashimi, squid}, []models.Card{wasabi}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, player := botTestGame(tt.hand, tt.collection)
+			if got := tt.strategy.ChooseCard(game, player, r); got != tt.expected {
+				t.Errorf("Expected card %d, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	// Random always stays within the hand
+	game, player := botTestGame([]models.Card{egg, sashimi, squid}, nil)
+	for i := 0; i < 50; i++ {
+		if got := (RandomStrategy{}).ChooseCard(game, player, r); got < 0 || got >= 3 {
+			t.Fatalf("Random strategy chose out-of-range card %d", got)
+		}
+	}
+}
@@ -1,22 +1,52 @@
 package engine
 
-import "github.com/sushi-go-game/backend/models"
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// ErrDeckExhausted is returned when the game's deck can't cover another deal
+var ErrDeckExhausted = errors.New("not enough cards left in the deck")
 
 // CardDealer is an interface for dealing cards to players
 // This allows custom card dealing for testing purposes
+// Dealers deal to game.Players for game.CurrentRound and may keep undealt cards in game.Deck
+// The engine passes a random source derived from the game's seed so deals are reproducible
 type CardDealer interface {
-	DealCards(players []*models.Player, round int, cardsPerHand int) error
+	DealCards(game *models.Game, cardsPerHand int, r *rand.Rand) error
 }
 
-// DefaultDealer uses the standard shuffled deck dealing
+// DefaultDealer shuffles a single deck at the start of the game and deals
+// every round from what is left of it, as in the physical game
+// Sushi Go Party! games reshuffle the menu each round with that round's desserts
+// Games with a deck definition are dealt from it instead of the original deck
 type DefaultDealer struct{}
 
-func (d *DefaultDealer) DealCards(players []*models.Player, round int, cardsPerHand int) error {
-	// Initialize and shuffle deck
-	deck := InitializeDeck()
-	deck = ShuffleDeck(deck)
+func (d *DefaultDealer) DealCards(game *models.Game, cardsPerHand int, r *rand.Rand) error {
+	if game.Menu != nil {
+		game.Deck = ShuffleDeck(PartyRoundDeck(game), r)
+	} else if game.CurrentRound <= 1 {
+		// Shuffle a fresh deck only for the first round
+		deck := &DefaultDeck
+		if game.DeckDefinition != nil {
+			deck = game.DeckDefinition
+		}
+		game.Deck = ShuffleDeck(BuildDeck(deck), r)
+	}
+
+	needed := cardsPerHand * len(game.Players)
+	if len(game.Deck) < needed {
+		return fmt.Errorf("%w: round %d needs %d, have %d", ErrDeckExhausted, game.CurrentRound, needed, len(game.Deck))
+	}
 
-	// Deal cards to players
-	_, _, err := DealCardsCustom(deck, players, cardsPerHand)
-	return err
+	// Deal cards to players and keep the remainder for later rounds
+	_, remaining, err := DealCardsCustom(game.Deck, game.Players, cardsPerHand)
+	if err != nil {
+		return err
+	}
+	game.Deck = remaining
+	return nil
 }
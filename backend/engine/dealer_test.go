@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestDefaultDealerUsesOneDeckPerGame tests that later rounds are dealt from the same deck
+func TestDefaultDealerUsesOneDeckPerGame(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(11)
+
+	game, _ := engine.CreateGame([]string{"p1", "p2", "p3"})
+	engine.StartGame(game.ID)
+
+	seen := make(map[string]bool)
+	totalDeck := len(InitializeDeck())
+
+	for round := 1; round <= 3; round++ {
+		if err := engine.StartRound(game.ID); err != nil {
+			t.Fatalf("Round %d: failed to start round: %v", round, err)
+		}
+
+		game = liveGame(t, engine, game.ID)
+		for _, player := range game.Players {
+			for _, card := range player.Hand {
+				if seen[card.ID] {
+					t.Fatalf("Round %d: card %s was dealt twice in one game", round, card.ID)
+				}
+				seen[card.ID] = true
+			}
+		}
+
+		expectedRemaining := totalDeck - round*3*game.CardsPerHand
+		if len(game.Deck) != expectedRemaining {
+			t.Errorf("Round %d: expected %d cards left in deck, got %d", round, expectedRemaining, len(game.Deck))
+		}
+
+		// Skip straight to the next round
+		game.CurrentRound++
+		game.RoundPhase = models.PhaseRoundEnd
+	}
+}
+
+// TestDefaultDealerDeckExhausted tests the error when the deck can't cover a deal
+func TestDefaultDealerDeckExhausted(t *testing.T) {
+	dealer := &DefaultDealer{}
+	game := &models.Game{
+		CurrentRound: 2,
+		Players:      []*models.Player{{ID: "p1"}, {ID: "p2"}},
+		Deck:         InitializeDeck()[:15],
+	}
+
+	err := dealer.DealCards(game, 10, roundRand(game))
+	if !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected ErrDeckExhausted, got %v", err)
+	}
+}
+
+// TestDefaultDeckTooSmall tests that a config the deck can't cover for the smallest table is rejected
+func TestDefaultDeckTooSmall(t *testing.T) {
+	engine := NewEngineWithConfig(nil, 10, CardsPerHandByPlayerCount)
+
+	if err := engine.CheckDeck(); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected CheckDeck to refuse 10 rounds, got %v", err)
+	}
+	if _, err := engine.CreateGame([]string{"p1", "p2"}); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected ErrDeckExhausted creating a game, got %v", err)
+	}
+}
+
+// TestDefaultDealerRunsOutPastTheCap tests that the dealer runs out when a game outgrows the table its deck covers
+func TestDefaultDealerRunsOutPastTheCap(t *testing.T) {
+	engine := NewEngineWithConfig(nil, 3, 10)
+	engine.SetSeed(6)
+
+	created, err := engine.CreateGame([]string{"p1", "p2", "p3"})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if err := engine.StartGame(created.ID); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	// A fourth round of ten-card hands needs 30 of the 18 cards the first three leave
+	game := liveGame(t, engine, created.ID)
+	game.NumRounds = 4
+	for round := 1; round <= 3; round++ {
+		if err := engine.StartRound(created.ID); err != nil {
+			t.Fatalf("Round %d: failed to start round: %v", round, err)
+		}
+		game.CurrentRound++
+		game.RoundPhase = models.PhaseRoundEnd
+	}
+
+	if err := engine.StartRound(created.ID); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected ErrDeckExhausted dealing round 4, got %v", err)
+	}
+}
+
+// TestReplayRestoresDeck tests that the remaining deck survives a replay
+func TestReplayRestoresDeck(t *testing.T) {
+	engine := NewEngine()
+
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+
+	live, _ := engine.GetGame(game.ID)
+	if len(replayed.Deck) != len(live.Deck) || len(live.Deck) == 0 {
+		t.Fatalf("Expected replayed deck of %d cards, got %d", len(live.Deck), len(replayed.Deck))
+	}
+	for i := range live.Deck {
+		if live.Deck[i].ID != replayed.Deck[i].ID {
+			t.Fatalf("Replayed deck differs at position %d", i)
+		}
+	}
+}
+
+// TestDefaultDeckHouseRules tests that house-rule hands and rounds cap new games at the table the single deck covers
+func TestDefaultDeckHouseRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		numRounds    int
+		cardsPerHand int
+		seats        int // Largest table the 108 cards cover every round for
+	}{
+		{"ten-card hands", 3, 10, 3},
+		{"four rounds", 4, CardsPerHandByPlayerCount, 3},
+		{"short rounds", 3, 7, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngineWithConfig(nil, tt.numRounds, tt.cardsPerHand)
+			engine.SetSeed(4)
+			if err := engine.CheckDeck(); err != nil {
+				t.Errorf("Expected a config that seats %d to pass CheckDeck, got %v", tt.seats, err)
+			}
+			if seats := engine.DeckSeats(); seats != tt.seats {
+				t.Errorf("Expected the deck to seat %d, got %d", tt.seats, seats)
+			}
+
+			players := []string{"p1", "p2", "p3", "p4", "p5"}
+			if tt.seats < len(players) {
+				if _, err := engine.CreateGame(players[:tt.seats+1]); err != ErrTooManyPlayers {
+					t.Errorf("Expected ErrTooManyPlayers for %d players, got %v", tt.seats+1, err)
+				}
+			}
+
+			game, err := engine.CreateGame(players[:tt.seats])
+			if err != nil {
+				t.Fatalf("Failed to create game: %v", err)
+			}
+			result := playFullGame(t, engine, game.ID)
+			if len(result.Rankings) != tt.seats {
+				t.Errorf("Expected %d ranked players, got %d", tt.seats, len(result.Rankings))
+			}
+		})
+	}
+}
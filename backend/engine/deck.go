@@ -1,126 +1,21 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
-	"time"
 
 	"github.com/sushi-go-game/backend/models"
 )
 
 // InitializeDeck creates a new deck with the correct card distribution for Sushi Go!
 func InitializeDeck() []models.Card {
-	deck := []models.Card{}
-	cardID := 0
-
-	// Maki Rolls: 6 cards with 1 icon, 12 cards with 2 icons, 8 cards with 3 icons
-	for i := 0; i < 6; i++ {
-		deck = append(deck, models.Card{
-			ID:    fmt.Sprintf("maki_%d", cardID),
-			Type:  models.CardTypeMakiRoll,
-			Value: 1,
-		})
-		cardID++
-	}
-	for i := 0; i < 12; i++ {
-		deck = append(deck, models.Card{
-			ID:    fmt.Sprintf("maki_%d", cardID),
-			Type:  models.CardTypeMakiRoll,
-			Value: 2,
-		})
-		cardID++
-	}
-	for i := 0; i < 8; i++ {
-		deck = append(deck, models.Card{
-			ID:    fmt.Sprintf("maki_%d", cardID),
-			Type:  models.CardTypeMakiRoll,
-			Value: 3,
-		})
-		cardID++
-	}
-
-	// Tempura: 14 cards
-	for i := 0; i < 14; i++ {
-		deck = append(deck, models.Card{
-			ID:   fmt.Sprintf("tempura_%d", i),
-			Type: models.CardTypeTempura,
-		})
-	}
-
-	// Sashimi: 14 cards
-	for i := 0; i < 14; i++ {
-		deck = append(deck, models.Card{
-			ID:   fmt.Sprintf("sashimi_%d", i),
-			Type: models.CardTypeSashimi,
-		})
-	}
-
-	// Dumplings: 14 cards
-	for i := 0; i < 14; i++ {
-		deck = append(deck, models.Card{
-			ID:   fmt.Sprintf("dumpling_%d", i),
-			Type: models.CardTypeDumpling,
-		})
-	}
-
-	// Nigiri: 5 Squid (3 points), 10 Salmon (2 points), 5 Egg (1 point)
-	for i := 0; i < 5; i++ {
-		deck = append(deck, models.Card{
-			ID:      fmt.Sprintf("nigiri_squid_%d", i),
-			Type:    models.CardTypeNigiri,
-			Variant: "Squid",
-			Value:   3,
-		})
-	}
-	for i := 0; i < 10; i++ {
-		deck = append(deck, models.Card{
-			ID:      fmt.Sprintf("nigiri_salmon_%d", i),
-			Type:    models.CardTypeNigiri,
-			Variant: "Salmon",
-			Value:   2,
-		})
-	}
-	for i := 0; i < 5; i++ {
-		deck = append(deck, models.Card{
-			ID:      fmt.Sprintf("nigiri_egg_%d", i),
-			Type:    models.CardTypeNigiri,
-			Variant: "Egg",
-			Value:   1,
-		})
-	}
-
-	// Wasabi: 6 cards
-	for i := 0; i < 6; i++ {
-		deck = append(deck, models.Card{
-			ID:   fmt.Sprintf("wasabi_%d", i),
-			Type: models.CardTypeWasabi,
-		})
-	}
-
-	// Chopsticks: 4 cards
-	for i := 0; i < 4; i++ {
-		deck = append(deck, models.Card{
-			ID:   fmt.Sprintf("chopsticks_%d", i),
-			Type: models.CardTypeChopsticks,
-		})
-	}
-
-	// Pudding: 10 cards
-	for i := 0; i < 10; i++ {
-		deck = append(deck, models.Card{
-			ID:   fmt.Sprintf("pudding_%d", i),
-			Type: models.CardTypePudding,
-		})
-	}
-
-	return deck
+	return BuildDeck(&DefaultDeck)
 }
 
 // ShuffleDeck shuffles the deck using Fisher-Yates algorithm
-func ShuffleDeck(deck []models.Card) []models.Card {
-	// Create a new random source with current time as seed
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
+// The same random source state always produces the same order
+func ShuffleDeck(deck []models.Card, r *rand.Rand) []models.Card {
 	// Create a copy to avoid modifying the original
 	shuffled := make([]models.Card, len(deck))
 	copy(shuffled, deck)
@@ -150,6 +45,30 @@ func GetCardsPerPlayer(playerCount int) (int, error) {
 	}
 }
 
+// GetPartyCardsPerPlayer returns the Sushi Go Party! hand size for a player count
+func GetPartyCardsPerPlayer(playerCount int) (int, error) {
+	switch playerCount {
+	case 2, 3:
+		return 10, nil
+	case 4, 5:
+		return 9, nil
+	case 6, 7:
+		return 8, nil
+	case 8:
+		return 7, nil
+	default:
+		return 0, fmt.Errorf("invalid player count: %d (must be 2-8)", playerCount)
+	}
+}
+
+// CardsPerPlayerFor returns the official hand size for a game's deck and a player count
+func CardsPerPlayerFor(game *models.Game, playerCount int) (int, error) {
+	if game.Menu != nil {
+		return GetPartyCardsPerPlayer(playerCount)
+	}
+	return GetCardsPerPlayer(playerCount)
+}
+
 // DealCards deals cards to players based on player count
 // Returns the updated players with cards in their hands and the remaining deck
 func DealCards(deck []models.Card, players []*models.Player) ([]*models.Player, []models.Card, error) {
@@ -181,11 +100,21 @@ func DealCards(deck []models.Card, players []*models.Player) ([]*models.Player,
 
 // DealCardsCustom deals a specific number of cards to each player
 // Returns the updated players with cards in their hands and the remaining deck
+// It deals one round from whatever cards it is given, so it only checks they cover this deal
+// (ErrDeckExhausted otherwise); it can't see the game's menu or rounds. Whether a menu or deck
+// definition lasts the whole game is checked once, by checkDeckSize, when the game starts
 func DealCardsCustom(deck []models.Card, players []*models.Player, cardsPerPlayer int) ([]*models.Player, []models.Card, error) {
 	playerCount := len(players)
+	if playerCount == 0 {
+		return nil, nil, errors.New("no players to deal to")
+	}
+	if cardsPerPlayer <= 0 {
+		return nil, nil, fmt.Errorf("invalid hand size: %d", cardsPerPlayer)
+	}
+
 	totalCardsNeeded := cardsPerPlayer * playerCount
 	if len(deck) < totalCardsNeeded {
-		return nil, nil, fmt.Errorf("not enough cards in deck: need %d, have %d", totalCardsNeeded, len(deck))
+		return nil, nil, fmt.Errorf("%w: need %d, have %d", ErrDeckExhausted, totalCardsNeeded, len(deck))
 	}
 
 	// Deal cards to each player
@@ -0,0 +1,276 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sushi-go-game/backend/models"
+	"github.com/sushi-go-game/backend/scoring"
+)
+
+// ErrInvalidDeck is returned when a deck definition can't be played
+var ErrInvalidDeck = errors.New("invalid deck")
+
+// DefaultDeck is the original Sushi Go! deck of 108 cards
+var DefaultDeck = models.DeckDefinition{
+	Name: "Sushi Go!",
+	Cards: []models.DeckEntry{
+		{Type: models.CardTypeMakiRoll, Value: 1, Count: 6},
+		{Type: models.CardTypeMakiRoll, Value: 2, Count: 12},
+		{Type: models.CardTypeMakiRoll, Value: 3, Count: 8},
+		{Type: models.CardTypeTempura, Count: 14},
+		{Type: models.CardTypeSashimi, Count: 14},
+		{Type: models.CardTypeDumpling, Count: 14},
+		{Type: models.CardTypeNigiri, Variant: "Squid", Count: 5},
+		{Type: models.CardTypeNigiri, Variant: "Salmon", Count: 10},
+		{Type: models.CardTypeNigiri, Variant: "Egg", Count: 5},
+		{Type: models.CardTypeWasabi, Count: 6},
+		{Type: models.CardTypeChopsticks, Count: 4},
+		{Type: models.CardTypePudding, Count: 10},
+	},
+}
+
+// nigiriValues are the points of each Nigiri fish
+var nigiriValues = map[string]int{"Squid": 3, "Salmon": 2, "Egg": 1}
+
+// rollIcons are the smallest and largest icon counts printed on each roll
+var rollIcons = map[models.CardType][2]int{
+	models.CardTypeMakiRoll: {1, 3},
+	models.CardTypeUramaki:  {3, 5},
+}
+
+// deckCardType reports whether a custom deck may hold a card type
+// Pudding is the only dessert a deck game scores
+func deckCardType(cardType models.CardType) bool {
+	if cardType == models.CardTypeNigiri || cardType == models.CardTypePudding {
+		return true
+	}
+	return containsCardType(MenuRolls, cardType) ||
+		containsCardType(MenuAppetizers, cardType) ||
+		containsCardType(MenuSpecials, cardType)
+}
+
+// LoadDeckDefinition reads a deck definition from a .yaml, .yml or .json file and validates it
+func LoadDeckDefinition(path string) (*models.DeckDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var deck models.DeckDefinition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&deck)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&deck)
+	default:
+		return nil, fmt.Errorf("%w: %s is not a .yaml, .yml or .json file", ErrInvalidDeck, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidDeck, path, err)
+	}
+
+	if err := ValidateDeckDefinition(&deck); err != nil {
+		return nil, err
+	}
+	return &deck, nil
+}
+
+// ValidateDeckDefinition checks that every card in a deck can be played and scored
+func ValidateDeckDefinition(deck *models.DeckDefinition) error {
+	if deck == nil || len(deck.Cards) == 0 {
+		return fmt.Errorf("%w: no cards", ErrInvalidDeck)
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range deck.Cards {
+		if !deckCardType(entry.Type) {
+			return fmt.Errorf("%w: %q cards can't be played in a custom deck", ErrInvalidDeck, entry.Type)
+		}
+		if entry.Count <= 0 {
+			return fmt.Errorf("%w: %s needs a positive count, got %d", ErrInvalidDeck, entry.Type, entry.Count)
+		}
+		if err := validateDeckEntry(entry); err != nil {
+			return err
+		}
+
+		key := deckEntryPrefix(entry)
+		if seen[key] {
+			return fmt.Errorf("%w: %s is listed more than once", ErrInvalidDeck, key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// validateDeckEntry checks the variant and value of one kind of card
+func validateDeckEntry(entry models.DeckEntry) error {
+	if icons, ok := rollIcons[entry.Type]; ok {
+		if entry.Value < icons[0] || entry.Value > icons[1] {
+			return fmt.Errorf("%w: %s needs %d to %d icons, got %d", ErrInvalidDeck, entry.Type, icons[0], icons[1], entry.Value)
+		}
+		if entry.Variant != "" {
+			return fmt.Errorf("%w: %s has no variants", ErrInvalidDeck, entry.Type)
+		}
+		return nil
+	}
+
+	switch entry.Type {
+	case models.CardTypeNigiri:
+		value, ok := nigiriValues[entry.Variant]
+		if !ok {
+			return fmt.Errorf("%w: unknown Nigiri %q (Squid, Salmon or Egg)", ErrInvalidDeck, entry.Variant)
+		}
+		if entry.Value != 0 && entry.Value != value {
+			return fmt.Errorf("%w: %s Nigiri are worth %d, got %d", ErrInvalidDeck, entry.Variant, value, entry.Value)
+		}
+	case models.CardTypeOnigiri:
+		found := false
+		for _, shape := range scoring.OnigiriShapes {
+			found = found || shape == entry.Variant
+		}
+		if !found {
+			return fmt.Errorf("%w: unknown Onigiri shape %q", ErrInvalidDeck, entry.Variant)
+		}
+		if entry.Value != 0 {
+			return fmt.Errorf("%w: Onigiri have no value", ErrInvalidDeck)
+		}
+	default:
+		if entry.Variant != "" || entry.Value != 0 {
+			return fmt.Errorf("%w: %s has no variant or value", ErrInvalidDeck, entry.Type)
+		}
+	}
+	return nil
+}
+
+// deckEntryPrefix names a kind of card, for card IDs and duplicate checks
+func deckEntryPrefix(entry models.DeckEntry) string {
+	prefix := string(entry.Type)
+	if entry.Variant != "" {
+		prefix += "_" + strings.ToLower(entry.Variant)
+	}
+	if _, ok := rollIcons[entry.Type]; ok {
+		prefix += fmt.Sprintf("_%d", entry.Value)
+	}
+	return prefix
+}
+
+// BuildDeck creates the cards of a validated deck definition
+func BuildDeck(deck *models.DeckDefinition) []models.Card {
+	cards := []models.Card{}
+	for _, entry := range deck.Cards {
+		card := models.Card{Type: entry.Type, Variant: entry.Variant, Value: entry.Value}
+		if entry.Type == models.CardTypeNigiri {
+			card.Value = nigiriValues[entry.Variant]
+		}
+		cards = addCards(cards, entry.Count, card, deckEntryPrefix(entry))
+	}
+	return cards
+}
+
+// deckSize returns how many cards a deck definition holds
+func deckSize(deck *models.DeckDefinition) int {
+	size := 0
+	for _, entry := range deck.Cards {
+		size += entry.Count
+	}
+	return size
+}
+
+// dealableSize returns how many cards of a deck definition are sure to be left for dealing
+// Every Menu played keeps a card it drew from the deck, so each one may cost the deals a card
+func dealableSize(deck *models.DeckDefinition) int {
+	size := deckSize(deck)
+	for _, entry := range deck.Cards {
+		if entry.Type == models.CardTypeMenu {
+			size -= entry.Count
+		}
+	}
+	return size
+}
+
+// deckSlack is how many times the cards the largest table needs a deck may hold
+const deckSlack = 2
+
+// tableCards returns the most cards every round at a table within limits can deal
+func tableCards(limits PlayerLimits, cardsPerHand, numRounds int) int {
+	most := 0
+	for players := limits.Min; players <= limits.Max; players++ {
+		hand := cardsPerHand
+		if hand == CardsPerHandByPlayerCount {
+			hand, _ = GetCardsPerPlayer(players)
+		}
+		most = max(most, numRounds*hand*players)
+	}
+	return most
+}
+
+// checkDeckLimits refuses a deck holding far more cards than any table within limits can deal
+// A kind of card may fill every hand of every round, and the whole deck twice that, so a client's deck can't make the server build millions of cards
+func checkDeckLimits(deck *models.DeckDefinition, limits PlayerLimits, cardsPerHand, numRounds int) error {
+	most := tableCards(limits, cardsPerHand, numRounds)
+	size := 0
+	for _, entry := range deck.Cards {
+		if entry.Count > most {
+			return fmt.Errorf("%w: %d %s cards is more than the %d a game can deal", ErrInvalidDeck, entry.Count, deckEntryPrefix(entry), most)
+		}
+		size += entry.Count
+	}
+	if size > deckSlack*most {
+		return fmt.Errorf("%w: %d cards is more than the %d a deck may hold", ErrInvalidDeck, size, deckSlack*most)
+	}
+	return nil
+}
+
+// deckMaxPlayers returns the largest table within limits that the deck can deal every round to
+// It is below limits.Min when the deck can't even cover the smallest table
+func deckMaxPlayers(deck *models.DeckDefinition, limits PlayerLimits, cardsPerHand, numRounds int) int {
+	size := dealableSize(deck)
+	maxPlayers := limits.Min - 1
+	for players := limits.Min; players <= limits.Max; players++ {
+		hand := cardsPerHand
+		if hand == CardsPerHandByPlayerCount {
+			hand, _ = GetCardsPerPlayer(players)
+		}
+		if numRounds*hand*players > size {
+			break
+		}
+		maxPlayers = players
+	}
+	return maxPlayers
+}
+
+// SetDeck sets the deck new games are dealt from (nil: the original Sushi Go! deck)
+// The deck must cover every round for the smallest table the player limits allow; larger tables are capped, see DeckSeats
+func (e *Engine) SetDeck(deck *models.DeckDefinition) error {
+	if deck != nil {
+		if err := ValidateDeckDefinition(deck); err != nil {
+			return err
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if deck != nil {
+		if err := checkDeckLimits(deck, e.limits, e.cardsPerHand, e.numRounds); err != nil {
+			return err
+		}
+	}
+	if deck != nil && deckMaxPlayers(deck, e.limits, e.cardsPerHand, e.numRounds) < e.limits.Min {
+		return fmt.Errorf("%w: %d cards can't deal %d rounds to %d players", ErrInvalidDeck, deckSize(deck), e.numRounds, e.limits.Min)
+	}
+	e.deck = deck
+	return nil
+}
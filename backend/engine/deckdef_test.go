@@ -0,0 +1,264 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestDefaultDeckDefinition tests that the default definition builds the original 108 cards
+func TestDefaultDeckDefinition(t *testing.T) {
+	if err := ValidateDeckDefinition(&DefaultDeck); err != nil {
+		t.Fatalf("Expected the default deck to be valid, got %v", err)
+	}
+
+	deck := InitializeDeck()
+	if len(deck) != 108 {
+		t.Fatalf("Expected 108 cards, got %d", len(deck))
+	}
+
+	ids := make(map[string]bool)
+	counts := make(map[models.CardType]int)
+	nigiri := make(map[string]int)
+	for _, card := range deck {
+		if ids[card.ID] {
+			t.Errorf("Duplicate card ID %s", card.ID)
+		}
+		ids[card.ID] = true
+		counts[card.Type]++
+		if card.Type == models.CardTypeNigiri {
+			nigiri[card.Variant] += card.Value
+		}
+	}
+	if counts[models.CardTypeMakiRoll] != 26 || counts[models.CardTypePudding] != 10 || counts[models.CardTypeChopsticks] != 4 {
+		t.Errorf("Unexpected card counts %v", counts)
+	}
+	// Nigiri take their value from the fish
+	if nigiri["Squid"] != 15 || nigiri["Salmon"] != 20 || nigiri["Egg"] != 5 {
+		t.Errorf("Unexpected Nigiri values %v", nigiri)
+	}
+}
+
+// TestValidateDeckDefinition tests that decks with cards the engine can't deal or score are rejected
+func TestValidateDeckDefinition(t *testing.T) {
+	invalid := map[string][]models.DeckEntry{
+		"no cards":            nil,
+		"unknown type":        {{Type: "sake", Count: 4}},
+		"face-down cards":     {{Type: models.CardTypeFaceDown, Count: 4}},
+		"other dessert":       {{Type: models.CardTypeFruit, Count: 4}},
+		"zero count":          {{Type: models.CardTypeTempura}},
+		"maki without icons":  {{Type: models.CardTypeMakiRoll, Count: 4}},
+		"uramaki icons":       {{Type: models.CardTypeUramaki, Value: 2, Count: 4}},
+		"unknown fish":        {{Type: models.CardTypeNigiri, Variant: "Tuna", Count: 4}},
+		"wrong nigiri value":  {{Type: models.CardTypeNigiri, Variant: "Egg", Value: 3, Count: 4}},
+		"unknown shape":       {{Type: models.CardTypeOnigiri, Variant: "Star", Count: 4}},
+		"tempura with value":  {{Type: models.CardTypeTempura, Value: 2, Count: 4}},
+		"duplicate entry":     {{Type: models.CardTypeTempura, Count: 4}, {Type: models.CardTypeTempura, Count: 2}},
+		"duplicate maki size": {{Type: models.CardTypeMakiRoll, Value: 2, Count: 4}, {Type: models.CardTypeMakiRoll, Value: 2, Count: 2}},
+	}
+	for name, cards := range invalid {
+		if err := ValidateDeckDefinition(&models.DeckDefinition{Name: name, Cards: cards}); !errors.Is(err, ErrInvalidDeck) {
+			t.Errorf("%s: expected ErrInvalidDeck, got %v", name, err)
+		}
+	}
+
+	valid := &models.DeckDefinition{Cards: []models.DeckEntry{
+		{Type: models.CardTypeMakiRoll, Value: 1, Count: 2},
+		{Type: models.CardTypeMakiRoll, Value: 3, Count: 2},
+		{Type: models.CardTypeNigiri, Variant: "Squid", Value: 3, Count: 2},
+		{Type: models.CardTypeOnigiri, Variant: "Circle", Count: 2},
+		{Type: models.CardTypeEel, Count: 2},
+	}}
+	if err := ValidateDeckDefinition(valid); err != nil {
+		t.Errorf("Expected a valid deck, got %v", err)
+	}
+}
+
+// TestLoadDeckDefinition tests loading YAML and JSON deck files
+func TestLoadDeckDefinition(t *testing.T) {
+	teaching, err := LoadDeckDefinition(filepath.Join("..", "decks", "teaching.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load the teaching deck: %v", err)
+	}
+	if teaching.Name != "Teaching" || deckSize(teaching) != 81 {
+		t.Errorf("Expected the 81-card teaching deck, got %q with %d cards", teaching.Name, deckSize(teaching))
+	}
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	deck, err := LoadDeckDefinition(write("tempura.json", `{"name": "Tempura", "cards": [{"type": "tempura", "count": 30}]}`))
+	if err != nil {
+		t.Fatalf("Failed to load JSON deck: %v", err)
+	}
+	if len(BuildDeck(deck)) != 30 {
+		t.Errorf("Expected 30 cards, got %d", len(BuildDeck(deck)))
+	}
+
+	invalid := map[string]string{
+		"typo.yaml":   "name: Typo\ncards:\n  - {type: tempura, cuont: 30}\n",
+		"typo.json":   `{"cards": [{"type": "tempura", "count": 30}], "extra": true}`,
+		"invalid.yml": "cards:\n  - {type: wasabi, value: 2, count: 4}\n",
+		"deck.txt":    "tempura 30",
+		"broken.yaml": "cards: [",
+	}
+	for name, content := range invalid {
+		if _, err := LoadDeckDefinition(write(name, content)); !errors.Is(err, ErrInvalidDeck) {
+			t.Errorf("%s: expected ErrInvalidDeck, got %v", name, err)
+		}
+	}
+	if _, err := LoadDeckDefinition(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+// TestDeckDefinitionSeats tests that a small deck only seats the players it can deal every round to
+func TestDeckDefinitionSeats(t *testing.T) {
+	teaching, err := LoadDeckDefinition(filepath.Join("..", "decks", "teaching.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load the teaching deck: %v", err)
+	}
+
+	engine := NewEngine()
+	if err := engine.SetDeck(teaching); err != nil {
+		t.Fatalf("Expected the teaching deck to be set, got %v", err)
+	}
+	if seats := engine.DeckSeats(); seats != 3 {
+		t.Errorf("Expected the teaching deck to seat 3, got %d", seats)
+	}
+	if err := engine.SetDeck(nil); err != nil {
+		t.Fatalf("Failed to restore the original deck: %v", err)
+	}
+
+	created, err := engine.CreateGameWithOptions([]string{"p1", "p2", "p3"}, GameOptions{Deck: teaching})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	game := liveGame(t, engine, created.ID)
+	if game.MaxPlayers != 3 || game.DeckDefinition != teaching {
+		t.Errorf("Expected a 3-seat game with the teaching deck, got %d seats", game.MaxPlayers)
+	}
+	if err := engine.JoinGame(game.ID, "p4"); err != ErrGameFull {
+		t.Errorf("Expected ErrGameFull, got %v", err)
+	}
+	if _, err := engine.CreateGameWithOptions([]string{"p1", "p2", "p3", "p4"}, GameOptions{Deck: teaching}); err != ErrTooManyPlayers {
+		t.Errorf("Expected ErrTooManyPlayers, got %v", err)
+	}
+
+	tiny := &models.DeckDefinition{Cards: []models.DeckEntry{{Type: models.CardTypeTempura, Count: 40}}}
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{Deck: tiny}); !errors.Is(err, ErrInvalidDeck) {
+		t.Errorf("Expected a deck too small for 2 players to be rejected, got %v", err)
+	}
+	if err := engine.SetDeck(tiny); !errors.Is(err, ErrInvalidDeck) {
+		t.Errorf("Expected SetDeck to refuse a deck too small for 2 players, got %v", err)
+	}
+	menu := DefaultPartyMenu
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{Deck: teaching, Menu: &menu}); !errors.Is(err, ErrInvalidDeck) {
+		t.Errorf("Expected a deck and a menu to be rejected together, got %v", err)
+	}
+
+	if err := engine.SetPlayerLimits(PlayerLimits{Min: 2, Max: 3, PartyMax: 8}); err != nil {
+		t.Fatalf("Failed to set limits: %v", err)
+	}
+	if err := engine.SetDeck(teaching); err != nil {
+		t.Fatalf("Expected the teaching deck to cover 3 players, got %v", err)
+	}
+	classic, _ := engine.CreateGame(nil)
+	if classic.DeckDefinition == nil || classic.DeckDefinition.Name != teaching.Name {
+		t.Error("Expected new games to use the engine's deck")
+	}
+	party, _ := engine.CreateGameWithOptions(nil, GameOptions{Menu: &menu})
+	if party.DeckDefinition != nil {
+		t.Error("Expected Party games to be dealt from their menu")
+	}
+}
+
+// TestDeckDefinitionGame tests that a game is dealt only from its deck definition, with every card used
+func TestDeckDefinitionGame(t *testing.T) {
+	teaching, err := LoadDeckDefinition(filepath.Join("..", "decks", "teaching.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load the teaching deck: %v", err)
+	}
+
+	engine := NewEngine()
+	engine.SetSeed(17)
+	created, err := engine.CreateGameWithOptions([]string{"p1", "p2", "p3"}, GameOptions{Deck: teaching})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	game := liveGame(t, engine, created.ID)
+
+	result := playFullGame(t, engine, game.ID)
+	if len(result.Rankings) != 3 {
+		t.Errorf("Expected 3 rankings, got %d", len(result.Rankings))
+	}
+
+	events, _ := engine.Events(game.ID)
+	dealt := 0
+	for _, event := range events {
+		if event.Type != EventRoundStarted {
+			continue
+		}
+		var payload RoundStartedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			t.Fatalf("Failed to decode round_started: %v", err)
+		}
+		for _, hand := range payload.Hands {
+			for _, card := range hand {
+				if card.Type == models.CardTypeWasabi || card.Type == models.CardTypeChopsticks {
+					t.Fatalf("Dealt %s, which the teaching deck doesn't have", card.Type)
+				}
+				dealt++
+			}
+		}
+	}
+	if dealt != 81 {
+		t.Errorf("Expected three rounds to deal all 81 cards, dealt %d", dealt)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.DeckDefinition == nil || replayed.DeckDefinition.Name != "Teaching" {
+		t.Error("Expected the replay to keep the deck definition")
+	}
+}
+
+// TestDeckDefinitionLimits tests that a deck far larger than any table can deal is refused before it is built
+func TestDeckDefinitionLimits(t *testing.T) {
+	engine := NewEngine()
+
+	// Five players need 105 cards over three rounds, so a deck may hold 210
+	huge := &models.DeckDefinition{Cards: []models.DeckEntry{{Type: models.CardTypeTempura, Count: 1 << 40}}}
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{Deck: huge}); !errors.Is(err, ErrInvalidDeck) {
+		t.Errorf("Expected an oversized entry to be rejected, got %v", err)
+	}
+	if err := engine.SetDeck(huge); !errors.Is(err, ErrInvalidDeck) {
+		t.Errorf("Expected SetDeck to refuse an oversized entry, got %v", err)
+	}
+
+	wide := &models.DeckDefinition{Cards: []models.DeckEntry{
+		{Type: models.CardTypeTempura, Count: 105},
+		{Type: models.CardTypeSashimi, Count: 105},
+		{Type: models.CardTypeDumpling, Count: 1},
+	}}
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{Deck: wide}); !errors.Is(err, ErrInvalidDeck) {
+		t.Errorf("Expected a 211-card deck to be rejected, got %v", err)
+	}
+
+	wide.Cards = wide.Cards[:2]
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{Deck: wide}); err != nil {
+		t.Errorf("Expected a 210-card deck to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// DummyPlayerID is the seat of the dummy hand in the two-player variant
+const DummyPlayerID = "dummy"
+
+var (
+	ErrDummyVariant    = errors.New("the dummy variant is for two players with the original deck")
+	ErrNotDummyPicker  = errors.New("it is not this player's turn to pick for the dummy")
+	ErrDummyCannotHost = errors.New("the dummy cannot host a game")
+)
+
+// newDummy creates the dummy player
+// The dummy sits after both humans, so it receives the second player's hand
+func newDummy() *models.Player {
+	player := newPlayer(DummyPlayerID)
+	player.Name = "Dummy"
+	player.IsDummy = true
+	return player
+}
+
+// seatDummy adds the dummy to a two-player table when the game starts
+func seatDummy(game *models.Game) error {
+	if len(game.Players) != 2 {
+		return ErrDummyVariant
+	}
+	game.Players = append(game.Players, newDummy())
+	return nil
+}
+
+// unseatDummy takes the dummy back out when the game can't start after all
+func unseatDummy(game *models.Game) {
+	if n := len(game.Players); n > 0 && game.Players[n-1].IsDummy {
+		game.Players = game.Players[:n-1]
+	}
+}
+
+// DummyPicker returns the player who picks the dummy's card this turn, or nil outside the dummy variant
+// The two players take turns, the first seat picking on the first turn of each round
+func DummyPicker(game *models.Game) *models.Player {
+	if !game.Dummy || len(game.Players) != 3 {
+		return nil
+	}
+	return game.Players[game.Turn%2]
+}
+
+// PlayDummyCard picks the dummy's card on behalf of the player whose turn it is
+func (e *Engine) PlayDummyCard(gameID, playerID string, cardIndex int) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+	if err := checkPhase(game, ActionPlayCard); err != nil {
+		return err
+	}
+	if picker := DummyPicker(game); picker == nil || picker.ID != playerID {
+		return ErrNotDummyPicker
+	}
+
+	if err := playCard(game, DummyPlayerID, cardIndex, PlayOptions{}); err != nil {
+		return err
+	}
+	return e.commit(entry, EventCardPlayed, CardPlayedPayload{
+		PlayerID:  DummyPlayerID,
+		CardIndex: cardIndex,
+		PickedBy:  playerID,
+	})
+}
+
+// WithdrawDummyCard takes back the dummy's card, only for the player who picked it this turn
+func (e *Engine) WithdrawDummyCard(gameID, playerID string) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+	if picker := DummyPicker(game); picker == nil || picker.ID != playerID {
+		return ErrNotDummyPicker
+	}
+
+	if err := withdrawCard(game, DummyPlayerID); err != nil {
+		return err
+	}
+	return e.commit(entry, EventCardWithdrawn, PlayerPayload{PlayerID: DummyPlayerID})
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestDummyVariantSeating tests that the dummy joins a two-player table when the game starts
+func TestDummyVariantSeating(t *testing.T) {
+	engine := NewEngine()
+
+	menu := DefaultPartyMenu
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{Dummy: true, Menu: &menu}); err != ErrDummyVariant {
+		t.Errorf("Expected ErrDummyVariant with a menu, got %v", err)
+	}
+	if _, err := engine.CreateGameWithOptions([]string{"p1", "p2", "p3"}, GameOptions{Dummy: true}); err != ErrTooManyPlayers {
+		t.Errorf("Expected ErrTooManyPlayers, got %v", err)
+	}
+
+	created, err := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Dummy: true})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	game := liveGame(t, engine, created.ID)
+	if err := engine.StartGame(game.ID); err != ErrNotEnoughPlayers {
+		t.Errorf("Expected ErrNotEnoughPlayers, got %v", err)
+	}
+	engine.JoinGame(game.ID, "p2")
+	if err := engine.JoinGame(game.ID, "p3"); err != ErrGameFull {
+		t.Errorf("Expected ErrGameFull for a third player, got %v", err)
+	}
+
+	if err := engine.StartGame(game.ID); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	if len(game.Players) != 3 || !game.Players[2].IsDummy || game.Players[2].ID != DummyPlayerID {
+		t.Fatalf("Expected the dummy in the third seat, got %d players", len(game.Players))
+	}
+	if game.CardsPerHand != 9 {
+		t.Errorf("Expected three-player hands of 9 cards, got %d", game.CardsPerHand)
+	}
+	if err := engine.SetHost(game.ID, DummyPlayerID); err != ErrDummyCannotHost {
+		t.Errorf("Expected ErrDummyCannotHost, got %v", err)
+	}
+}
+
+// TestDummyVariantPicks tests that the two players take turns picking the dummy's card
+func TestDummyVariantPicks(t *testing.T) {
+	engine := NewEngine()
+	created, _ := engine.CreateGameWithOptions([]string{"p1", "p2"}, GameOptions{Dummy: true})
+	game := liveGame(t, engine, created.ID)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	if picker := DummyPicker(game); picker == nil || picker.ID != "p1" {
+		t.Fatalf("Expected p1 to pick first, got %v", picker)
+	}
+	if err := engine.PlayDummyCard(game.ID, "p2", 0); err != ErrNotDummyPicker {
+		t.Errorf("Expected ErrNotDummyPicker, got %v", err)
+	}
+	if err := engine.PlayDummyCard(game.ID, "p1", 0); err != nil {
+		t.Fatalf("Failed to pick for the dummy: %v", err)
+	}
+	if err := engine.WithdrawDummyCard(game.ID, "p2"); err != ErrNotDummyPicker {
+		t.Errorf("Expected ErrNotDummyPicker, got %v", err)
+	}
+	if err := engine.WithdrawDummyCard(game.ID, "p1"); err != nil {
+		t.Fatalf("Failed to withdraw the dummy's card: %v", err)
+	}
+
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	engine.PlayCard(game.ID, "p2", 0, false, nil)
+	if err := engine.RevealCards(game.ID); err == nil {
+		t.Fatal("Expected the reveal to wait for the dummy's card")
+	}
+	engine.PlayDummyCard(game.ID, "p1", 1)
+	if err := engine.RevealCards(game.ID); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+	if len(game.Players[2].Collection) != 1 {
+		t.Errorf("Expected the dummy to keep its card, got %d", len(game.Players[2].Collection))
+	}
+	engine.PassHands(game.ID)
+
+	if picker := DummyPicker(game); picker.ID != "p2" {
+		t.Errorf("Expected p2 to pick on the second turn, got %s", picker.ID)
+	}
+}
+
+// TestDummyVariantBots tests that a bot picks for the dummy on its turns and timeouts cover the dummy
+func TestDummyVariantBots(t *testing.T) {
+	engine := NewEngine()
+	game, _ := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Dummy: true})
+	bot, _ := engine.AddBot(game.ID, BotStrategyGreedy)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	// p1 picks for the dummy on the first turn, so the bot only plays its own card
+	played, _ := engine.PlayBots(game.ID)
+	if len(played) != 1 || played[0] != bot.ID {
+		t.Errorf("Expected only the bot to play, got %v", played)
+	}
+	played, _ = engine.AutoPlay(game.ID, FirstCardPolicy{})
+	if len(played) != 2 {
+		t.Errorf("Expected p1 and the dummy to be auto-played, got %v", played)
+	}
+	engine.RevealCards(game.ID)
+	engine.PassHands(game.ID)
+
+	played, _ = engine.PlayBots(game.ID)
+	if len(played) != 2 || played[1] != DummyPlayerID {
+		t.Errorf("Expected the bot to play for itself and the dummy, got %v", played)
+	}
+}
+
+// TestDummyVariantScoring tests that the dummy competes for Maki and Pudding but isn't ranked
+func TestDummyVariantScoring(t *testing.T) {
+	game := &models.Game{
+		Dummy:        true,
+		TieMode:      models.TieSplit,
+		RoundPhase:   models.PhaseScoring,
+		CurrentRound: 3,
+		NumRounds:    3,
+		Players: []*models.Player{
+			{ID: "p1", Collection: []models.Card{{Type: models.CardTypeMakiRoll, Value: 2}}, PuddingCards: []models.Card{{Type: models.CardTypePudding}}},
+			{ID: "p2"},
+			{ID: DummyPlayerID, IsDummy: true, Collection: []models.Card{{Type: models.CardTypeMakiRoll, Value: 3}}, PuddingCards: []models.Card{{Type: models.CardTypePudding}, {Type: models.CardTypePudding}}},
+		},
+	}
+
+	if err := scoreRound(game); err != nil {
+		t.Fatalf("Failed to score round: %v", err)
+	}
+	if game.Players[2].Score != 6 || game.Players[0].Score != 3 {
+		t.Errorf("Expected the dummy to take first Maki and p1 second, got %d and %d", game.Players[2].Score, game.Players[0].Score)
+	}
+
+	result, err := endGame(game)
+	if err != nil {
+		t.Fatalf("Failed to end game: %v", err)
+	}
+	// With the dummy at the table, the fewest Pudding loses points as in a three-player game
+	if game.Players[1].DessertScore.Points != -6 {
+		t.Errorf("Expected p2 to lose 6 for fewest Pudding, got %d", game.Players[1].DessertScore.Points)
+	}
+	if len(result.Rankings) != 2 || result.Winner != "p1" {
+		t.Errorf("Expected only the players to be ranked with p1 winning, got %+v", result.Rankings)
+	}
+	if result.Dummy == nil || result.Dummy.FinalScore != 12 {
+		t.Errorf("Expected the dummy's 12 points to be reported apart, got %+v", result.Dummy)
+	}
+}
+
+// TestDummyVariantReplay tests that a full dummy game replays to the same result
+func TestDummyVariantReplay(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(19)
+	created, _ := engine.CreateGameWithOptions([]string{"p1", "p2"}, GameOptions{Dummy: true})
+	game := liveGame(t, engine, created.ID)
+	if err := engine.StartGame(game.ID); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	for game.RoundPhase != models.PhaseGameEnd {
+		if err := engine.StartRound(game.ID); err != nil {
+			t.Fatalf("Failed to start round: %v", err)
+		}
+		for game.RoundPhase == models.PhaseSelecting {
+			engine.PlayCard(game.ID, "p1", 0, false, nil)
+			engine.PlayCard(game.ID, "p2", 0, false, nil)
+			if err := engine.PlayDummyCard(game.ID, DummyPicker(game).ID, 0); err != nil {
+				t.Fatalf("Failed to pick for the dummy: %v", err)
+			}
+			engine.RevealCards(game.ID)
+			engine.PassHands(game.ID)
+		}
+		engine.ScoreRound(game.ID)
+	}
+	result, err := engine.EndGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to end game: %v", err)
+	}
+	if len(result.Rankings) != 2 || result.Dummy == nil {
+		t.Errorf("Expected 2 rankings and the dummy apart, got %+v", result)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	for i, player := range replayed.Players {
+		if player.Score != game.Players[i].Score {
+			t.Errorf("Expected replayed score %d for %s, got %d", game.Players[i].Score, player.ID, player.Score)
+		}
+	}
+}
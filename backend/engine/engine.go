@@ -18,13 +18,16 @@ type GameEngine interface {
 type GameResult struct {
 	Winner   string          `json:"winner"`
 	Rankings []PlayerRanking `json:"rankings"`
+	Dummy    *PlayerRanking  `json:"dummy,omitempty"` // The dummy's score in the two-player variant; it is never ranked
 }
 
 // PlayerRanking represents a player's final ranking
 type PlayerRanking struct {
-	PlayerID     string `json:"playerId"`
-	PlayerName   string `json:"playerName"`
-	FinalScore   int    `json:"finalScore"`
-	PuddingCount int    `json:"puddingCount"`
-	Rank         int    `json:"rank"`
+	PlayerID        string                  `json:"playerId"`
+	PlayerName      string                  `json:"playerName"`
+	FinalScore      int                     `json:"finalScore"`
+	PuddingCount    int                     `json:"puddingCount"`
+	Rank            int                     `json:"rank"`
+	Breakdown       models.ScoreBreakdown   `json:"breakdown"`       // Whole game by category, dessert included
+	RoundBreakdowns []models.ScoreBreakdown `json:"roundBreakdowns"` // Each round by category
 }
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/sushi-go-game/backend/models"
@@ -450,7 +452,7 @@ func TestEnginePassHands(t *testing.T) {
 	engine.StartGame(game.ID)
 	engine.StartRound(game.ID)
 
-	game, _ = engine.GetGame(game.ID)
+	game = liveGame(t, engine, game.ID)
 	originalHands := make(map[string][]models.Card)
 	for _, player := range game.Players {
 		// Copy the hand
@@ -459,7 +461,8 @@ func TestEnginePassHands(t *testing.T) {
 		originalHands[player.ID] = hand
 	}
 
-	// Pass hands
+	// Pass hands as if the turn had just been revealed
+	game.RoundPhase = models.PhaseRevealing
 	err := engine.PassHands(game.ID)
 	if err != nil {
 		t.Fatalf("Failed to pass hands: %v", err)
@@ -496,12 +499,12 @@ func TestEngineScoreRound(t *testing.T) {
 	engine.StartRound(game.ID)
 
 	// Give players some cards in their collection
-	game, _ = engine.GetGame(game.ID)
+	game = liveGame(t, engine, game.ID)
 	game.Players[0].Collection = []models.Card{
 		{Type: models.CardTypeTempura},
 		{Type: models.CardTypeTempura},
 	}
-	
+
 	// Set game to scoring phase
 	game.RoundPhase = models.PhaseScoring
 
@@ -531,7 +534,7 @@ func TestEngineEndGame(t *testing.T) {
 	engine.StartGame(game.ID)
 
 	// Give players some pudding cards for final scoring
-	game, _ = engine.GetGame(game.ID)
+	game = liveGame(t, engine, game.ID)
 	game.Players[0].PuddingCards = []models.Card{
 		{Type: models.CardTypePudding},
 		{Type: models.CardTypePudding},
@@ -544,7 +547,7 @@ func TestEngineEndGame(t *testing.T) {
 	}
 	game.Players[1].Score = 15
 	game.Players[1].Name = "Player 2"
-	
+
 	// Set game to game_end phase
 	game.CurrentRound = 3
 	game.RoundPhase = models.PhaseGameEnd
@@ -568,3 +571,106 @@ func TestEngineEndGame(t *testing.T) {
 		t.Errorf("Expected phase to be game_end, got %s", game.RoundPhase)
 	}
 }
+
+// TestEngineScoreBreakdowns tests that every round's breakdown is kept and adds up to the final score
+func TestEngineScoreBreakdowns(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(14)
+
+	created, _ := engine.CreateGame([]string{"p1", "p2", "p3"})
+	game := liveGame(t, engine, created.ID)
+	result := playFullGame(t, engine, game.ID)
+
+	for _, ranking := range result.Rankings {
+		if len(ranking.RoundBreakdowns) != 3 {
+			t.Fatalf("Expected 3 round breakdowns for %s, got %d", ranking.PlayerID, len(ranking.RoundBreakdowns))
+		}
+
+		player := findPlayer(game, ranking.PlayerID)
+		for round, breakdown := range ranking.RoundBreakdowns {
+			if breakdown.Total != player.RoundScores[round] {
+				t.Errorf("%s round %d: breakdown total %d, round score %d", ranking.PlayerID, round+1, breakdown.Total, player.RoundScores[round])
+			}
+		}
+
+		if ranking.Breakdown.Total != ranking.FinalScore {
+			t.Errorf("%s: breakdown total %d, final score %d", ranking.PlayerID, ranking.Breakdown.Total, ranking.FinalScore)
+		}
+		points := 0
+		for _, category := range ranking.Breakdown.Categories {
+			points += category.Points
+		}
+		if points != ranking.FinalScore {
+			t.Errorf("%s: categories add up to %d, final score %d", ranking.PlayerID, points, ranking.FinalScore)
+		}
+
+		if player.DessertScore == nil || player.DessertScore.Category != string(models.CardTypePudding) {
+			t.Errorf("%s: expected a pudding dessert score, got %+v", ranking.PlayerID, player.DessertScore)
+		}
+	}
+
+	// Breakdowns are rebuilt when the game is replayed
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	for _, player := range game.Players {
+		if !reflect.DeepEqual(findPlayer(replayed, player.ID).RoundBreakdowns, player.RoundBreakdowns) {
+			t.Errorf("Expected replayed breakdowns for %s to match", player.ID)
+		}
+	}
+}
+
+// TestEngineRulesModeHandSize tests that rules mode deals the official hand size for the player count
+func TestEngineRulesModeHandSize(t *testing.T) {
+	expected := map[int]int{2: 10, 3: 9, 4: 8, 5: 7}
+
+	for playerCount, handSize := range expected {
+		engine := NewEngineWithConfig(nil, 3, CardsPerHandByPlayerCount)
+
+		playerIDs := []string{}
+		for i := 0; i < playerCount; i++ {
+			playerIDs = append(playerIDs, fmt.Sprintf("p%d", i+1))
+		}
+		game, _ := engine.CreateGame(playerIDs)
+
+		if game.HandSizeMode != models.HandSizeRules {
+			t.Errorf("%d players: expected rules mode, got %s", playerCount, game.HandSizeMode)
+		}
+
+		engine.StartGame(game.ID)
+		if err := engine.StartRound(game.ID); err != nil {
+			t.Fatalf("%d players: failed to start round: %v", playerCount, err)
+		}
+
+		game, _ = engine.GetGame(game.ID)
+		if game.CardsPerHand != handSize {
+			t.Errorf("%d players: expected %d cards per hand, got %d", playerCount, handSize, game.CardsPerHand)
+		}
+		for _, player := range game.Players {
+			if len(player.Hand) != handSize {
+				t.Errorf("%d players: expected hand of %d, got %d", playerCount, handSize, len(player.Hand))
+			}
+		}
+	}
+}
+
+// TestEngineHouseRuleHandSize tests that an explicit cards-per-hand overrides the player count table
+func TestEngineHouseRuleHandSize(t *testing.T) {
+	engine := NewEngineWithConfig(nil, 3, 6)
+
+	game, _ := engine.CreateGame([]string{"p1", "p2", "p3", "p4"})
+	if game.HandSizeMode != models.HandSizeHouse {
+		t.Errorf("Expected house mode, got %s", game.HandSizeMode)
+	}
+
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	game, _ = engine.GetGame(game.ID)
+	for _, player := range game.Players {
+		if len(player.Hand) != 6 {
+			t.Errorf("Expected house rule hand of 6, got %d", len(player.Hand))
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// EventType identifies a kind of game event
+type EventType string
+
+const (
+	EventGameCreated    EventType = "game_created"
+	EventPlayerJoined   EventType = "player_joined"
+	EventPlayerRemoved  EventType = "player_removed"
+	EventPlayerRenamed  EventType = "player_renamed"
+	EventPlayerReady    EventType = "player_ready"
+	EventBotAdded       EventType = "bot_added"
+	EventHostChanged    EventType = "host_changed"
+	EventGameStarted    EventType = "game_started"
+	EventRoundStarted   EventType = "round_started"
+	EventCardPlayed     EventType = "card_played"
+	EventCardWithdrawn  EventType = "card_withdrawn"
+	EventCardsRevealed  EventType = "cards_revealed"
+	EventHandsPassed    EventType = "hands_passed"
+	EventRoundScored    EventType = "round_scored"
+	EventGameEnded      EventType = "game_ended"
+	EventEndedEarly     EventType = "ended_early"
+	EventRevealUndone   EventType = "reveal_undone"
+	EventMenuCardPicked EventType = "menu_card_picked"
+)
+
+// Event is a single recorded mutation of a game
+// Folding a game's events in order with Replay reconstructs its state
+type Event struct {
+	Seq       int             `json:"seq"`
+	GameID    string          `json:"gameId"`
+	Type      EventType       `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+// GameCreatedPayload is the payload of a game_created event
+type GameCreatedPayload struct {
+	PlayerIDs    []string               `json:"playerIds"`
+	NumRounds    int                    `json:"numRounds"`
+	CardsPerHand int                    `json:"cardsPerHand"`
+	Seed         int64                  `json:"seed"`
+	TurnTimeout  time.Duration          `json:"turnTimeout,omitempty"`
+	Menu         *models.Menu           `json:"menu,omitempty"`
+	TieMode      models.TieMode         `json:"tieMode,omitempty"`
+	PassPolicy   models.PassPolicy      `json:"passPolicy"`
+	ReadyCheck   models.ReadyCheck      `json:"readyCheck,omitempty"`
+	Private      bool                   `json:"private,omitempty"`
+	InviteSalt   []byte                 `json:"inviteSalt,omitempty"`
+	InviteHash   []byte                 `json:"inviteHash,omitempty"`
+	Dummy        bool                   `json:"dummy,omitempty"`
+	MinPlayers   int                    `json:"minPlayers,omitempty"`
+	MaxPlayers   int                    `json:"maxPlayers,omitempty"`
+	Deck         *models.DeckDefinition `json:"deck,omitempty"`
+	CreatedAt    time.Time              `json:"createdAt"`
+}
+
+// PlayerPayload is the payload of events that concern a single player
+type PlayerPayload struct {
+	PlayerID string `json:"playerId"`
+	Name     string `json:"name,omitempty"`
+	Strategy string `json:"strategy,omitempty"` // Bot strategy, for bot_added
+	Ready    bool   `json:"ready,omitempty"`    // Readiness, for player_ready
+}
+
+// RoundStartedPayload is the payload of a round_started event
+// The dealt hands and remaining deck are recorded so a replay does not depend on the dealer
+type RoundStartedPayload struct {
+	Round int             `json:"round"`
+	Hands [][]models.Card `json:"hands"`
+	Deck  []models.Card   `json:"deck"`
+}
+
+// CardPlayedPayload is the payload of a card_played event
+type CardPlayedPayload struct {
+	PlayerID        string              `json:"playerId"`
+	CardIndex       int                 `json:"cardIndex"`
+	UseChopsticks   bool                `json:"useChopsticks"`
+	SecondCardIndex *int                `json:"secondCardIndex,omitempty"`
+	Spoon           *models.CardRequest `json:"spoon,omitempty"`       // Card asked for with a Spoon
+	CopyCardID      string              `json:"copyCardId,omitempty"`  // Card a Special Order copies
+	FlipCardIDs     []string            `json:"flipCardIds,omitempty"` // Cards a Takeout Box turns face down
+	AutoPlayed      bool                `json:"autoPlayed,omitempty"`  // Played by the server after the turn timed out
+	PickedBy        string              `json:"pickedBy,omitempty"`    // Player who picked the dummy's card
+}
+
+// MenuCardPickedPayload is the payload of a menu_card_picked event
+type MenuCardPickedPayload struct {
+	PlayerID   string `json:"playerId"`
+	CardIndex  int    `json:"cardIndex"`
+	AutoPlayed bool   `json:"autoPlayed,omitempty"` // Picked by the server after the pick timed out
+}
+
+// NewEvent creates an event with its payload encoded
+// Encoding up front keeps the recorded event independent of later game mutations
+func NewEvent(gameID string, eventType EventType, payload interface{}) (Event, error) {
+	event := Event{
+		GameID:    gameID,
+		Type:      eventType,
+		Timestamp: time.Now(),
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+		}
+		event.Payload = data
+	}
+
+	return event, nil
+}
+
+// Replay reconstructs a game by folding its events in order
+func Replay(events []Event) (*models.Game, error) {
+	if len(events) == 0 {
+		return nil, errors.New("no events to replay")
+	}
+	if events[0].Type != EventGameCreated {
+		return nil, fmt.Errorf("event log must start with %s, got %s", EventGameCreated, events[0].Type)
+	}
+
+	var game, beforeReveal *models.Game // beforeReveal is the table a reveal_undone goes back to
+	for _, event := range events {
+		var err error
+		switch event.Type {
+		case EventCardsRevealed:
+			beforeReveal = game.Clone()
+			game, err = applyEvent(game, event)
+		case EventRevealUndone:
+			game, err = takeBack(game, beforeReveal)
+			beforeReveal = nil
+		default:
+			game, err = applyEvent(game, event)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply event %d (%s): %w", event.Seq, event.Type, err)
+		}
+		if startsTurn(event.Type) {
+			setTurnDeadline(game, event.Timestamp)
+		}
+	}
+
+	return game, nil
+}
+
+// ReplayGame reconstructs a game from the events the engine recorded for it
+func (e *Engine) ReplayGame(gameID string) (*models.Game, error) {
+	events, err := e.Events(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return Replay(events)
+}
+
+// applyEvent applies a single event to a game, returning the updated game
+func applyEvent(game *models.Game, event Event) (*models.Game, error) {
+	if event.Type == EventGameCreated {
+		var payload GameCreatedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return newGame(event.GameID, payload), nil
+	}
+
+	if game == nil {
+		return nil, errors.New("event applied before game was created")
+	}
+
+	switch event.Type {
+	case EventPlayerJoined, EventPlayerRemoved, EventPlayerRenamed, EventPlayerReady, EventBotAdded, EventHostChanged, EventCardWithdrawn:
+		var payload PlayerPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		switch event.Type {
+		case EventPlayerJoined:
+			return game, joinGame(game, payload.PlayerID)
+		case EventPlayerRemoved:
+			return game, removePlayer(game, payload.PlayerID)
+		case EventPlayerRenamed:
+			return game, setPlayerName(game, payload.PlayerID, payload.Name)
+		case EventPlayerReady:
+			return game, setReady(game, payload.PlayerID, payload.Ready)
+		case EventBotAdded:
+			return game, addBot(game, payload.PlayerID, payload.Name, payload.Strategy)
+		case EventHostChanged:
+			return game, setHost(game, payload.PlayerID)
+		default:
+			return game, withdrawCard(game, payload.PlayerID)
+		}
+	case EventGameStarted:
+		return game, startGame(game)
+	case EventRoundStarted:
+		var payload RoundStartedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		if len(payload.Hands) != len(game.Players) {
+			return nil, fmt.Errorf("round %d dealt %d hands for %d players", payload.Round, len(payload.Hands), len(game.Players))
+		}
+		return game, startRound(game, payload.Hands, payload.Deck)
+	case EventCardPlayed:
+		var payload CardPlayedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return game, playCard(game, payload.PlayerID, payload.CardIndex, PlayOptions{
+			UseChopsticks:   payload.UseChopsticks,
+			SecondCardIndex: payload.SecondCardIndex,
+			Spoon:           payload.Spoon,
+			CopyCardID:      payload.CopyCardID,
+			FlipCardIDs:     payload.FlipCardIDs,
+		})
+	case EventMenuCardPicked:
+		var payload MenuCardPickedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return game, pickMenuCard(game, payload.PlayerID, payload.CardIndex)
+	case EventCardsRevealed:
+		return game, revealCards(game)
+	case EventHandsPassed:
+		return game, passHands(game)
+	case EventRoundScored:
+		return game, scoreRound(game)
+	case EventGameEnded:
+		_, err := endGame(game)
+		return game, err
+	case EventEndedEarly:
+		return game, endEarly(game)
+	default:
+		return nil, fmt.Errorf("unknown event type: %s", event.Type)
+	}
+}
+
+// EventLog durably records game events
+// Delete drops a game's history once the engine removes the game, so logs don't outlive their games
+type EventLog interface {
+	Append(event Event) error
+	Events(gameID string) ([]Event, error)
+	Delete(gameID string) error
+}
+
+// FileEventLog appends each game's events to a JSON Lines file in a directory
+type FileEventLog struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileEventLog creates a file-backed event log, creating the directory if needed
+func NewFileEventLog(dir string) (*FileEventLog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+	return &FileEventLog{dir: dir}, nil
+}
+
+// Append writes an event to the end of its game's log
+// A game_created event starts a fresh log; an older log for a reused game ID, left behind
+// by a game that was never deleted, is kept alongside it with a timestamp suffix until Delete
+func (l *FileEventLog) Append(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	path := l.path(event.GameID)
+	if event.Type == EventGameCreated {
+		if _, err := os.Stat(path); err == nil {
+			archived := strings.TrimSuffix(path, ".jsonl") + fmt.Sprintf(".%d.jsonl", time.Now().UnixNano())
+			if err := os.Rename(path, archived); err != nil {
+				return fmt.Errorf("failed to archive old event log: %w", err)
+			}
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// Events reads all events logged for a game
+func (l *FileEventLog) Events(gameID string) ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path(gameID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Event{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	events := []Event{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, scanner.Err()
+}
+
+// Delete removes a game's log, along with any older logs kept for its ID
+func (l *FileEventLog) Delete(gameID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	path := l.path(gameID)
+	archived, err := filepath.Glob(strings.TrimSuffix(path, ".jsonl") + ".*.jsonl")
+	if err != nil {
+		return err
+	}
+	for _, file := range append(archived, path) {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// path returns the log file path for a game, keeping IDs from escaping the directory
+func (l *FileEventLog) path(gameID string) string {
+	name := strings.ReplaceAll(filepath.Base(gameID), string(filepath.Separator), "_")
+	return filepath.Join(l.dir, name+".events.jsonl")
+}
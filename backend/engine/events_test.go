@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// liveGame returns the engine's own game rather than a snapshot,
+// for tests that follow a game through several moves or set up a position directly
+func liveGame(t *testing.T, engine *Engine, gameID string) *models.Game {
+	t.Helper()
+
+	engine.mu.RLock()
+	entry, exists := engine.games[gameID]
+	engine.mu.RUnlock()
+	if !exists {
+		t.Fatalf("Game %s not found", gameID)
+	}
+	return entry.game
+}
+
+// playFullGame plays every turn of a game, always picking the first card
+func playFullGame(t *testing.T, engine *Engine, gameID string) *GameResult {
+	t.Helper()
+
+	if err := engine.StartGame(gameID); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+
+	for {
+		if err := engine.StartRound(gameID); err != nil {
+			t.Fatalf("Failed to start round: %v", err)
+		}
+
+		for {
+			game, _ := engine.GetGame(gameID)
+			for _, player := range game.Players {
+				if err := engine.PlayCard(gameID, player.ID, 0, false, nil); err != nil {
+					t.Fatalf("Failed to play card: %v", err)
+				}
+			}
+			if err := engine.RevealCards(gameID); err != nil {
+				t.Fatalf("Failed to reveal cards: %v", err)
+			}
+			if err := engine.PassHands(gameID); err != nil {
+				t.Fatalf("Failed to pass hands: %v", err)
+			}
+			if game, _ := engine.GetGame(gameID); game.RoundPhase == models.PhaseScoring {
+				break
+			}
+		}
+
+		if err := engine.ScoreRound(gameID); err != nil {
+			t.Fatalf("Failed to score round: %v", err)
+		}
+
+		game, _ := engine.GetGame(gameID)
+		if game.RoundPhase == models.PhaseGameEnd {
+			result, err := engine.EndGame(gameID)
+			if err != nil {
+				t.Fatalf("Failed to end game: %v", err)
+			}
+			return result
+		}
+	}
+}
+
+// TestEngineRecordsEvents tests that each mutation appends a typed event
+func TestEngineRecordsEvents(t *testing.T) {
+	engine := NewEngine()
+
+	game, _ := engine.CreateGame([]string{"p1"})
+	engine.JoinGame(game.ID, "p2")
+	engine.SetPlayerName(game.ID, "p2", "Bob")
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	engine.WithdrawCard(game.ID, "p1")
+
+	events, err := engine.Events(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get events: %v", err)
+	}
+
+	expected := []EventType{
+		EventGameCreated,
+		EventPlayerJoined,
+		EventPlayerRenamed,
+		EventGameStarted,
+		EventRoundStarted,
+		EventCardPlayed,
+		EventCardWithdrawn,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event.Type != expected[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, expected[i], event.Type)
+		}
+		if event.Seq != i+1 {
+			t.Errorf("Event %d: expected seq %d, got %d", i, i+1, event.Seq)
+		}
+	}
+}
+
+// TestEngineFailedMutationRecordsNoEvent tests that rejected actions leave the log untouched
+func TestEngineFailedMutationRecordsNoEvent(t *testing.T) {
+	engine := NewEngine()
+
+	game, _ := engine.CreateGame([]string{"p1"})
+	if err := engine.StartGame(game.ID); err != ErrNotEnoughPlayers {
+		t.Fatalf("Expected ErrNotEnoughPlayers, got %v", err)
+	}
+
+	events, _ := engine.Events(game.ID)
+	if len(events) != 1 {
+		t.Errorf("Expected only the game_created event, got %d events", len(events))
+	}
+}
+
+// TestReplayReproducesFullGame tests that folding the event log rebuilds the exact final state
+func TestReplayReproducesFullGame(t *testing.T) {
+	engine := NewEngine()
+
+	created, _ := engine.CreateGame([]string{"p1", "p2", "p3"})
+	game := liveGame(t, engine, created.ID)
+	playFullGame(t, engine, game.ID)
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay game: %v", err)
+	}
+
+	live, _ := engine.GetGame(game.ID)
+	liveJSON, _ := json.Marshal(live)
+	replayedJSON, _ := json.Marshal(replayed)
+	if string(liveJSON) != string(replayedJSON) {
+		t.Errorf("Replayed game differs from live game\nlive:     %s\nreplayed: %s", liveJSON, replayedJSON)
+	}
+}
+
+// TestReplayRequiresGameCreated tests that a log must begin with game creation
+func TestReplayRequiresGameCreated(t *testing.T) {
+	event, _ := NewEvent("g1", EventGameStarted, nil)
+
+	if _, err := Replay([]Event{event}); err == nil {
+		t.Error("Expected error replaying a log without game_created")
+	}
+	if _, err := Replay(nil); err == nil {
+		t.Error("Expected error replaying an empty log")
+	}
+}
+
+// TestFileEventLogSurvivesRestart tests that a reloaded game keeps its history
+func TestFileEventLogSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := NewFileStore(dir)
+	log, err := NewFileEventLog(dir)
+	if err != nil {
+		t.Fatalf("Failed to create event log: %v", err)
+	}
+
+	first := NewEngine()
+	first.SetStore(store)
+	first.SetEventLog(log)
+
+	game, _ := first.CreateGame([]string{"p1", "p2"})
+	first.StartGame(game.ID)
+	first.StartRound(game.ID)
+	first.PlayCard(game.ID, "p2", 3, false, nil)
+
+	second := NewEngine()
+	if err := second.SetStore(store); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+	if err := second.SetEventLog(log); err != nil {
+		t.Fatalf("Failed to set event log: %v", err)
+	}
+
+	events, err := second.Events(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get events: %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events after restart, got %d", len(events))
+	}
+
+	// New events continue the sequence
+	second.PlayCard(game.ID, "p1", 0, false, nil)
+	events, _ = second.Events(game.ID)
+	if events[len(events)-1].Seq != 5 {
+		t.Errorf("Expected next event to have seq 5, got %d", events[len(events)-1].Seq)
+	}
+
+	replayed, err := Replay(events)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.Players[1].SelectedCard == nil || *replayed.Players[1].SelectedCard != 3 {
+		t.Error("Expected replayed game to include p2's selection")
+	}
+}
+
+// TestFileEventLogDelete tests that deleting a game removes its log and any older logs for its ID
+func TestFileEventLogDelete(t *testing.T) {
+	dir := t.TempDir()
+	log, err := NewFileEventLog(dir)
+	if err != nil {
+		t.Fatalf("Failed to create event log: %v", err)
+	}
+
+	// A reused ID archives the older log alongside the new one
+	created, _ := NewEvent("nara-fuji-7", EventGameCreated, GameCreatedPayload{PlayerIDs: []string{"p1"}})
+	log.Append(created)
+	log.Append(created)
+
+	engine := NewEngine()
+	engine.SetEventLog(log)
+	game, _ := engine.CreateGame([]string{"p1"})
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl")); len(files) != 3 {
+		t.Fatalf("Expected 3 log files, got %v", files)
+	}
+
+	if err := engine.DeleteGame(game.ID); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+	if err := log.Delete("nara-fuji-7"); err != nil {
+		t.Fatalf("Failed to delete log: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+		t.Errorf("Expected every log to be removed, got %v", files)
+	}
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+var (
+	ErrPlayerNotFound = errors.New("player not found in game")
+	ErrBotCannotHost  = errors.New("bots cannot host a game")
+)
+
+// SetHost hands the host role to another player in the game
+func (e *Engine) SetHost(gameID, playerID string) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+	if game.HostID == playerID {
+		return nil
+	}
+
+	if err := setHost(game, playerID); err != nil {
+		return err
+	}
+	return e.commit(entry, EventHostChanged, PlayerPayload{PlayerID: playerID})
+}
+
+// setHost makes a human player the game's host
+func setHost(game *models.Game, playerID string) error {
+	player := findPlayer(game, playerID)
+	if player == nil {
+		return ErrPlayerNotFound
+	}
+	if player.IsBot {
+		return ErrBotCannotHost
+	}
+	if player.IsDummy {
+		return ErrDummyCannotHost
+	}
+
+	game.HostID = playerID
+	return nil
+}
+
+// nextHost returns the first human in seat order, or "" when only bots remain
+func nextHost(game *models.Game) string {
+	for _, player := range game.Players {
+		if !player.IsBot && !player.IsDummy {
+			return player.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestHostAssignment tests that the first player hosts and bots never take over
+func TestHostAssignment(t *testing.T) {
+	engine := NewEngine()
+
+	created, _ := engine.CreateGame([]string{"p1"})
+	game := liveGame(t, engine, created.ID)
+	if game.HostID != "p1" {
+		t.Errorf("Expected p1 to host, got %q", game.HostID)
+	}
+
+	created, _ = engine.CreateGame(nil)
+	empty := liveGame(t, engine, created.ID)
+	if empty.HostID != "" {
+		t.Errorf("Expected no host for an empty game, got %q", empty.HostID)
+	}
+	engine.AddBot(empty.ID, BotStrategyRandom)
+	if empty.HostID != "" {
+		t.Errorf("Expected a bot not to become host, got %q", empty.HostID)
+	}
+	engine.JoinGame(empty.ID, "p2")
+	if empty.HostID != "p2" {
+		t.Errorf("Expected the first human to host, got %q", empty.HostID)
+	}
+}
+
+// TestHostMigratesOnRemove tests that the host role passes to the next human in seat order
+func TestHostMigratesOnRemove(t *testing.T) {
+	engine := NewEngine()
+	created, _ := engine.CreateGame([]string{"p1"})
+	game := liveGame(t, engine, created.ID)
+	bot, _ := engine.AddBot(game.ID, BotStrategyRandom)
+	engine.JoinGame(game.ID, "p2")
+
+	if err := engine.RemovePlayer(game.ID, "p1"); err != nil {
+		t.Fatalf("Failed to remove player: %v", err)
+	}
+	if game.HostID != "p2" {
+		t.Errorf("Expected p2 to host after p1 left, got %q", game.HostID)
+	}
+
+	// Removing someone else leaves the host alone
+	engine.RemovePlayer(game.ID, bot.ID)
+	if game.HostID != "p2" {
+		t.Errorf("Expected p2 to stay host, got %q", game.HostID)
+	}
+
+	engine.AddBot(game.ID, BotStrategyRandom)
+	engine.RemovePlayer(game.ID, "p2")
+	if game.HostID != "" {
+		t.Errorf("Expected no host when only bots remain, got %q", game.HostID)
+	}
+}
+
+// TestSetHost tests transferring the host role
+func TestSetHost(t *testing.T) {
+	engine := NewEngine()
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	game := liveGame(t, engine, created.ID)
+	bot, _ := engine.AddBot(game.ID, BotStrategyRandom)
+
+	if err := engine.SetHost(game.ID, "p2"); err != nil {
+		t.Fatalf("Failed to set host: %v", err)
+	}
+	if game.HostID != "p2" {
+		t.Errorf("Expected p2 to host, got %q", game.HostID)
+	}
+
+	if err := engine.SetHost(game.ID, bot.ID); err != ErrBotCannotHost {
+		t.Errorf("Expected ErrBotCannotHost, got %v", err)
+	}
+	if err := engine.SetHost(game.ID, "p9"); err != ErrPlayerNotFound {
+		t.Errorf("Expected ErrPlayerNotFound, got %v", err)
+	}
+	if err := engine.SetHost("missing", "p1"); err != ErrGameNotFound {
+		t.Errorf("Expected ErrGameNotFound, got %v", err)
+	}
+
+	// The host can also change hands once the game is running
+	engine.StartGame(game.ID)
+	if err := engine.SetHost(game.ID, "p1"); err != nil {
+		t.Fatalf("Failed to set host during play: %v", err)
+	}
+	if game.HostID != "p1" {
+		t.Errorf("Expected p1 to host, got %q", game.HostID)
+	}
+}
+
+// TestHostReplay tests that host changes survive replaying the event log
+func TestHostReplay(t *testing.T) {
+	engine := NewEngine()
+	game, _ := engine.CreateGame([]string{"p1", "p2", "p3"})
+	engine.RemovePlayer(game.ID, "p1")
+	engine.SetHost(game.ID, "p3")
+
+	events, _ := engine.Events(game.ID)
+	if last := events[len(events)-1]; last.Type != EventHostChanged {
+		t.Errorf("Expected last event %s, got %s", EventHostChanged, last.Type)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay game: %v", err)
+	}
+	if replayed.HostID != "p3" {
+		t.Errorf("Expected replayed host p3, got %q", replayed.HostID)
+	}
+	if replayed.RoundPhase != models.PhaseWaitingForPlayers {
+		t.Errorf("Expected replayed game to be waiting, got %s", replayed.RoundPhase)
+	}
+}
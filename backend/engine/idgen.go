@@ -3,7 +3,6 @@ package engine
 import (
 	"fmt"
 	"math/rand"
-	"time"
 )
 
 // Japanese regions for game ID generation
@@ -58,23 +57,30 @@ var historicalFigures = []string{
 	"Oda Nobunaga",
 }
 
-// Initialize random seed
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // GenerateGameID generates a memorable game ID in the format region-flower-number
-func GenerateGameID() string {
-	region := japaneseRegions[rand.Intn(len(japaneseRegions))]
-	flower := japaneseFlowers[rand.Intn(len(japaneseFlowers))]
-	number := rand.Intn(99-10) + 10 // 10-99
+func GenerateGameID(r *rand.Rand) string {
+	region := japaneseRegions[r.Intn(len(japaneseRegions))]
+	flower := japaneseFlowers[r.Intn(len(japaneseFlowers))]
+	number := r.Intn(99-10) + 10 // 10-99
 
 	return fmt.Sprintf("%s-%s-%d", region, flower, number)
 }
 
+// inviteCodeAlphabet leaves out letters and digits that are easily confused when read aloud
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateInviteCode generates the six-character code players join a private game with
+func GenerateInviteCode(r *rand.Rand) string {
+	code := make([]byte, 6)
+	for i := range code {
+		code[i] = inviteCodeAlphabet[r.Intn(len(inviteCodeAlphabet))]
+	}
+	return string(code)
+}
+
 // GeneratePlayerName generates a random player name from famous sushi chefs,
 // pop culture characters, or historical figures
-func GeneratePlayerName() string {
+func GeneratePlayerName(r *rand.Rand) string {
 	// Combine all name lists
 	allNames := make([]string, 0)
 	allNames = append(allNames, sushiChefs...)
@@ -82,5 +88,5 @@ func GeneratePlayerName() string {
 	allNames = append(allNames, historicalFigures...)
 
 	// Select a random name
-	return allNames[rand.Intn(len(allNames))]
+	return allNames[r.Intn(len(allNames))]
 }
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// PlayerLimits bounds how many players can sit at a table
+// Each game copies the limits that apply to it when it is created
+type PlayerLimits struct {
+	Min      int // Players needed to start
+	Max      int // Seats with the original Sushi Go! deck (at most 5)
+	PartyMax int // Seats with a Sushi Go Party! menu (at most 8)
+}
+
+// DefaultPlayerLimits are the player counts the printed games support
+var DefaultPlayerLimits = PlayerLimits{Min: 2, Max: 5, PartyMax: 8}
+
+// Validate checks that the limits fit the hand size tables
+func (l PlayerLimits) Validate() error {
+	if l.Min < DefaultPlayerLimits.Min {
+		return fmt.Errorf("minimum players must be at least %d, got %d", DefaultPlayerLimits.Min, l.Min)
+	}
+	if l.Max < l.Min || l.Max > DefaultPlayerLimits.Max {
+		return fmt.Errorf("maximum players must be between %d and %d, got %d", l.Min, DefaultPlayerLimits.Max, l.Max)
+	}
+	if l.PartyMax < l.Min || l.PartyMax > DefaultPlayerLimits.PartyMax {
+		return fmt.Errorf("maximum Party players must be between %d and %d, got %d", l.Min, DefaultPlayerLimits.PartyMax, l.PartyMax)
+	}
+	return nil
+}
+
+// maxFor returns the seat limit for a game with the given menu
+func (l PlayerLimits) maxFor(menu *models.Menu) int {
+	if menu != nil {
+		return l.PartyMax
+	}
+	return l.Max
+}
+
+// SetPlayerLimits sets the player limits for new games
+func (e *Engine) SetPlayerLimits(limits PlayerLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.limits = limits
+	return nil
+}
+
+// PlayerLimits returns the player limits applied to new games
+func (e *Engine) PlayerLimits() PlayerLimits {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.limits
+}
+
+// seatLimits returns a game's minimum and maximum player counts
+// Games saved before the limits were recorded use the defaults
+func seatLimits(game *models.Game) (int, int) {
+	minPlayers, maxPlayers := game.MinPlayers, game.MaxPlayers
+	if minPlayers == 0 {
+		minPlayers = DefaultPlayerLimits.Min
+	}
+	if maxPlayers == 0 {
+		maxPlayers = DefaultPlayerLimits.maxFor(game.Menu)
+	}
+	return minPlayers, maxPlayers
+}
+
+// DeckSeats returns the largest table the deck new games are dealt from covers every round for
+// The deck is shuffled once per game, so a house-rule hand size or an extra round caps new games below the limits
+func (e *Engine) DeckSeats() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return deckMaxPlayers(e.dealtDeck(), e.limits, e.cardsPerHand, e.numRounds)
+}
+
+// CheckDeck verifies the deck new games are dealt from covers every round for the smallest table the limits allow
+// A deck that runs dry at a larger table only caps the seats of each new game, see DeckSeats
+func (e *Engine) CheckDeck() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	deck := e.dealtDeck()
+	if deckMaxPlayers(deck, e.limits, e.cardsPerHand, e.numRounds) < e.limits.Min {
+		hand := "the rules' hand size"
+		if e.cardsPerHand != CardsPerHandByPlayerCount {
+			hand = fmt.Sprintf("%d-card hands", e.cardsPerHand)
+		}
+		return fmt.Errorf("%w: %d cards can't deal %d rounds of %s to %d players", ErrDeckExhausted, deckSize(deck), e.numRounds, hand, e.limits.Min)
+	}
+	return nil
+}
+
+// dealtDeck returns the deck new games of the original Sushi Go! are dealt from
+// Callers must hold e.mu
+func (e *Engine) dealtDeck() *models.DeckDefinition {
+	if e.deck == nil {
+		return &DefaultDeck
+	}
+	return e.deck
+}
+
+// checkDeckSize verifies a Sushi Go Party! menu or the game's deck has enough cards to deal every round to the table
+// Every Party round is dealt from the menu plus that round's new desserts, so the smallest round decides
+func checkDeckSize(game *models.Game, cardsPerHand int) error {
+	if game.Menu == nil {
+		deck := game.DeckDefinition
+		if deck == nil {
+			deck = &DefaultDeck
+		}
+		// Every round is dealt from what the earlier rounds and their Menus left
+		needed := game.NumRounds * cardsPerHand * len(game.Players)
+		if available := dealableSize(deck); available < needed {
+			return fmt.Errorf("%w: %d rounds need %d cards, the deck has %d", ErrDeckExhausted, game.NumRounds, needed, available)
+		}
+		return nil
+	}
+
+	needed := cardsPerHand * len(game.Players)
+	base := len(InitializePartyDeck(game.Menu))
+	for round := 1; round <= game.NumRounds; round++ {
+		// Desserts kept from earlier rounds never come back, so only count this round's new ones
+		added := dessertsInPlay(len(game.Players), round) - dessertsInPlay(len(game.Players), round-1)
+		if available := base + added; available < needed {
+			return fmt.Errorf("%w: round %d needs %d cards, the menu has %d", ErrDeckExhausted, round, needed, available)
+		}
+	}
+	return nil
+}
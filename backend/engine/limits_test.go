@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestPlayerLimitsValidate tests the bounds on configurable player limits
+func TestPlayerLimitsValidate(t *testing.T) {
+	if err := DefaultPlayerLimits.Validate(); err != nil {
+		t.Fatalf("Expected default limits to be valid, got %v", err)
+	}
+
+	invalid := []PlayerLimits{
+		{Min: 1, Max: 5, PartyMax: 8},
+		{Min: 4, Max: 3, PartyMax: 8},
+		{Min: 2, Max: 6, PartyMax: 8},
+		{Min: 2, Max: 5, PartyMax: 9},
+		{Min: 3, Max: 5, PartyMax: 2},
+	}
+	for _, limits := range invalid {
+		if err := limits.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", limits)
+		}
+	}
+
+	engine := NewEngine()
+	if err := engine.SetPlayerLimits(invalid[0]); err == nil {
+		t.Error("Expected SetPlayerLimits to reject invalid limits")
+	}
+	if engine.PlayerLimits() != DefaultPlayerLimits {
+		t.Errorf("Expected limits unchanged after a rejected update, got %+v", engine.PlayerLimits())
+	}
+}
+
+// TestPartyTableSeatsEight tests that Party games seat up to 8 while the original deck stops at 5
+func TestPartyTableSeatsEight(t *testing.T) {
+	engine := NewEngine()
+
+	menu := DefaultPartyMenu
+	party, _ := engine.CreateGameWithOptions(nil, GameOptions{Menu: &menu})
+	classic, _ := engine.CreateGame(nil)
+
+	for i := 1; i <= 8; i++ {
+		if err := engine.JoinGame(party.ID, fmt.Sprintf("p%d", i)); err != nil {
+			t.Fatalf("Failed to seat player %d at a Party table: %v", i, err)
+		}
+	}
+	if err := engine.JoinGame(party.ID, "p9"); err != ErrGameFull {
+		t.Errorf("Expected ErrGameFull for a 9th player, got %v", err)
+	}
+
+	for i := 1; i <= 5; i++ {
+		engine.JoinGame(classic.ID, fmt.Sprintf("p%d", i))
+	}
+	if err := engine.JoinGame(classic.ID, "p6"); err != ErrGameFull {
+		t.Errorf("Expected ErrGameFull for a 6th player with the original deck, got %v", err)
+	}
+
+	nine := []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8", "p9"}
+	if _, err := engine.CreateGameWithOptions(nine, GameOptions{Menu: &menu}); err != ErrTooManyPlayers {
+		t.Errorf("Expected ErrTooManyPlayers, got %v", err)
+	}
+	if _, err := engine.CreateGame(nine[:6]); err != ErrTooManyPlayers {
+		t.Errorf("Expected ErrTooManyPlayers, got %v", err)
+	}
+}
+
+// TestEightPlayerPartyGame tests that a full table plays through with the Party hand size
+func TestEightPlayerPartyGame(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(8)
+
+	menu := DefaultPartyMenu
+	created, err := engine.CreateGameWithOptions([]string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8"}, GameOptions{Menu: &menu})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	game := liveGame(t, engine, created.ID)
+
+	result := playFullGame(t, engine, game.ID)
+	if game.CardsPerHand != 7 {
+		t.Errorf("Expected 7 cards per hand with 8 players, got %d", game.CardsPerHand)
+	}
+	if len(result.Rankings) != 8 {
+		t.Errorf("Expected 8 rankings, got %d", len(result.Rankings))
+	}
+}
+
+// TestSetPlayerLimits tests that configured limits apply to new games
+func TestSetPlayerLimits(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetPlayerLimits(PlayerLimits{Min: 3, Max: 3, PartyMax: 6}); err != nil {
+		t.Fatalf("Failed to set limits: %v", err)
+	}
+
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	if game.MinPlayers != 3 || game.MaxPlayers != 3 {
+		t.Errorf("Expected the game to record limits 3-3, got %d-%d", game.MinPlayers, game.MaxPlayers)
+	}
+	if err := engine.StartGame(game.ID); err != ErrNotEnoughPlayers {
+		t.Errorf("Expected ErrNotEnoughPlayers with 2 of 3 players, got %v", err)
+	}
+	engine.JoinGame(game.ID, "p3")
+	if err := engine.JoinGame(game.ID, "p4"); err != ErrGameFull {
+		t.Errorf("Expected ErrGameFull, got %v", err)
+	}
+
+	// Replays keep the limits the game was created with
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.MaxPlayers != 3 {
+		t.Errorf("Expected replayed max players 3, got %d", replayed.MaxPlayers)
+	}
+
+	// Games saved before the limits existed fall back to the defaults
+	old := &models.Game{Players: []*models.Player{{ID: "p1"}}}
+	if minPlayers, maxPlayers := seatLimits(old); minPlayers != 2 || maxPlayers != 5 {
+		t.Errorf("Expected default limits 2-5, got %d-%d", minPlayers, maxPlayers)
+	}
+}
+
+// TestGetPartyCardsPerPlayer tests the Sushi Go Party! hand sizes
+func TestGetPartyCardsPerPlayer(t *testing.T) {
+	expected := map[int]int{2: 10, 3: 10, 4: 9, 5: 9, 6: 8, 7: 8, 8: 7}
+	for players, cards := range expected {
+		got, err := GetPartyCardsPerPlayer(players)
+		if err != nil || got != cards {
+			t.Errorf("Expected %d cards for %d players, got %d (%v)", cards, players, got, err)
+		}
+	}
+	for _, players := range []int{1, 9} {
+		if _, err := GetPartyCardsPerPlayer(players); err == nil {
+			t.Errorf("Expected an error for %d players", players)
+		}
+	}
+}
+
+// TestPartyDeckTooSmall tests that a game won't start if its menu can't cover every hand
+func TestPartyDeckTooSmall(t *testing.T) {
+	// Ten cards each for eight players needs 80 cards a round
+	engine := NewEngineWithConfig(nil, 3, 10)
+
+	menu := DefaultPartyMenu
+	game, _ := engine.CreateGameWithOptions([]string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8"}, GameOptions{Menu: &menu})
+
+	if err := engine.StartGame(game.ID); !errors.Is(err, ErrDeckExhausted) {
+		t.Fatalf("Expected ErrDeckExhausted, got %v", err)
+	}
+	if game.RoundPhase != models.PhaseWaitingForPlayers {
+		t.Errorf("Expected the game to keep waiting, got %s", game.RoundPhase)
+	}
+}
+
+// TestDealCardsCustomValidation tests DealCardsCustom's checks on the deck and table
+func TestDealCardsCustomValidation(t *testing.T) {
+	players := []*models.Player{{ID: "p1"}, {ID: "p2"}}
+	deck := InitializePartyDeck(&DefaultPartyMenu)
+
+	if _, _, err := DealCardsCustom(deck[:15], players, 8); !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected ErrDeckExhausted, got %v", err)
+	}
+	if _, _, err := DealCardsCustom(deck, nil, 8); err == nil {
+		t.Error("Expected an error with no players")
+	}
+	if _, _, err := DealCardsCustom(deck, players, 0); err == nil {
+		t.Error("Expected an error for an empty hand")
+	}
+
+	_, remaining, err := DealCardsCustom(deck, players, 8)
+	if err != nil {
+		t.Fatalf("Failed to deal: %v", err)
+	}
+	if len(remaining) != len(deck)-16 {
+		t.Errorf("Expected %d cards left, got %d", len(deck)-16, len(remaining))
+	}
+}
@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestConcurrentGames plays hundreds of games at once, each player picking from their own goroutine
+// while readers list, snapshot and scribble on the games; run it with -race
+func TestConcurrentGames(t *testing.T) {
+	numGames := 300
+	if testing.Short() {
+		numGames = 50
+	}
+
+	engine := NewEngine()
+	engine.SetSeed(21)
+	if err := engine.SetStore(NopStore{}); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+
+	gameIDs := make([]string, numGames)
+	for i := range gameIDs {
+		game, err := engine.CreateGame([]string{fmt.Sprintf("g%d-p1", i), fmt.Sprintf("g%d-p2", i)})
+		if err != nil {
+			t.Fatalf("Failed to create game %d: %v", i, err)
+		}
+		if _, err := engine.AddBot(game.ID, BotStrategyGreedy); err != nil {
+			t.Fatalf("Failed to add bot to game %d: %v", i, err)
+		}
+		gameIDs[i] = game.ID
+	}
+
+	errs := make(chan error, numGames*2)
+	var players sync.WaitGroup
+	for _, gameID := range gameIDs {
+		players.Add(1)
+		go func(gameID string) {
+			defer players.Done()
+			if err := engine.StartGame(gameID); err != nil {
+				errs <- err
+				return
+			}
+			if err := engine.StartRound(gameID); err != nil {
+				errs <- err
+				return
+			}
+
+			var seats sync.WaitGroup
+			game, _ := engine.GetGame(gameID)
+			for _, player := range game.Players[:2] {
+				seats.Add(1)
+				go func(playerID string) {
+					defer seats.Done()
+					if err := playUntilGameEnd(engine, gameID, playerID); err != nil {
+						errs <- fmt.Errorf("%s in game %s: %w", playerID, gameID, err)
+					}
+				}(player.ID)
+			}
+			seats.Wait()
+		}(gameID)
+	}
+
+	// Readers never block the games, and their snapshots never reach the engine
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func(r int) {
+			defer readers.Done()
+			for i := r; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				engine.ListGames()
+				if snapshot, err := engine.GetGame(gameIDs[i%numGames]); err == nil {
+					snapshot.Players[0].Name = "scribbled"
+					snapshot.Players[0].Score = -1000
+					snapshot.Players[0].Hand = nil
+				}
+				engine.Events(gameIDs[(i*7)%numGames])
+			}
+		}(r)
+	}
+
+	players.Wait()
+	close(done)
+	readers.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for _, gameID := range gameIDs {
+		game, err := engine.GetGame(gameID)
+		if err != nil {
+			t.Fatalf("Failed to get game %s: %v", gameID, err)
+		}
+		if game.RoundPhase != models.PhaseGameEnd {
+			t.Errorf("Expected game %s to end, got %s", gameID, game.RoundPhase)
+			continue
+		}
+
+		replayed, err := engine.ReplayGame(gameID)
+		if err != nil {
+			t.Fatalf("Failed to replay game %s: %v", gameID, err)
+		}
+		for i, player := range game.Players {
+			if player.Name == "scribbled" || player.Score != replayed.Players[i].Score {
+				t.Errorf("Game %s: %s ended with %d (%q), the replay with %d", gameID, player.ID, player.Score, player.Name, replayed.Players[i].Score)
+			}
+		}
+	}
+
+	// Deleting every game leaves nothing to find, even for a reader that looked a game up just before
+	var deleters sync.WaitGroup
+	for _, gameID := range gameIDs {
+		deleters.Add(2)
+		go func(gameID string) {
+			defer deleters.Done()
+			engine.DeleteGame(gameID)
+		}(gameID)
+		go func(gameID string) {
+			defer deleters.Done()
+			engine.GetGame(gameID)
+		}(gameID)
+	}
+	deleters.Wait()
+	if games := engine.ListGames(); len(games) != 0 {
+		t.Errorf("Expected every game to be deleted, %d left", len(games))
+	}
+}
+
+// playUntilGameEnd picks the first card whenever the player is waiting on a pick,
+// advancing the game after each one the way the handler does
+func playUntilGameEnd(engine *Engine, gameID, playerID string) error {
+	for {
+		game, err := engine.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		switch game.RoundPhase {
+		case models.PhaseGameEnd:
+			return nil
+		case models.PhaseSelecting:
+			// Nothing can be revealed before this player picks, so the phase holds until PlayCard
+			if player := findPlayer(game, playerID); player.SelectedCard == nil && len(player.Hand) > 0 {
+				if err := engine.PlayCard(gameID, playerID, 0, false, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err := engine.Advance(gameID); err != nil && !errors.Is(err, ErrWrongPhase) {
+			return err
+		}
+		runtime.Gosched()
+	}
+}
@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sushi-go-game/backend/models"
+	"github.com/sushi-go-game/backend/scoring"
+)
+
+// ErrInvalidMenu is returned when a Sushi Go Party! menu can't be served
+var ErrInvalidMenu = errors.New("invalid menu")
+
+// Sushi Go Party! menu categories; a menu picks one roll, three appetizers, two specials and one dessert
+var (
+	MenuRolls      = []models.CardType{models.CardTypeMakiRoll, models.CardTypeTemaki, models.CardTypeUramaki}
+	MenuAppetizers = []models.CardType{models.CardTypeDumpling, models.CardTypeEdamame, models.CardTypeEel, models.CardTypeOnigiri, models.CardTypeMisoSoup, models.CardTypeSashimi, models.CardTypeTempura, models.CardTypeTofu}
+	MenuSpecials   = []models.CardType{models.CardTypeChopsticks, models.CardTypeMenu, models.CardTypeSoySauce, models.CardTypeSpoon, models.CardTypeSpecialOrder, models.CardTypeTakeoutBox, models.CardTypeTea, models.CardTypeWasabi}
+	MenuDesserts   = []models.CardType{models.CardTypePudding, models.CardTypeGreenTeaIceCream, models.CardTypeFruit}
+)
+
+// DefaultPartyMenu is the rulebook's suggested menu for a first game
+var DefaultPartyMenu = models.Menu{
+	Roll:       models.CardTypeMakiRoll,
+	Appetizers: []models.CardType{models.CardTypeTempura, models.CardTypeSashimi, models.CardTypeMisoSoup},
+	Specials:   []models.CardType{models.CardTypeWasabi, models.CardTypeTea},
+	Dessert:    models.CardTypeGreenTeaIceCream,
+}
+
+// ValidateMenu checks that a menu has one item from each course, without repeats
+func ValidateMenu(menu *models.Menu) error {
+	if err := validateCourse("roll", []models.CardType{menu.Roll}, MenuRolls, 1); err != nil {
+		return err
+	}
+	if err := validateCourse("appetizer", menu.Appetizers, MenuAppetizers, 3); err != nil {
+		return err
+	}
+	if err := validateCourse("special", menu.Specials, MenuSpecials, 2); err != nil {
+		return err
+	}
+	return validateCourse("dessert", []models.CardType{menu.Dessert}, MenuDesserts, 1)
+}
+
+// validateCourse checks that items holds want distinct categories from allowed
+func validateCourse(course string, items, allowed []models.CardType, want int) error {
+	if len(items) != want {
+		return fmt.Errorf("%w: need %d %s(s), got %d", ErrInvalidMenu, want, course, len(items))
+	}
+
+	seen := make(map[models.CardType]bool)
+	for _, item := range items {
+		if !containsCardType(allowed, item) {
+			return fmt.Errorf("%w: %q is not a %s", ErrInvalidMenu, item, course)
+		}
+		if seen[item] {
+			return fmt.Errorf("%w: %s listed twice", ErrInvalidMenu, item)
+		}
+		seen[item] = true
+	}
+	return nil
+}
+
+// containsCardType reports whether cardType is in types
+func containsCardType(types []models.CardType, cardType models.CardType) bool {
+	for _, t := range types {
+		if t == cardType {
+			return true
+		}
+	}
+	return false
+}
+
+// isDessert reports whether a card is kept across rounds and scored at the end of the game
+func isDessert(cardType models.CardType) bool {
+	return containsCardType(MenuDesserts, cardType)
+}
+
+// addCards appends count copies of card, numbering their IDs from prefix
+func addCards(deck []models.Card, count int, card models.Card, prefix string) []models.Card {
+	for i := 0; i < count; i++ {
+		card.ID = fmt.Sprintf("%s_%d", prefix, i)
+		deck = append(deck, card)
+	}
+	return deck
+}
+
+// InitializePartyDeck creates the non-dessert Sushi Go Party! cards for a menu
+// Nigiri are always included; desserts are added round by round by PartyRoundDeck
+func InitializePartyDeck(menu *models.Menu) []models.Card {
+	deck := []models.Card{}
+
+	// Nigiri: 4 Egg, 5 Salmon, 3 Squid
+	deck = addCards(deck, 4, models.Card{Type: models.CardTypeNigiri, Variant: "Egg", Value: 1}, "nigiri_egg")
+	deck = addCards(deck, 5, models.Card{Type: models.CardTypeNigiri, Variant: "Salmon", Value: 2}, "nigiri_salmon")
+	deck = addCards(deck, 3, models.Card{Type: models.CardTypeNigiri, Variant: "Squid", Value: 3}, "nigiri_squid")
+
+	courses := append([]models.CardType{menu.Roll}, menu.Appetizers...)
+	courses = append(courses, menu.Specials...)
+	for _, cardType := range courses {
+		deck = append(deck, partyCards(cardType)...)
+	}
+	return deck
+}
+
+// partyCards returns the Sushi Go Party! cards of one roll, appetizer or special
+func partyCards(cardType models.CardType) []models.Card {
+	prefix := string(cardType)
+	cards := []models.Card{}
+
+	switch cardType {
+	case models.CardTypeMakiRoll:
+		// Maki: 4 with 1 icon, 5 with 2 icons, 3 with 3 icons
+		cards = addCards(cards, 4, models.Card{Type: cardType, Value: 1}, "maki_1")
+		cards = addCards(cards, 5, models.Card{Type: cardType, Value: 2}, "maki_2")
+		cards = addCards(cards, 3, models.Card{Type: cardType, Value: 3}, "maki_3")
+	case models.CardTypeUramaki:
+		// Uramaki: 4 each with 3, 4 and 5 icons
+		for icons := 3; icons <= 5; icons++ {
+			cards = addCards(cards, 4, models.Card{Type: cardType, Value: icons}, fmt.Sprintf("%s_%d", prefix, icons))
+		}
+	case models.CardTypeTemaki:
+		cards = addCards(cards, 12, models.Card{Type: cardType}, prefix)
+	case models.CardTypeOnigiri:
+		// Onigiri: 2 of each shape
+		for _, shape := range scoring.OnigiriShapes {
+			cards = addCards(cards, 2, models.Card{Type: cardType, Variant: shape}, prefix+"_"+strings.ToLower(shape))
+		}
+	default:
+		if containsCardType(MenuAppetizers, cardType) {
+			cards = addCards(cards, 8, models.Card{Type: cardType}, prefix)
+		} else if containsCardType(MenuSpecials, cardType) {
+			cards = addCards(cards, 3, models.Card{Type: cardType}, prefix)
+		}
+	}
+	return cards
+}
+
+// partyDesserts returns all 15 cards of a dessert in the order they join the deck
+func partyDesserts(dessert models.CardType) []models.Card {
+	if dessert != models.CardTypeFruit {
+		return addCards(nil, 15, models.Card{Type: dessert}, string(dessert))
+	}
+
+	// Fruit: 2 of each double fruit, 3 of each mixed pair
+	cards := []models.Card{}
+	for i, first := range scoring.Fruits {
+		for _, second := range scoring.Fruits[i:] {
+			count := 3
+			if first == second {
+				count = 2
+			}
+			variant := first + "+" + second
+			cards = addCards(cards, count, models.Card{Type: dessert, Variant: variant}, "fruit_"+strings.ToLower(first+"_"+second))
+		}
+	}
+	return cards
+}
+
+// dessertsInPlay returns how many desserts have joined the deck by a round
+// Desserts are added 5, 3, 2 per round with up to 5 players, and 7, 5, 3 with more
+func dessertsInPlay(playerCount, round int) int {
+	added := []int{5, 3, 2}
+	if playerCount > 5 {
+		added = []int{7, 5, 3}
+	}
+
+	total := 0
+	for i := 0; i < round && i < len(added); i++ {
+		total += added[i]
+	}
+	return total
+}
+
+// PartyRoundDeck builds the unshuffled deck for the game's current round
+// Every round starts from the full menu plus the desserts added so far,
+// less the desserts players are still holding
+func PartyRoundDeck(game *models.Game) []models.Card {
+	deck := InitializePartyDeck(game.Menu)
+
+	kept := make(map[string]bool)
+	for _, player := range game.Players {
+		for _, card := range player.PuddingCards {
+			kept[card.ID] = true
+		}
+	}
+
+	desserts := partyDesserts(game.Menu.Dessert)
+	inPlay := dessertsInPlay(len(game.Players), game.CurrentRound)
+	if inPlay > len(desserts) {
+		inPlay = len(desserts)
+	}
+	for _, card := range desserts[:inPlay] {
+		if !kept[card.ID] {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+// discardClashingMisoSoup removes this turn's Miso Soups when more than one was played
+func discardClashingMisoSoup(game *models.Game) {
+	played := 0
+	for _, player := range game.Players {
+		for _, card := range player.Collection {
+			if card.Type == models.CardTypeMisoSoup && card.Turn == game.Turn {
+				played++
+			}
+		}
+	}
+	if played < 2 {
+		return
+	}
+
+	for _, player := range game.Players {
+		kept := player.Collection[:0]
+		for _, card := range player.Collection {
+			if card.Type != models.CardTypeMisoSoup || card.Turn != game.Turn {
+				kept = append(kept, card)
+			}
+		}
+		player.Collection = kept
+	}
+}
+
+// dessertScores scores the game's dessert for every player at the end of the game
+func dessertScores(game *models.Game) map[string]models.CategoryScore {
+	dessert := models.CardTypePudding
+	if game.Menu != nil {
+		dessert = game.Menu.Dessert
+	}
+	return scoring.RegistryFor(game.TieMode).ScoreDessert(dessert, game.Players)
+}
@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestValidateMenu tests menu validation
+func TestValidateMenu(t *testing.T) {
+	if err := ValidateMenu(&DefaultPartyMenu); err != nil {
+		t.Fatalf("Expected the default menu to be valid, got %v", err)
+	}
+
+	withChange := func(change func(menu *models.Menu)) *models.Menu {
+		menu := DefaultPartyMenu
+		menu.Appetizers = append([]models.CardType{}, DefaultPartyMenu.Appetizers...)
+		menu.Specials = append([]models.CardType{}, DefaultPartyMenu.Specials...)
+		change(&menu)
+		return &menu
+	}
+
+	invalid := map[string]*models.Menu{
+		"missing roll":       withChange(func(m *models.Menu) { m.Roll = "" }),
+		"appetizer as roll":  withChange(func(m *models.Menu) { m.Roll = models.CardTypeTofu }),
+		"two appetizers":     withChange(func(m *models.Menu) { m.Appetizers = m.Appetizers[:2] }),
+		"repeated appetizer": withChange(func(m *models.Menu) { m.Appetizers[2] = models.CardTypeTempura }),
+		"dessert as special": withChange(func(m *models.Menu) { m.Specials[1] = models.CardTypePudding }),
+		"unknown dessert":    withChange(func(m *models.Menu) { m.Dessert = "cake" }),
+		"face-down special":  withChange(func(m *models.Menu) { m.Specials[1] = models.CardTypeFaceDown }),
+	}
+
+	for name, menu := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateMenu(menu); !errors.Is(err, ErrInvalidMenu) {
+				t.Errorf("Expected ErrInvalidMenu, got %v", err)
+			}
+		})
+	}
+}
+
+// TestCreateGameWithMenu tests that a game keeps its menu and rejects invalid ones
+func TestCreateGameWithMenu(t *testing.T) {
+	engine := NewEngine()
+
+	menu := DefaultPartyMenu
+	game, err := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Menu: &menu})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if game.Menu == nil || game.Menu.Dessert != models.CardTypeGreenTeaIceCream {
+		t.Errorf("Expected the game to keep its menu, got %+v", game.Menu)
+	}
+
+	bad := models.Menu{Roll: models.CardTypeTemaki}
+	if _, err := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Menu: &bad}); !errors.Is(err, ErrInvalidMenu) {
+		t.Errorf("Expected ErrInvalidMenu, got %v", err)
+	}
+
+	classic, _ := engine.CreateGame([]string{"p1"})
+	if classic.Menu != nil {
+		t.Errorf("Expected no menu for the original game, got %+v", classic.Menu)
+	}
+}
+
+// TestInitializePartyDeck tests the cards each menu category brings
+func TestInitializePartyDeck(t *testing.T) {
+	for _, course := range []struct {
+		types []models.CardType
+		count int
+	}{
+		{MenuRolls, 12},
+		{MenuAppetizers, 8},
+		{MenuSpecials, 3},
+	} {
+		for _, cardType := range course.types {
+			if cards := partyCards(cardType); len(cards) != course.count {
+				t.Errorf("Expected %d %s cards, got %d", course.count, cardType, len(cards))
+			}
+		}
+	}
+	for _, dessert := range MenuDesserts {
+		if cards := partyDesserts(dessert); len(cards) != 15 {
+			t.Errorf("Expected 15 %s cards, got %d", dessert, len(cards))
+		}
+	}
+
+	deck := InitializePartyDeck(&DefaultPartyMenu)
+	// 12 Nigiri, 12 Maki, 3×8 appetizers, 2×3 specials
+	if len(deck) != 54 {
+		t.Errorf("Expected 54 cards, got %d", len(deck))
+	}
+
+	ids := make(map[string]bool)
+	for _, card := range append(deck, partyDesserts(models.CardTypeFruit)...) {
+		if ids[card.ID] {
+			t.Errorf("Duplicate card ID %s", card.ID)
+		}
+		ids[card.ID] = true
+		if card.Type == models.CardTypeFruit && card.Variant == "" {
+			t.Errorf("Fruit card %s has no fruits", card.ID)
+		}
+	}
+}
+
+// TestPartyRoundDeckAddsDesserts tests that desserts join the deck round by round
+func TestPartyRoundDeckAddsDesserts(t *testing.T) {
+	menu := DefaultPartyMenu
+	game := &models.Game{
+		Menu:         &menu,
+		CurrentRound: 1,
+		Players:      []*models.Player{{ID: "p1"}, {ID: "p2"}, {ID: "p3"}},
+	}
+
+	countDesserts := func(deck []models.Card) int {
+		count := 0
+		for _, card := range deck {
+			if isDessert(card.Type) {
+				count++
+			}
+		}
+		return count
+	}
+
+	if desserts := countDesserts(PartyRoundDeck(game)); desserts != 5 {
+		t.Errorf("Round 1: expected 5 desserts, got %d", desserts)
+	}
+
+	// Two desserts kept from round 1 don't go back into the deck
+	kept := partyDesserts(menu.Dessert)[:2]
+	game.Players[0].PuddingCards = kept
+	game.CurrentRound = 2
+	if desserts := countDesserts(PartyRoundDeck(game)); desserts != 6 {
+		t.Errorf("Round 2: expected 8 desserts less 2 kept, got %d", desserts)
+	}
+
+	game.CurrentRound = 3
+	for _, player := range []string{"p4", "p5", "p6"} {
+		game.Players = append(game.Players, &models.Player{ID: player})
+	}
+	if desserts := countDesserts(PartyRoundDeck(game)); desserts != 13 {
+		t.Errorf("Round 3 with 6 players: expected 15 desserts less 2 kept, got %d", desserts)
+	}
+}
+
+// TestMisoSoupClash tests that Miso Soups played on the same turn are discarded
+func TestMisoSoupClash(t *testing.T) {
+	miso := models.Card{ID: "miso", Type: models.CardTypeMisoSoup}
+	tofu := models.Card{ID: "tofu", Type: models.CardTypeTofu}
+	first, second := 0, 1
+	game := &models.Game{
+		RoundPhase: models.PhaseSelecting,
+		Players: []*models.Player{
+			{ID: "p1", Hand: []models.Card{miso, miso}, SelectedCard: &first},
+			{ID: "p2", Hand: []models.Card{miso, tofu}, SelectedCard: &first},
+		},
+	}
+
+	if err := revealCards(game); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+	for _, player := range game.Players {
+		if len(player.Collection) != 0 {
+			t.Errorf("Expected %s's clashing Miso Soup to be discarded, got %v", player.ID, player.Collection)
+		}
+	}
+
+	// Only p1 plays Miso Soup on the next turn, so it stays
+	game.Players[1].SelectedCard = &second
+	game.RoundPhase = models.PhaseSelecting
+	revealCards(game)
+	if len(game.Players[0].Collection) != 1 || game.Players[0].Collection[0].Turn != 2 {
+		t.Errorf("Expected p1 to keep a turn 2 Miso Soup, got %v", game.Players[0].Collection)
+	}
+	if len(game.Players[1].Collection) != 1 || game.Players[1].Collection[0].Type != models.CardTypeTofu {
+		t.Errorf("Expected p2 to keep Tofu, got %v", game.Players[1].Collection)
+	}
+}
+
+// TestDessertScores tests that only the menu's dessert is scored at the end
+func TestDessertScores(t *testing.T) {
+	menu := DefaultPartyMenu
+	menu.Dessert = models.CardTypeFruit
+	game := &models.Game{
+		Menu: &menu,
+		Players: []*models.Player{
+			{ID: "p1", PuddingCards: []models.Card{
+				{Type: models.CardTypeFruit, Variant: "Watermelon+Watermelon"},
+				{Type: models.CardTypeFruit, Variant: "Pineapple+Orange"},
+			}},
+			{ID: "p2"},
+			{ID: "p3"},
+		},
+	}
+
+	scores := dessertScores(game)
+	// Watermelon 2 (1), Pineapple 1 (0), Orange 1 (0)
+	if scores["p1"].Points != 1 {
+		t.Errorf("Expected p1 to score 1, got %d", scores["p1"].Points)
+	}
+	// No fruit at all costs 2 per fruit, with no Pudding penalty on top
+	if scores["p2"].Points != -6 {
+		t.Errorf("Expected p2 to score -6, got %d", scores["p2"].Points)
+	}
+}
+
+// TestPartyGameReplays tests that a Sushi Go Party! game plays through and replays exactly
+func TestPartyGameReplays(t *testing.T) {
+	for _, dessert := range MenuDesserts {
+		t.Run(string(dessert), func(t *testing.T) {
+			engine := NewEngine()
+			engine.SetSeed(5)
+
+			menu := models.Menu{
+				Roll:       models.CardTypeUramaki,
+				Appetizers: []models.CardType{models.CardTypeOnigiri, models.CardTypeMisoSoup, models.CardTypeEdamame},
+				Specials:   []models.CardType{models.CardTypeSoySauce, models.CardTypeChopsticks},
+				Dessert:    dessert,
+			}
+			game, err := engine.CreateGameWithOptions([]string{"p1", "p2", "p3", "p4"}, GameOptions{Menu: &menu})
+			if err != nil {
+				t.Fatalf("Failed to create game: %v", err)
+			}
+
+			result := playFullGame(t, engine, game.ID)
+
+			replayed, err := engine.ReplayGame(game.ID)
+			if err != nil {
+				t.Fatalf("Failed to replay: %v", err)
+			}
+			for _, ranking := range result.Rankings {
+				if player := findPlayer(replayed, ranking.PlayerID); player.Score != ranking.FinalScore {
+					t.Errorf("Expected replayed %s to score %d, got %d", ranking.PlayerID, ranking.FinalScore, player.Score)
+				}
+			}
+		})
+	}
+}
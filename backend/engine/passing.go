@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// ErrInvalidPassPolicy is returned for a pass policy that doesn't hand every hand to exactly one player
+var ErrInvalidPassPolicy = errors.New("invalid pass policy")
+
+// ValidatePassPolicy checks the direction and, for custom passing, that the permutation moves every seat to a distinct seat
+// The permutation's length is checked against the table when the game starts
+func ValidatePassPolicy(policy models.PassPolicy) error {
+	switch policy.Direction {
+	case models.PassLeft, models.PassRight, models.PassAlternate:
+		if len(policy.Permutation) > 0 {
+			return fmt.Errorf("%w: only custom passing takes a permutation", ErrInvalidPassPolicy)
+		}
+		return nil
+	case models.PassCustom:
+		// Checked below
+	default:
+		return fmt.Errorf("%w: direction must be left, right, alternate or custom, got %q", ErrInvalidPassPolicy, policy.Direction)
+	}
+
+	seats := len(policy.Permutation)
+	if seats == 0 {
+		return fmt.Errorf("%w: custom passing needs a permutation", ErrInvalidPassPolicy)
+	}
+	seen := make([]bool, seats)
+	for from, to := range policy.Permutation {
+		if to < 0 || to >= seats {
+			return fmt.Errorf("%w: seat %d passes to seat %d, outside a table of %d", ErrInvalidPassPolicy, from, to, seats)
+		}
+		if seen[to] {
+			return fmt.Errorf("%w: seat %d receives more than one hand", ErrInvalidPassPolicy, to)
+		}
+		seen[to] = true
+	}
+	return nil
+}
+
+// checkPassPolicy verifies a custom permutation covers the table that sat down
+func checkPassPolicy(game *models.Game) error {
+	if game.PassPolicy.Direction != models.PassCustom {
+		return nil
+	}
+	if len(game.PassPolicy.Permutation) != len(game.Players) {
+		return fmt.Errorf("%w: the permutation has %d seats, the table has %d players",
+			ErrInvalidPassPolicy, len(game.PassPolicy.Permutation), len(game.Players))
+	}
+	return nil
+}
+
+// PassDirectionFor returns the direction hands are passed in a round: left, right or custom
+// Games saved before pass policies existed pass left
+func PassDirectionFor(game *models.Game, round int) models.PassDirection {
+	switch game.PassPolicy.Direction {
+	case "":
+		return models.PassLeft
+	case models.PassAlternate:
+		if round%2 == 0 {
+			return models.PassRight
+		}
+		return models.PassLeft
+	default:
+		return game.PassPolicy.Direction
+	}
+}
+
+// passTargets returns the seat each player passes their hand to this round
+func passTargets(game *models.Game) []int {
+	numPlayers := len(game.Players)
+	direction := PassDirectionFor(game, game.CurrentRound)
+	if direction == models.PassCustom && len(game.PassPolicy.Permutation) == numPlayers {
+		return game.PassPolicy.Permutation
+	}
+
+	targets := make([]int, numPlayers)
+	for i := range targets {
+		if direction == models.PassRight {
+			targets[i] = (i - 1 + numPlayers) % numPlayers
+		} else {
+			targets[i] = (i + 1) % numPlayers
+		}
+	}
+	return targets
+}
+
+// SetPassPolicy sets how hands are passed in new games
+func (e *Engine) SetPassPolicy(policy models.PassPolicy) error {
+	if err := ValidatePassPolicy(policy); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.passPolicy = policy
+	return nil
+}
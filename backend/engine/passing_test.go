@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestValidatePassPolicy tests that every hand must go to exactly one player
+func TestValidatePassPolicy(t *testing.T) {
+	valid := []models.PassPolicy{
+		{Direction: models.PassLeft},
+		{Direction: models.PassRight},
+		{Direction: models.PassAlternate},
+		{Direction: models.PassCustom, Permutation: []int{2, 0, 3, 1}},
+	}
+	for _, policy := range valid {
+		if err := ValidatePassPolicy(policy); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", policy, err)
+		}
+	}
+
+	invalid := map[string]models.PassPolicy{
+		"unknown direction":          {Direction: "across"},
+		"empty direction":            {},
+		"permutation without custom": {Direction: models.PassLeft, Permutation: []int{1, 0}},
+		"custom without permutation": {Direction: models.PassCustom},
+		"seat outside the table":     {Direction: models.PassCustom, Permutation: []int{1, 2}},
+		"two hands to one seat":      {Direction: models.PassCustom, Permutation: []int{1, 1, 0}},
+	}
+	for name, policy := range invalid {
+		if err := ValidatePassPolicy(policy); !errors.Is(err, ErrInvalidPassPolicy) {
+			t.Errorf("%s: expected ErrInvalidPassPolicy, got %v", name, err)
+		}
+	}
+}
+
+// TestPassHandsDirections tests who receives each hand under each policy
+func TestPassHandsDirections(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   models.PassPolicy
+		round    int
+		expected []string // Hand each seat holds after passing
+	}{
+		{"left", models.PassPolicy{Direction: models.PassLeft}, 1, []string{"d", "a", "b", "c"}},
+		{"saved before policies", models.PassPolicy{}, 1, []string{"d", "a", "b", "c"}},
+		{"right", models.PassPolicy{Direction: models.PassRight}, 1, []string{"b", "c", "d", "a"}},
+		{"alternate odd round", models.PassPolicy{Direction: models.PassAlternate}, 3, []string{"d", "a", "b", "c"}},
+		{"alternate even round", models.PassPolicy{Direction: models.PassAlternate}, 2, []string{"b", "c", "d", "a"}},
+		{"custom", models.PassPolicy{Direction: models.PassCustom, Permutation: []int{2, 3, 0, 1}}, 1, []string{"c", "d", "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &models.Game{PassPolicy: tt.policy, CurrentRound: tt.round, RoundPhase: models.PhaseRevealing}
+			for _, id := range []string{"a", "b", "c", "d"} {
+				// Each hand keeps a card named after its original owner
+				game.Players = append(game.Players, &models.Player{ID: id, Hand: []models.Card{{ID: id}}})
+			}
+
+			if err := passHands(game); err != nil {
+				t.Fatalf("Failed to pass hands: %v", err)
+			}
+			for i, player := range game.Players {
+				if player.Hand[0].ID != tt.expected[i] {
+					t.Errorf("Expected seat %d to hold %s's hand, got %s's", i, tt.expected[i], player.Hand[0].ID)
+				}
+			}
+		})
+	}
+}
+
+// TestPassPolicyOptions tests that games record their pass policy and check custom passing against the table
+func TestPassPolicyOptions(t *testing.T) {
+	engine := NewEngine()
+
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	if game.PassPolicy.Direction != models.PassLeft {
+		t.Errorf("Expected new games to pass left, got %q", game.PassPolicy.Direction)
+	}
+
+	bad := models.PassPolicy{Direction: "across"}
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{PassPolicy: &bad}); !errors.Is(err, ErrInvalidPassPolicy) {
+		t.Errorf("Expected ErrInvalidPassPolicy, got %v", err)
+	}
+	if err := engine.SetPassPolicy(bad); !errors.Is(err, ErrInvalidPassPolicy) {
+		t.Errorf("Expected ErrInvalidPassPolicy, got %v", err)
+	}
+
+	custom := models.PassPolicy{Direction: models.PassCustom, Permutation: []int{1, 2, 0}}
+	short, err := engine.CreateGameWithOptions([]string{"p1", "p2"}, GameOptions{PassPolicy: &custom})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if err := engine.StartGame(short.ID); !errors.Is(err, ErrInvalidPassPolicy) {
+		t.Errorf("Expected a 3-seat permutation to be rejected for 2 players, got %v", err)
+	}
+	engine.JoinGame(short.ID, "p3")
+	if err := engine.StartGame(short.ID); err != nil {
+		t.Errorf("Failed to start with a full permutation: %v", err)
+	}
+
+	if err := engine.SetPassPolicy(models.PassPolicy{Direction: models.PassAlternate}); err != nil {
+		t.Fatalf("Failed to set pass policy: %v", err)
+	}
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	alternate := liveGame(t, engine, created.ID)
+	playFullGame(t, engine, alternate.ID)
+
+	replayed, err := engine.ReplayGame(alternate.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.PassPolicy.Direction != models.PassAlternate {
+		t.Errorf("Expected the replay to keep the pass policy, got %q", replayed.PassPolicy.Direction)
+	}
+	for i, player := range replayed.Players {
+		if player.Score != alternate.Players[i].Score {
+			t.Errorf("Expected replayed score %d for %s, got %d", alternate.Players[i].Score, player.ID, player.Score)
+		}
+	}
+}
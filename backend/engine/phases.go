@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// Action is a move on a game that only some phases allow
+type Action string
+
+const (
+	ActionJoin         Action = "join"
+	ActionSetReady     Action = "set_ready"
+	ActionRemovePlayer Action = "remove_player"
+	ActionStartGame    Action = "start_game"
+	ActionStartRound   Action = "start_round"
+	ActionPlayCard     Action = "play_card"
+	ActionWithdrawCard Action = "withdraw_card"
+	ActionReveal       Action = "reveal"
+	ActionPass         Action = "pass"
+	ActionScore        Action = "score"
+	ActionEndGame      Action = "end_game"
+	ActionEndEarly     Action = "end_early"
+	ActionPickMenuCard Action = "pick_menu_card"
+)
+
+var (
+	ErrWrongPhase = errors.New("action not allowed in this phase")
+	ErrGameOver   = errors.New("game has already ended")
+)
+
+// PhaseError reports an action attempted in a phase that doesn't allow it
+// It matches ErrWrongPhase with errors.Is
+type PhaseError struct {
+	Action Action
+	Phase  models.RoundPhase
+}
+
+func (e *PhaseError) Error() string {
+	return fmt.Sprintf("cannot %s in the %s phase", e.Action, e.Phase)
+}
+
+func (e *PhaseError) Is(target error) bool {
+	return target == ErrWrongPhase
+}
+
+// actionPhases lists the phases each action is allowed in
+// A round is also started in the selecting phase the game opens with, before any card is dealt
+var actionPhases = map[Action][]models.RoundPhase{
+	ActionJoin:         {models.PhaseWaitingForPlayers},
+	ActionSetReady:     {models.PhaseWaitingForPlayers},
+	ActionRemovePlayer: {models.PhaseWaitingForPlayers},
+	ActionStartGame:    {models.PhaseWaitingForPlayers},
+	ActionStartRound:   {models.PhaseSelecting, models.PhaseRoundEnd},
+	ActionPlayCard:     {models.PhaseSelecting},
+	ActionWithdrawCard: {models.PhaseSelecting},
+	ActionReveal:       {models.PhaseSelecting},
+	ActionPass:         {models.PhaseRevealing},
+	ActionScore:        {models.PhaseScoring},
+	ActionEndGame:      {models.PhaseGameEnd},
+	ActionEndEarly:     {models.PhaseRoundEnd},
+	ActionPickMenuCard: {models.PhaseRevealing},
+}
+
+// phaseTransitions lists the phases each phase may move to
+// Hands are passed right after the reveal, so PhasePassing is never entered
+// A game only ends from PhaseRoundEnd when its next round can't be dealt
+var phaseTransitions = map[models.RoundPhase][]models.RoundPhase{
+	models.PhaseWaitingForPlayers: {models.PhaseSelecting},
+	models.PhaseSelecting:         {models.PhaseSelecting, models.PhaseRevealing},
+	models.PhaseRevealing:         {models.PhaseSelecting, models.PhaseScoring},
+	models.PhaseScoring:           {models.PhaseRoundEnd, models.PhaseGameEnd},
+	models.PhaseRoundEnd:          {models.PhaseSelecting, models.PhaseGameEnd},
+	models.PhaseGameEnd:           {},
+}
+
+// Allows reports whether an action is allowed in the phase
+func Allows(phase models.RoundPhase, action Action) bool {
+	return containsPhase(actionPhases[action], phase)
+}
+
+// CanTransition reports whether a game may move from one phase to the other
+func CanTransition(from, to models.RoundPhase) bool {
+	return containsPhase(phaseTransitions[from], to)
+}
+
+// checkPhase returns a PhaseError when the game's phase doesn't allow the action
+func checkPhase(game *models.Game, action Action) error {
+	if !Allows(game.RoundPhase, action) {
+		return &PhaseError{Action: action, Phase: game.RoundPhase}
+	}
+	return nil
+}
+
+// setPhase moves the game to the next phase, refusing transitions the state machine doesn't have
+func setPhase(game *models.Game, to models.RoundPhase) error {
+	if !CanTransition(game.RoundPhase, to) {
+		return fmt.Errorf("%w: %s cannot move to %s", ErrWrongPhase, game.RoundPhase, to)
+	}
+	game.RoundPhase = to
+	return nil
+}
+
+// checkRoundStart verifies a round can be dealt: after the last one was scored,
+// or when the game has just started and no hand has been dealt yet
+func checkRoundStart(game *models.Game) error {
+	if err := checkPhase(game, ActionStartRound); err != nil {
+		return err
+	}
+	if game.RoundPhase == models.PhaseSelecting && roundDealt(game) {
+		return &PhaseError{Action: ActionStartRound, Phase: game.RoundPhase}
+	}
+	return nil
+}
+
+// roundDealt reports whether any player holds cards or has played one this round
+func roundDealt(game *models.Game) bool {
+	if game.Turn > 0 {
+		return true
+	}
+	for _, player := range game.Players {
+		if len(player.Hand) > 0 || player.SelectedCard != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// gameEnded reports whether the final scores have been applied
+func gameEnded(game *models.Game) bool {
+	for _, player := range game.Players {
+		if player.DessertScore != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// allSelected reports whether every player has picked a card this turn
+func allSelected(game *models.Game) bool {
+	for _, player := range game.Players {
+		if player.SelectedCard == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPhase(phases []models.RoundPhase, phase models.RoundPhase) bool {
+	for _, p := range phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
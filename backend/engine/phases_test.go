@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestPhaseTransitions tests the moves the state machine allows between phases
+func TestPhaseTransitions(t *testing.T) {
+	allowed := [][2]models.RoundPhase{
+		{models.PhaseWaitingForPlayers, models.PhaseSelecting},
+		{models.PhaseSelecting, models.PhaseRevealing},
+		{models.PhaseRevealing, models.PhaseSelecting},
+		{models.PhaseRevealing, models.PhaseScoring},
+		{models.PhaseScoring, models.PhaseRoundEnd},
+		{models.PhaseScoring, models.PhaseGameEnd},
+		{models.PhaseRoundEnd, models.PhaseSelecting},
+		{models.PhaseRoundEnd, models.PhaseGameEnd}, // Only when the next round can't be dealt
+	}
+	for _, move := range allowed {
+		if !CanTransition(move[0], move[1]) {
+			t.Errorf("Expected %s to move to %s", move[0], move[1])
+		}
+	}
+
+	refused := [][2]models.RoundPhase{
+		{models.PhaseWaitingForPlayers, models.PhaseScoring},
+		{models.PhaseSelecting, models.PhaseScoring},
+		{models.PhaseRevealing, models.PhaseRevealing},
+		{models.PhaseRoundEnd, models.PhaseScoring},
+		{models.PhaseGameEnd, models.PhaseSelecting},
+		{models.PhaseGameEnd, models.PhaseWaitingForPlayers},
+	}
+	for _, move := range refused {
+		if CanTransition(move[0], move[1]) {
+			t.Errorf("Expected %s not to move to %s", move[0], move[1])
+		}
+	}
+
+	game := &models.Game{RoundPhase: models.PhaseGameEnd}
+	if err := setPhase(game, models.PhaseSelecting); !errors.Is(err, ErrWrongPhase) || game.RoundPhase != models.PhaseGameEnd {
+		t.Errorf("Expected the finished game to stay put with ErrWrongPhase, got %v in %s", err, game.RoundPhase)
+	}
+}
+
+// TestPhaseErrors tests that actions outside their phase fail with a PhaseError
+func TestPhaseErrors(t *testing.T) {
+	engine := NewEngine()
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+
+	expectPhaseError := func(name string, err error, action Action) {
+		t.Helper()
+		var phaseErr *PhaseError
+		if !errors.Is(err, ErrWrongPhase) || !errors.As(err, &phaseErr) || phaseErr.Action != action {
+			t.Errorf("%s: expected a %s PhaseError, got %v", name, action, err)
+		}
+	}
+
+	expectPhaseError("play while waiting", engine.PlayCard(game.ID, "p1", 0, false, nil), ActionPlayCard)
+	expectPhaseError("reveal while waiting", engine.RevealCards(game.ID), ActionReveal)
+	expectPhaseError("score while waiting", engine.ScoreRound(game.ID), ActionScore)
+
+	engine.StartGame(game.ID)
+	expectPhaseError("restart", engine.StartGame(game.ID), ActionStartGame)
+	expectPhaseError("join a running game", engine.JoinGame(game.ID, "p3"), ActionJoin)
+	expectPhaseError("pass before the first round", engine.PassHands(game.ID), ActionPass)
+
+	if err := engine.StartRound(game.ID); err != nil {
+		t.Fatalf("Failed to start round: %v", err)
+	}
+	expectPhaseError("redeal mid-round", engine.StartRound(game.ID), ActionStartRound)
+	expectPhaseError("pass before the reveal", engine.PassHands(game.ID), ActionPass)
+	_, err := engine.EndGame(game.ID)
+	expectPhaseError("end mid-round", err, ActionEndGame)
+
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	engine.PlayCard(game.ID, "p2", 0, false, nil)
+	engine.RevealCards(game.ID)
+	expectPhaseError("play during the reveal", engine.PlayCard(game.ID, "p1", 0, false, nil), ActionPlayCard)
+	expectPhaseError("withdraw during the reveal", engine.WithdrawCard(game.ID, "p1"), ActionWithdrawCard)
+	expectPhaseError("reveal twice", engine.RevealCards(game.ID), ActionReveal)
+	expectPhaseError("score mid-round", engine.ScoreRound(game.ID), ActionScore)
+	if _, err := engine.PlayBots(game.ID); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected bots to wait for the next turn, got %v", err)
+	}
+}
+
+// TestEndGameOnce tests that the final scores are only applied once
+func TestEndGameOnce(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(20)
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	game := liveGame(t, engine, created.ID)
+	playFullGame(t, engine, game.ID)
+
+	score := game.Players[0].Score
+	if _, err := engine.EndGame(game.ID); err != ErrGameOver {
+		t.Errorf("Expected ErrGameOver, got %v", err)
+	}
+	if game.Players[0].Score != score {
+		t.Errorf("Expected the score to stay %d, got %d", score, game.Players[0].Score)
+	}
+	if err := engine.StartRound(game.ID); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected no round after the game ended, got %v", err)
+	}
+}
+
+// TestAdvance tests that Advance runs the reveal, pass, score and end chain once every pick is in
+func TestAdvance(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(21)
+	created, _ := engine.CreateGame([]string{"p1"})
+	game := liveGame(t, engine, created.ID)
+	bot, _ := engine.AddBot(game.ID, BotStrategyGreedy)
+
+	if _, err := engine.Advance(game.ID); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected nothing to advance before the game starts, got %v", err)
+	}
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	advance, err := engine.Advance(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to advance: %v", err)
+	}
+	if len(advance.BotsPlayed) != 1 || advance.BotsPlayed[0] != bot.ID || advance.Revealed {
+		t.Errorf("Expected the bot to pick and the turn to wait on p1, got %+v", advance)
+	}
+
+	var result *GameResult
+	rounds := 0
+	for result == nil {
+		if err := engine.PlayCard(game.ID, "p1", 0, false, nil); err != nil {
+			t.Fatalf("Failed to play: %v", err)
+		}
+		advance, err := engine.Advance(game.ID)
+		if err != nil {
+			t.Fatalf("Failed to advance: %v", err)
+		}
+		if !advance.Revealed {
+			t.Fatalf("Expected the turn to be revealed, got %+v", advance)
+		}
+		if advance.RoundScored > 0 {
+			rounds++
+			if advance.RoundScored != rounds {
+				t.Errorf("Expected round %d to be scored, got %d", rounds, advance.RoundScored)
+			}
+			if advance.Result == nil && (game.RoundPhase != models.PhaseSelecting || game.Turn != 0) {
+				t.Errorf("Expected the next round to be dealt, got %s on turn %d", game.RoundPhase, game.Turn)
+			}
+		}
+		result = advance.Result
+	}
+
+	if rounds != 3 || game.RoundPhase != models.PhaseGameEnd || len(result.Rankings) != 2 {
+		t.Errorf("Expected 3 rounds and 2 rankings, got %d rounds and %+v", rounds, result)
+	}
+	if _, err := engine.Advance(game.ID); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected nothing to advance after the game ended, got %v", err)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	for i, player := range replayed.Players {
+		if player.Score != game.Players[i].Score {
+			t.Errorf("Expected replayed score %d for %s, got %d", game.Players[i].Score, player.ID, player.Score)
+		}
+	}
+}
+
+// firstRoundDealer deals the first round as usual and runs out of cards after it
+type firstRoundDealer struct{ DefaultDealer }
+
+func (d *firstRoundDealer) DealCards(game *models.Game, cardsPerHand int, r *rand.Rand) error {
+	if game.CurrentRound > 1 {
+		return ErrDeckExhausted
+	}
+	return d.DefaultDealer.DealCards(game, cardsPerHand, r)
+}
+
+// TestAdvanceEndsEarly tests that a game whose next round can't be dealt ends with the rounds already scored
+func TestAdvanceEndsEarly(t *testing.T) {
+	engine := NewEngineWithDealer(&firstRoundDealer{})
+	created, _ := engine.CreateGame([]string{"p1"})
+	engine.AddBot(created.ID, BotStrategyGreedy)
+	engine.StartGame(created.ID)
+	engine.StartRound(created.ID)
+
+	var advance *AdvanceResult
+	var err error
+	for advance == nil || advance.RoundScored == 0 {
+		engine.PlayCard(created.ID, "p1", 0, false, nil)
+		if advance, err = engine.Advance(created.ID); err != nil && advance.RoundScored == 0 {
+			t.Fatalf("Failed to advance: %v", err)
+		}
+	}
+
+	if !errors.Is(err, ErrEndedEarly) || !errors.Is(err, ErrDeckExhausted) {
+		t.Errorf("Expected ErrEndedEarly caused by ErrDeckExhausted, got %v", err)
+	}
+	if advance.Result == nil || len(advance.Result.Rankings) != 2 {
+		t.Fatalf("Expected the final result, got %+v", advance.Result)
+	}
+	game, _ := engine.GetGame(created.ID)
+	if game.RoundPhase != models.PhaseGameEnd || game.NumRounds != 1 {
+		t.Errorf("Expected the game to end after its one round, got %s with %d rounds", game.RoundPhase, game.NumRounds)
+	}
+
+	replayed, err := engine.ReplayGame(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.RoundPhase != models.PhaseGameEnd || replayed.Players[0].Score != game.Players[0].Score {
+		t.Errorf("Expected the replay to end the same way, got %s", replayed.RoundPhase)
+	}
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+var (
+	ErrInviteRequired  = errors.New("this game is private; an invite code is required")
+	ErrWrongInviteCode = errors.New("wrong invite code")
+)
+
+// inviteSaltSize is the length of the random salt each private game's code is hashed with
+const inviteSaltSize = 16
+
+// hashInvite hashes an invite code with its game's salt
+// Only the hash is kept, so a saved game or its event log doesn't give away the code or a reused password
+func hashInvite(salt []byte, code string) []byte {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), code...))
+	return sum[:]
+}
+
+// newInviteHash salts and hashes the code of a new private game
+func newInviteHash(code string) (salt, hash []byte, err error) {
+	salt = make([]byte, inviteSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	return salt, hashInvite(salt, code), nil
+}
+
+// CheckInvite verifies the code a client gave for joining or watching a game
+// Public games need no code
+func CheckInvite(game *models.Game, code string) error {
+	if !game.Private {
+		return nil
+	}
+	if code == "" {
+		return ErrInviteRequired
+	}
+	if subtle.ConstantTimeCompare(hashInvite(game.InviteSalt, code), game.InviteHash) != 1 {
+		return ErrWrongInviteCode
+	}
+	return nil
+}
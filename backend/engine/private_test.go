@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestPrivateGames tests that private games are left off the games list and take their invite code
+func TestPrivateGames(t *testing.T) {
+	engine := NewEngine()
+	public, _ := engine.CreateGame([]string{"p1"})
+	private, err := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Private: true})
+	if err != nil {
+		t.Fatalf("Failed to create private game: %v", err)
+	}
+	password, _ := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Password: "hunter2"})
+
+	if len(private.InviteCode) != 6 || strings.Trim(private.InviteCode, inviteCodeAlphabet) != "" {
+		t.Errorf("Expected a six-character invite code, got %q", private.InviteCode)
+	}
+	if !password.Private || password.InviteCode != "hunter2" {
+		t.Errorf("Expected the password to make the game private, got %v with %q", password.Private, password.InviteCode)
+	}
+
+	games := engine.ListGames()
+	if len(games) != 1 || games[0]["id"] != public.ID {
+		t.Errorf("Expected only the public game to be listed, got %v", games)
+	}
+
+	if err := CheckInvite(public, ""); err != nil {
+		t.Errorf("Expected a public game to need no code, got %v", err)
+	}
+	if err := CheckInvite(private, ""); err != ErrInviteRequired {
+		t.Errorf("Expected ErrInviteRequired, got %v", err)
+	}
+	if err := CheckInvite(password, "hunter3"); err != ErrWrongInviteCode {
+		t.Errorf("Expected ErrWrongInviteCode, got %v", err)
+	}
+	if err := CheckInvite(private, private.InviteCode); err != nil {
+		t.Errorf("Expected the invite code to be accepted, got %v", err)
+	}
+
+	replayed, err := engine.ReplayGame(private.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if !replayed.Private || CheckInvite(replayed, private.InviteCode) != nil {
+		t.Errorf("Expected the replay to keep taking the invite code, got private %v", replayed.Private)
+	}
+}
+
+// TestInviteCodeHashed tests that only the creator is handed a private game's code, and that it isn't kept in the clear
+func TestInviteCodeHashed(t *testing.T) {
+	engine := NewEngine()
+	created, err := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Password: "hunter2"})
+	if err != nil {
+		t.Fatalf("Failed to create private game: %v", err)
+	}
+	other, _ := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{Password: "hunter2"})
+
+	game, err := engine.GetGame(created.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if game.InviteCode != "" {
+		t.Errorf("Expected only the creator to be handed the code, got %q", game.InviteCode)
+	}
+	if err := CheckInvite(game, "hunter2"); err != nil {
+		t.Errorf("Expected the password to be accepted, got %v", err)
+	}
+	if string(game.InviteHash) == string(other.InviteHash) {
+		t.Error("Expected games with the same password to be salted apart")
+	}
+
+	saved, _ := json.Marshal(game)
+	if strings.Contains(string(saved), "hunter2") {
+		t.Errorf("Expected the saved game to leave out the password, got %s", saved)
+	}
+	events, _ := engine.Events(created.ID)
+	for _, event := range events {
+		if strings.Contains(string(event.Payload), "hunter2") {
+			t.Errorf("Expected %s to leave out the password, got %s", event.Type, event.Payload)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+var (
+	ErrInvalidReadyCheck = errors.New("ready check must be off, required or auto")
+	ErrPlayersNotReady   = errors.New("not every player is ready")
+)
+
+// ValidateReadyCheck checks that check is a known ready check
+func ValidateReadyCheck(check models.ReadyCheck) error {
+	switch check {
+	case models.ReadyCheckOff, models.ReadyCheckRequired, models.ReadyCheckAuto:
+		return nil
+	}
+	return ErrInvalidReadyCheck
+}
+
+// SetReadyCheck sets whether players must be ready before new games start
+func (e *Engine) SetReadyCheck(check models.ReadyCheck) error {
+	if err := ValidateReadyCheck(check); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.readyCheck = check
+	return nil
+}
+
+// SetReady records whether a player is ready for the game to start
+// With ReadyCheckAuto the game starts once every player is ready and enough have joined,
+// which the result reports so the caller can deal the first round
+func (e *Engine) SetReady(gameID, playerID string, ready bool) (bool, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return false, err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+
+	if err := setReady(game, playerID, ready); err != nil {
+		return false, err
+	}
+	if err := e.commit(entry, EventPlayerReady, PlayerPayload{PlayerID: playerID, Ready: ready}); err != nil {
+		return false, err
+	}
+
+	if game.ReadyCheck != models.ReadyCheckAuto || !allReady(game) {
+		return false, nil
+	}
+	if err := startGame(game); err != nil {
+		// Everyone at the table is ready, but the game waits for more players
+		if errors.Is(err, ErrNotEnoughPlayers) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := e.commit(entry, EventGameStarted, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setReady marks a player ready or not in the lobby
+func setReady(game *models.Game, playerID string, ready bool) error {
+	if err := checkPhase(game, ActionSetReady); err != nil {
+		return err
+	}
+
+	player := findPlayer(game, playerID)
+	if player == nil {
+		return errors.New("player not found in game")
+	}
+	if player.IsBot {
+		return errors.New("bots are always ready")
+	}
+
+	player.Ready = ready
+	return nil
+}
+
+// checkReady verifies every player is ready when the game's ready check asks for it
+func checkReady(game *models.Game) error {
+	if game.ReadyCheck == models.ReadyCheckRequired || game.ReadyCheck == models.ReadyCheckAuto {
+		if !allReady(game) {
+			return ErrPlayersNotReady
+		}
+	}
+	return nil
+}
+
+// allReady reports whether every player at the table is ready
+func allReady(game *models.Game) bool {
+	for _, player := range game.Players {
+		if !player.Ready {
+			return false
+		}
+	}
+	return true
+}
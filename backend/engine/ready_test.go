@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestReadyCheckRequired tests that the game only starts once every player is ready
+func TestReadyCheckRequired(t *testing.T) {
+	engine := NewEngine()
+	if _, err := engine.CreateGameWithOptions([]string{"p1"}, GameOptions{ReadyCheck: "sometimes"}); err != ErrInvalidReadyCheck {
+		t.Errorf("Expected ErrInvalidReadyCheck, got %v", err)
+	}
+
+	created, _ := engine.CreateGameWithOptions([]string{"p1", "p2"}, GameOptions{ReadyCheck: models.ReadyCheckRequired})
+	game := liveGame(t, engine, created.ID)
+	bot, _ := engine.AddBot(game.ID, BotStrategyGreedy)
+	if !bot.Ready {
+		t.Error("Expected the bot to be ready")
+	}
+	if _, err := engine.SetReady(game.ID, bot.ID, false); err == nil {
+		t.Error("Expected bots to stay ready")
+	}
+
+	if err := engine.StartGame(game.ID); err != ErrPlayersNotReady {
+		t.Errorf("Expected ErrPlayersNotReady, got %v", err)
+	}
+	engine.SetReady(game.ID, "p1", true)
+	engine.SetReady(game.ID, "p2", true)
+	engine.SetReady(game.ID, "p2", false)
+	if err := engine.StartGame(game.ID); err != ErrPlayersNotReady {
+		t.Errorf("Expected ErrPlayersNotReady once p2 changed their mind, got %v", err)
+	}
+
+	if started, err := engine.SetReady(game.ID, "p2", true); started || err != nil {
+		t.Errorf("Expected the game to wait for the host, got %v, %v", started, err)
+	}
+	if err := engine.StartGame(game.ID); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	if _, err := engine.SetReady(game.ID, "p1", false); !errors.Is(err, ErrWrongPhase) {
+		t.Errorf("Expected readiness to be fixed once the game started, got %v", err)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.ReadyCheck != models.ReadyCheckRequired || replayed.RoundPhase != models.PhaseSelecting || !replayed.Players[1].Ready {
+		t.Errorf("Expected the replay to start with everyone ready, got %s in %s", replayed.ReadyCheck, replayed.RoundPhase)
+	}
+}
+
+// TestReadyCheckAuto tests that the game starts by itself once enough players are ready
+func TestReadyCheckAuto(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetReadyCheck(models.ReadyCheckAuto); err != nil {
+		t.Fatalf("Failed to set ready check: %v", err)
+	}
+	created, _ := engine.CreateGame([]string{"p1"})
+	game := liveGame(t, engine, created.ID)
+
+	if started, err := engine.SetReady(game.ID, "p1", true); started || err != nil {
+		t.Errorf("Expected a lone player to wait for company, got %v, %v", started, err)
+	}
+	engine.JoinGame(game.ID, "p2")
+	if started, err := engine.SetReady(game.ID, "p2", true); !started || err != nil {
+		t.Fatalf("Expected the game to start, got %v, %v", started, err)
+	}
+	if game.RoundPhase != models.PhaseSelecting || game.CurrentRound != 1 {
+		t.Errorf("Expected the first round to be ready to deal, got %s in round %d", game.RoundPhase, game.CurrentRound)
+	}
+	if err := engine.StartRound(game.ID); err != nil {
+		t.Errorf("Failed to deal the first round: %v", err)
+	}
+}
+
+// TestReadyCheckOff tests that the host starts the game at will by default
+func TestReadyCheckOff(t *testing.T) {
+	engine := NewEngine()
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	if game.ReadyCheck != models.ReadyCheckOff {
+		t.Errorf("Expected the ready check to be off, got %s", game.ReadyCheck)
+	}
+	if started, _ := engine.SetReady(game.ID, "p1", true); started {
+		t.Error("Expected the game not to start by itself")
+	}
+	if err := engine.StartGame(game.ID); err != nil {
+		t.Errorf("Expected the game to start with p2 not ready, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+// TestShuffleDeckIsReproducible tests that the same seed always yields the same order
+func TestShuffleDeckIsReproducible(t *testing.T) {
+	deck := InitializeDeck()
+
+	first := ShuffleDeck(deck, rand.New(rand.NewSource(7)))
+	second := ShuffleDeck(deck, rand.New(rand.NewSource(7)))
+	other := ShuffleDeck(deck, rand.New(rand.NewSource(8)))
+
+	same := true
+	differs := false
+	for i := range first {
+		if first[i].ID != second[i].ID {
+			same = false
+		}
+		if first[i].ID != other[i].ID {
+			differs = true
+		}
+	}
+
+	if !same {
+		t.Error("Expected identical shuffles for the same seed")
+	}
+	if !differs {
+		t.Error("Expected a different shuffle for a different seed")
+	}
+}
+
+// TestGenerateGameIDIsReproducible tests ID generation from an injected source
+func TestGenerateGameIDIsReproducible(t *testing.T) {
+	a := GenerateGameID(rand.New(rand.NewSource(99)))
+	b := GenerateGameID(rand.New(rand.NewSource(99)))
+	if a != b {
+		t.Errorf("Expected identical IDs for the same seed, got %s and %s", a, b)
+	}
+
+	nameA := GeneratePlayerName(rand.New(rand.NewSource(99)))
+	nameB := GeneratePlayerName(rand.New(rand.NewSource(99)))
+	if nameA != nameB {
+		t.Errorf("Expected identical names for the same seed, got %s and %s", nameA, nameB)
+	}
+}
+
+// TestEngineSeedReproducesGame tests that a seed plus the move list replays a game bit-for-bit
+func TestEngineSeedReproducesGame(t *testing.T) {
+	play := func() ([]byte, *GameResult) {
+		engine := NewEngine()
+		engine.SetSeed(2024)
+
+		game, err := engine.CreateGame([]string{"p1", "p2", "p3"})
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		result := playFullGame(t, engine, game.ID)
+
+		final, _ := engine.GetGame(game.ID)
+		data, _ := json.Marshal(struct {
+			ID      string
+			Seed    int64
+			Players interface{}
+		}{final.ID, final.Seed, final.Players})
+		return data, result
+	}
+
+	firstState, firstResult := play()
+	secondState, secondResult := play()
+
+	if string(firstState) != string(secondState) {
+		t.Errorf("Expected identical games for the same seed\nfirst:  %s\nsecond: %s", firstState, secondState)
+	}
+	if firstResult.Winner != secondResult.Winner {
+		t.Errorf("Expected the same winner, got %s and %s", firstResult.Winner, secondResult.Winner)
+	}
+}
+
+// TestEngineGamesGetDistinctSeeds tests that each game in an engine has its own seed
+func TestEngineGamesGetDistinctSeeds(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(1)
+
+	first, _ := engine.CreateGame([]string{"p1", "p2"})
+	second, _ := engine.CreateGame([]string{"p3", "p4"})
+
+	if first.Seed == second.Seed {
+		t.Error("Expected each game to get a different seed")
+	}
+}
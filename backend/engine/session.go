@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	mathrand "math/rand"
 	"sync"
 	"time"
 
@@ -12,30 +13,124 @@ import (
 	"github.com/sushi-go-game/backend/scoring"
 )
 
+// CardsPerHandByPlayerCount selects rules mode, where the hand size is
+// derived from the player count when the game starts
+const CardsPerHandByPlayerCount = 0
+
 var (
 	ErrGameNotFound        = errors.New("game not found")
-	ErrGameFull            = errors.New("game is full (max 5 players)")
-	ErrNotEnoughPlayers    = errors.New("not enough players to start (minimum 2)")
-	ErrTooManyPlayers      = errors.New("too many players (maximum 5)")
+	ErrGameFull            = errors.New("game is full")
+	ErrNotEnoughPlayers    = errors.New("not enough players to start")
+	ErrTooManyPlayers      = errors.New("too many players")
 	ErrPlayerAlreadyJoined = errors.New("player already in game")
 )
 
 // Engine is the concrete implementation of GameEngine
+// mu guards the game index and the engine's configuration; each game has its own lock,
+// so moves in different games never wait on each other
+// The store, event log and dealer are set up before games are played and must be safe for concurrent use
 type Engine struct {
-	games        map[string]*models.Game
+	games        map[string]*gameEntry
+	store        GameStore
+	eventLog     EventLog // optional durable copy of every event
 	dealer       CardDealer
+	rng          *mathrand.Rand // source for game IDs, names and per-game seeds; guarded by mu
 	mu           sync.RWMutex
 	numRounds    int
 	cardsPerHand int
+	turnTimeout  time.Duration
+	limits       PlayerLimits
+	tieMode      models.TieMode         // Empty: full points for ties
+	deck         *models.DeckDefinition // Nil: the original Sushi Go! deck
+	passPolicy   models.PassPolicy
+	readyCheck   models.ReadyCheck // Empty: ReadyCheckOff
+}
+
+// gameEntry is a game with the lock that serializes its moves
+type gameEntry struct {
+	mu      sync.Mutex
+	game    *models.Game
+	saved   *models.Game   // game as last persisted, restored when a commit fails
+	log     []Event        // events recorded so far
+	rng     *mathrand.Rand // source for bot IDs and bot and auto-play picks
+	deleted bool           // set once DeleteGame removed the game, for callers that looked it up before
+	undo    *models.Game   // table before the last reveal, kept in memory for a vote to take it back
+	vote    *undoVote      // open vote to take the last reveal back
+}
+
+// newGameEntry wraps a game, seeding its random source from the engine's
+// Callers must hold e.mu
+func (e *Engine) newGameEntry(game *models.Game) *gameEntry {
+	return &gameEntry{
+		game:  game,
+		saved: game.Clone(),
+		rng:   mathrand.New(mathrand.NewSource(e.rng.Int63())),
+	}
+}
+
+// lockGame looks up a game and locks it
+// Callers must unlock the returned entry, and must not hold e.mu
+func (e *Engine) lockGame(gameID string) (*gameEntry, error) {
+	e.mu.RLock()
+	entry, exists := e.games[gameID]
+	e.mu.RUnlock()
+	if !exists {
+		return nil, ErrGameNotFound
+	}
+
+	entry.mu.Lock()
+	if entry.deleted {
+		entry.mu.Unlock()
+		return nil, ErrGameNotFound
+	}
+	return entry, nil
+}
+
+// entries returns every game's entry, so games can be locked one by one without holding e.mu
+func (e *Engine) entries() []*gameEntry {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	entries := make([]*gameEntry, 0, len(e.games))
+	for _, entry := range e.games {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// GameOptions overrides the engine defaults for a single game
+// Nil fields fall back to the engine's configuration
+type GameOptions struct {
+	// TurnTimeout is how long players have for each pick; 0 disables the timer
+	TurnTimeout *time.Duration
+	// Menu plays Sushi Go Party! with these categories instead of the original deck
+	Menu *models.Menu
+	// TieMode chooses how tied Maki and Pudding points are shared; empty uses the engine's
+	TieMode models.TieMode
+	// Deck deals the original game from a custom deck instead of the engine's; it can't be combined with a menu
+	Deck *models.DeckDefinition
+	// PassPolicy chooses who receives each hand; nil uses the engine's
+	PassPolicy *models.PassPolicy
+	// Dummy plays the two-player variant, with a dummy third hand the players pick for
+	Dummy bool
+	// ReadyCheck chooses whether players must be ready before the game starts; empty uses the engine's
+	ReadyCheck models.ReadyCheck
+	// Private hides the game from the games list, so players join it with an invite code
+	Private bool
+	// Password is the code for joining a private game instead of a generated one; setting it makes the game private
+	Password string
 }
 
 // NewEngine creates a new game engine with default dealer
 func NewEngine() *Engine {
 	return &Engine{
-		games:        make(map[string]*models.Game),
+		games:        make(map[string]*gameEntry),
+		store:        NewMemoryStore(),
+		rng:          newTimeSeededRand(),
 		dealer:       &DefaultDealer{},
 		numRounds:    3,
-		cardsPerHand: 10,
+		cardsPerHand: CardsPerHandByPlayerCount,
+		limits:       DefaultPlayerLimits,
 	}
 }
 
@@ -45,14 +140,19 @@ func NewEngineWithDealer(dealer CardDealer) *Engine {
 		dealer = &DefaultDealer{}
 	}
 	return &Engine{
-		games:        make(map[string]*models.Game),
+		games:        make(map[string]*gameEntry),
+		store:        NewMemoryStore(),
+		rng:          newTimeSeededRand(),
 		dealer:       dealer,
 		numRounds:    3,
-		cardsPerHand: 10,
+		cardsPerHand: CardsPerHandByPlayerCount,
+		limits:       DefaultPlayerLimits,
 	}
 }
 
 // NewEngineWithConfig creates a new game engine with custom configuration
+// Pass CardsPerHandByPlayerCount to follow the official hand sizes, or a
+// positive cardsPerHand to deal a fixed hand size as a house rule
 func NewEngineWithConfig(dealer CardDealer, numRounds, cardsPerHand int) *Engine {
 	if dealer == nil {
 		dealer = &DefaultDealer{}
@@ -61,85 +161,367 @@ func NewEngineWithConfig(dealer CardDealer, numRounds, cardsPerHand int) *Engine
 		numRounds = 3
 	}
 	if cardsPerHand <= 0 {
-		cardsPerHand = 10
+		cardsPerHand = CardsPerHandByPlayerCount
 	}
 	return &Engine{
-		games:        make(map[string]*models.Game),
+		games:        make(map[string]*gameEntry),
+		store:        NewMemoryStore(),
+		rng:          newTimeSeededRand(),
 		dealer:       dealer,
 		numRounds:    numRounds,
 		cardsPerHand: cardsPerHand,
+		limits:       DefaultPlayerLimits,
 	}
 }
 
-// CreateGame creates a new game session with unique ID generation
-func (e *Engine) CreateGame(playerIDs []string) (*models.Game, error) {
-	if len(playerIDs) > 5 {
-		return nil, ErrTooManyPlayers
-	}
+// newTimeSeededRand creates a random source seeded from the current time
+func newTimeSeededRand() *mathrand.Rand {
+	return mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+}
 
+// SetSeed reseeds the engine so game IDs, generated names and every game's
+// shuffles are reproducible from this point on
+func (e *Engine) SetSeed(seed int64) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	// Generate unique game ID
-	gameID := e.generateUniqueGameID()
+	e.rng = mathrand.New(mathrand.NewSource(seed))
+}
 
-	// Create players with unique IDs
-	players := make([]*models.Player, 0, len(playerIDs))
-	for _, playerID := range playerIDs {
-		player := &models.Player{
-			ID:              playerID,
-			Name:            fmt.Sprintf("Player %s", playerID),
-			Hand:            []models.Card{},
-			Collection:      []models.Card{},
-			PuddingCards:    []models.Card{},
-			Score:           0,
-			RoundScores:     []int{},
-			ChopsticksCount: 0,
-			SelectedCard:    nil,
-		}
-		players = append(players, player)
+// GeneratePlayerName generates a random player name from the engine's random source
+func (e *Engine) GeneratePlayerName() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return GeneratePlayerName(e.rng)
+}
+
+// SetStore attaches a persistent store to the engine and reloads any games saved in it
+func (e *Engine) SetStore(store GameStore) error {
+	if store == nil {
+		return errors.New("store must not be nil")
 	}
 
-	// Create the game with engine configuration
-	game := &models.Game{
-		ID:           gameID,
-		Players:      players,
-		Deck:         []models.Card{},
-		CurrentRound: 0,
-		RoundPhase:   models.PhaseWaitingForPlayers,
-		CreatedAt:    time.Now(),
-		NumRounds:    e.numRounds,
-		CardsPerHand: e.cardsPerHand,
+	games, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to load games from store: %w", err)
+	}
+
+	e.mu.Lock()
+	e.store = store
+	for _, game := range games {
+		e.games[game.ID] = e.newGameEntry(game)
+	}
+	e.mu.Unlock()
+
+	return e.loadEvents()
+}
+
+// SetEventLog attaches a durable event log to the engine
+// History for games already loaded in the engine is read back from the log
+func (e *Engine) SetEventLog(log EventLog) error {
+	if log == nil {
+		return errors.New("event log must not be nil")
+	}
+
+	e.mu.Lock()
+	e.eventLog = log
+	e.mu.Unlock()
+
+	return e.loadEvents()
+}
+
+// loadEvents reads the history of every loaded game from the event log
+func (e *Engine) loadEvents() error {
+	if e.eventLog == nil {
+		return nil
 	}
 
-	e.games[gameID] = game
+	for _, entry := range e.entries() {
+		entry.mu.Lock()
+		events, err := e.eventLog.Events(entry.game.ID)
+		if err == nil {
+			entry.log = events
+		}
+		entry.mu.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to load events for game %s: %w", entry.game.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// persist saves the game to the engine's store
+// Callers must hold the game's lock
+func (e *Engine) persist(game *models.Game) error {
+	if err := e.store.Save(game); err != nil {
+		return fmt.Errorf("failed to persist game %s: %w", game.ID, err)
+	}
+	return nil
+}
+
+// commit records an event for a successful mutation and persists the resulting state
+// If the game can't be saved or the event logged, the mutation is rolled back so the game
+// in memory still matches the store; callers must not keep using the game they mutated
+// Callers must hold the game's lock
+func (e *Engine) commit(entry *gameEntry, eventType EventType, payload interface{}) error {
+	if err := e.record(entry, eventType, payload); err != nil {
+		entry.game = entry.saved.Clone()
+		return err
+	}
+	entry.saved = entry.game.Clone()
+	return nil
+}
+
+// record saves the mutated game, then appends its event to the log
+// A game whose event could not be logged is saved back as it was, so the store and log agree
+// Callers must hold the game's lock
+func (e *Engine) record(entry *gameEntry, eventType EventType, payload interface{}) error {
+	game := entry.game
+	event, err := NewEvent(game.ID, eventType, payload)
+	if err != nil {
+		return err
+	}
+	event.Seq = len(entry.log) + 1
+
+	// Start the pick timer from the event time so replays derive the same deadline
+	if startsTurn(eventType) {
+		setTurnDeadline(game, event.Timestamp)
+	}
+
+	if err := e.persist(game); err != nil {
+		return err
+	}
+	if e.eventLog != nil {
+		if err := e.eventLog.Append(event); err != nil {
+			if eventType == EventGameCreated {
+				e.store.Delete(game.ID)
+			} else {
+				e.persist(entry.saved)
+			}
+			return fmt.Errorf("failed to append event to log: %w", err)
+		}
+	}
+
+	entry.log = append(entry.log, event)
+	return nil
+}
+
+// Events returns the events recorded for a game, oldest first
+func (e *Engine) Events(gameID string) ([]Event, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+
+	events := make([]Event, len(entry.log))
+	copy(events, entry.log)
+	return events, nil
+}
+
+// CreateGame creates a new game session with unique ID generation
+func (e *Engine) CreateGame(playerIDs []string) (*models.Game, error) {
+	return e.CreateGameWithOptions(playerIDs, GameOptions{})
+}
+
+// CreateGameWithOptions creates a new game session, overriding engine defaults with opts
+// It returns a snapshot of the new game, like GetGame
+func (e *Engine) CreateGameWithOptions(playerIDs []string, opts GameOptions) (*models.Game, error) {
+	entry, payload, code, err := e.registerGame(playerIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+
+	// The game is recorded under its own lock, so lookups of other games don't wait on the store
+	if err := e.commit(entry, EventGameCreated, payload); err != nil {
+		e.mu.Lock()
+		delete(e.games, entry.game.ID)
+		e.mu.Unlock()
+		entry.deleted = true
+		return nil, err
+	}
+	// Only the hash of a private game's code is kept, so this is the one chance to hand it out
+	game := entry.game.Clone()
+	game.InviteCode = code
 	return game, nil
 }
 
-// JoinGame adds a player to an existing game
-func (e *Engine) JoinGame(gameID, playerID string) error {
+// registerGame builds a new game from the engine's configuration and opts and adds it to the index
+// The entry is returned locked, so nobody else can see the game until it is recorded,
+// along with the invite code of a private game
+func (e *Engine) registerGame(playerIDs []string, opts GameOptions) (*gameEntry, GameCreatedPayload, string, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	deck := e.deck
+	if opts.Deck != nil {
+		if opts.Menu != nil {
+			return nil, GameCreatedPayload{}, "", fmt.Errorf("%w: a Sushi Go Party! game is dealt from its menu", ErrInvalidDeck)
+		}
+		if err := ValidateDeckDefinition(opts.Deck); err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		if err := checkDeckLimits(opts.Deck, e.limits, e.cardsPerHand, e.numRounds); err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		deck = opts.Deck
+	}
+	if opts.Menu != nil {
+		deck = nil
 	}
 
-	// Check if game is full
-	if len(game.Players) >= 5 {
-		return ErrGameFull
+	maxPlayers := e.limits.maxFor(opts.Menu)
+	if opts.Menu == nil {
+		// Every round is dealt from one deck, so a small deck, a large hand or an extra round seats fewer players
+		dealt, tooSmall := deck, ErrInvalidDeck
+		if dealt == nil {
+			dealt, tooSmall = &DefaultDeck, ErrDeckExhausted
+		}
+		maxPlayers = deckMaxPlayers(dealt, e.limits, e.cardsPerHand, e.numRounds)
+		if maxPlayers < e.limits.Min {
+			return nil, GameCreatedPayload{}, "", fmt.Errorf("%w: %d cards can't deal %d rounds to %d players", tooSmall, deckSize(dealt), e.numRounds, e.limits.Min)
+		}
+	}
+	minPlayers := e.limits.Min
+	if opts.Dummy {
+		if opts.Menu != nil {
+			return nil, GameCreatedPayload{}, "", ErrDummyVariant
+		}
+		minPlayers, maxPlayers = 2, 2
+	}
+	if len(playerIDs) > maxPlayers {
+		return nil, GameCreatedPayload{}, "", ErrTooManyPlayers
 	}
 
-	// Check if player already in game
-	for _, p := range game.Players {
-		if p.ID == playerID {
-			return ErrPlayerAlreadyJoined
+	// Generate unique game ID
+	gameID := e.generateUniqueGameID()
+
+	// Create the game with engine configuration
+	payload := GameCreatedPayload{
+		PlayerIDs:    playerIDs,
+		NumRounds:    e.numRounds,
+		CardsPerHand: e.cardsPerHand,
+		Seed:         e.rng.Int63(),
+		TurnTimeout:  e.turnTimeout,
+		TieMode:      e.tieMode,
+		PassPolicy:   e.passPolicy,
+		ReadyCheck:   e.readyCheck,
+		Dummy:        opts.Dummy,
+		MinPlayers:   minPlayers,
+		MaxPlayers:   maxPlayers,
+		Deck:         deck,
+		CreatedAt:    time.Now(),
+	}
+	if opts.TurnTimeout != nil {
+		if *opts.TurnTimeout < 0 {
+			return nil, GameCreatedPayload{}, "", errors.New("turn timeout must not be negative")
+		}
+		payload.TurnTimeout = *opts.TurnTimeout
+	}
+	if opts.Menu != nil {
+		if err := ValidateMenu(opts.Menu); err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		payload.Menu = opts.Menu
+	}
+	if opts.TieMode != "" {
+		if err := ValidateTieMode(opts.TieMode); err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		payload.TieMode = opts.TieMode
+	}
+	if payload.TieMode == "" {
+		payload.TieMode = models.TieFull
+	}
+	if opts.PassPolicy != nil {
+		if err := ValidatePassPolicy(*opts.PassPolicy); err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		payload.PassPolicy = *opts.PassPolicy
+	}
+	if payload.PassPolicy.Direction == "" {
+		payload.PassPolicy.Direction = models.PassLeft
+	}
+	if opts.ReadyCheck != "" {
+		if err := ValidateReadyCheck(opts.ReadyCheck); err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		payload.ReadyCheck = opts.ReadyCheck
+	}
+	if payload.ReadyCheck == "" {
+		payload.ReadyCheck = models.ReadyCheckOff
+	}
+	code := ""
+	if opts.Private || opts.Password != "" {
+		payload.Private = true
+		code = opts.Password
+		if code == "" {
+			code = GenerateInviteCode(e.rng)
 		}
+		salt, hash, err := newInviteHash(code)
+		if err != nil {
+			return nil, GameCreatedPayload{}, "", err
+		}
+		payload.InviteSalt, payload.InviteHash = salt, hash
+	}
+	entry := e.newGameEntry(newGame(gameID, payload))
+	entry.mu.Lock()
+	e.games[gameID] = entry
+	return entry, payload, code, nil
+}
+
+// newGame builds a game in the waiting phase from its creation parameters
+func newGame(gameID string, payload GameCreatedPayload) *models.Game {
+	// Create players with unique IDs
+	players := make([]*models.Player, 0, len(payload.PlayerIDs))
+	for _, playerID := range payload.PlayerIDs {
+		players = append(players, newPlayer(playerID))
+	}
+
+	handSizeMode := models.HandSizeHouse
+	if payload.CardsPerHand == CardsPerHandByPlayerCount {
+		handSizeMode = models.HandSizeRules
+	}
+
+	// The creator hosts the game
+	hostID := ""
+	if len(players) > 0 {
+		hostID = players[0].ID
+	}
+
+	return &models.Game{
+		ID:             gameID,
+		Players:        players,
+		HostID:         hostID,
+		Deck:           []models.Card{},
+		CurrentRound:   0,
+		RoundPhase:     models.PhaseWaitingForPlayers,
+		CreatedAt:      payload.CreatedAt,
+		NumRounds:      payload.NumRounds,
+		CardsPerHand:   payload.CardsPerHand,
+		HandSizeMode:   handSizeMode,
+		TieMode:        payload.TieMode,
+		PassPolicy:     payload.PassPolicy,
+		ReadyCheck:     payload.ReadyCheck,
+		Private:        payload.Private,
+		InviteSalt:     payload.InviteSalt,
+		InviteHash:     payload.InviteHash,
+		Dummy:          payload.Dummy,
+		Seed:           payload.Seed,
+		TurnTimeout:    payload.TurnTimeout,
+		Menu:           payload.Menu,
+		MinPlayers:     payload.MinPlayers,
+		MaxPlayers:     payload.MaxPlayers,
+		DeckDefinition: payload.Deck,
 	}
+}
 
-	// Add player to game
-	player := &models.Player{
+// newPlayer creates a player with an empty hand and collection
+func newPlayer(playerID string) *models.Player {
+	return &models.Player{
 		ID:              playerID,
 		Name:            fmt.Sprintf("Player %s", playerID),
 		Hand:            []models.Card{},
@@ -147,9 +529,56 @@ func (e *Engine) JoinGame(gameID, playerID string) error {
 		PuddingCards:    []models.Card{},
 		Score:           0,
 		RoundScores:     []int{},
+		RoundBreakdowns: []models.ScoreBreakdown{},
 		ChopsticksCount: 0,
 		SelectedCard:    nil,
 	}
+}
+
+// JoinGame adds a player to an existing game
+func (e *Engine) JoinGame(gameID, playerID string) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+
+	if err := joinGame(game, playerID); err != nil {
+		return err
+	}
+	return e.commit(entry, EventPlayerJoined, PlayerPayload{PlayerID: playerID})
+}
+
+// joinGame adds a new player to the game
+// The first human to sit at a game without a host becomes its host
+func joinGame(game *models.Game, playerID string) error {
+	if err := checkPhase(game, ActionJoin); err != nil {
+		return err
+	}
+	if err := seatPlayer(game, newPlayer(playerID)); err != nil {
+		return err
+	}
+
+	if game.HostID == "" {
+		game.HostID = playerID
+	}
+	return nil
+}
+
+// seatPlayer appends a player if there is room and the ID is new
+func seatPlayer(game *models.Game, player *models.Player) error {
+	// Check if game is full
+	if _, maxPlayers := seatLimits(game); len(game.Players) >= maxPlayers {
+		return ErrGameFull
+	}
+
+	// Check if player already in game
+	for _, p := range game.Players {
+		if p.ID == player.ID {
+			return ErrPlayerAlreadyJoined
+		}
+	}
 
 	game.Players = append(game.Players, player)
 	return nil
@@ -157,23 +586,35 @@ func (e *Engine) JoinGame(gameID, playerID string) error {
 
 // RemovePlayer removes a player from a game (only allowed in waiting phase)
 func (e *Engine) RemovePlayer(gameID, playerID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	if err := removePlayer(game, playerID); err != nil {
+		return err
 	}
+	return e.commit(entry, EventPlayerRemoved, PlayerPayload{PlayerID: playerID})
+}
 
+// removePlayer removes a player from a game that has not started
+func removePlayer(game *models.Game, playerID string) error {
 	// Only allow removing players in waiting phase
-	if game.RoundPhase != models.PhaseWaitingForPlayers {
-		return errors.New("can only remove players before game starts")
+	if err := checkPhase(game, ActionRemovePlayer); err != nil {
+		return err
 	}
 
 	// Find and remove the player
 	for i, p := range game.Players {
 		if p.ID == playerID {
 			game.Players = append(game.Players[:i], game.Players[i+1:]...)
+
+			// Hand the game to the next human in seat order
+			if game.HostID == playerID {
+				game.HostID = nextHost(game)
+			}
 			return nil
 		}
 	}
@@ -183,74 +624,173 @@ func (e *Engine) RemovePlayer(gameID, playerID string) error {
 
 // StartGame starts a game if minimum player count is met
 func (e *Engine) StartGame(gameID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	if err := startGame(game); err != nil {
+		return err
+	}
+	return e.commit(entry, EventGameStarted, nil)
+}
+
+// startGame moves the game into its first round
+func startGame(game *models.Game) error {
+	if err := checkPhase(game, ActionStartGame); err != nil {
+		return err
 	}
 
 	// Check minimum player count
-	if len(game.Players) < 2 {
+	if minPlayers, _ := seatLimits(game); len(game.Players) < minPlayers {
 		return ErrNotEnoughPlayers
 	}
+	if err := checkReady(game); err != nil {
+		return err
+	}
+
+	// The dummy is dealt a hand like a third player
+	if game.Dummy {
+		if err := seatDummy(game); err != nil {
+			return err
+		}
+	}
+
+	cardsPerHand, err := checkTable(game)
+	if err != nil {
+		unseatDummy(game)
+		return err
+	}
+	game.CardsPerHand = cardsPerHand
 
 	// Initialize the game
 	game.CurrentRound = 1
-	game.RoundPhase = models.PhaseSelecting
+	return setPhase(game, models.PhaseSelecting)
+}
+
+// checkTable returns the hand size for the players who sat down, checking the deck and pass policy fit the table
+func checkTable(game *models.Game) (int, error) {
+	// In rules mode the hand size depends on how many players actually sat down
+	cardsPerHand := game.CardsPerHand
+	if game.HandSizeMode == models.HandSizeRules {
+		var err error
+		cardsPerHand, err = CardsPerPlayerFor(game, len(game.Players))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// A small menu may not stretch to a full table
+	if err := checkDeckSize(game, cardsPerHand); err != nil {
+		return 0, err
+	}
+	if err := checkPassPolicy(game); err != nil {
+		return 0, err
+	}
+	return cardsPerHand, nil
+}
 
+// SetPlayerName changes the display name of a player
+func (e *Engine) SetPlayerName(gameID, playerID, name string) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+
+	if err := setPlayerName(game, playerID, name); err != nil {
+		return err
+	}
+	return e.commit(entry, EventPlayerRenamed, PlayerPayload{PlayerID: playerID, Name: name})
+}
+
+// setPlayerName changes a player's display name
+func setPlayerName(game *models.Game, playerID, name string) error {
+	player := findPlayer(game, playerID)
+	if player == nil {
+		return errors.New("player not found in game")
+	}
+
+	player.Name = name
 	return nil
 }
 
-// GetGame retrieves a game by ID
-func (e *Engine) GetGame(gameID string) (*models.Game, error) {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+// findPlayer returns the player with the given ID, or nil if not in the game
+func findPlayer(game *models.Game, playerID string) *models.Player {
+	for _, p := range game.Players {
+		if p.ID == playerID {
+			return p
+		}
+	}
+	return nil
+}
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return nil, ErrGameNotFound
+// GetGame retrieves a snapshot of a game by ID
+// The snapshot shares no state with the engine, so callers may read and change it freely;
+// changes never reach the game
+func (e *Engine) GetGame(gameID string) (*models.Game, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
 	}
+	defer entry.mu.Unlock()
 
-	return game, nil
+	return entry.game.Clone(), nil
 }
 
-// ListGames returns a list of all active games
+// ListGames returns a list of all active public games
 func (e *Engine) ListGames() []map[string]interface{} {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
-
-	games := make([]map[string]interface{}, 0, len(e.games))
-	for _, game := range e.games {
-		games = append(games, map[string]interface{}{
-			"id":          game.ID,
-			"playerCount": len(game.Players),
-			"phase":       game.RoundPhase,
-			"round":       game.CurrentRound,
-		})
+	entries := e.entries()
+
+	games := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		entry.mu.Lock()
+		if !entry.deleted && !entry.game.Private {
+			game := entry.game
+			games = append(games, map[string]interface{}{
+				"id":          game.ID,
+				"playerCount": len(game.Players),
+				"maxPlayers":  game.MaxPlayers,
+				"phase":       game.RoundPhase,
+				"round":       game.CurrentRound,
+			})
+		}
+		entry.mu.Unlock()
 	}
 
 	return games
 }
 
-// DeleteGame removes a game from the engine
+// DeleteGame removes a game from the engine, along with its saved state and event log
+// A move already waiting on the game's lock finds it gone
 func (e *Engine) DeleteGame(gameID string) error {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	if _, exists := e.games[gameID]; !exists {
+	entry, exists := e.games[gameID]
+	delete(e.games, gameID)
+	e.mu.Unlock()
+	if !exists {
 		return ErrGameNotFound
 	}
 
-	delete(e.games, gameID)
-	return nil
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	entry.deleted = true
+	if e.eventLog != nil {
+		if err := e.eventLog.Delete(gameID); err != nil {
+			return fmt.Errorf("failed to delete event log: %w", err)
+		}
+	}
+	return e.store.Delete(gameID)
 }
 
 // generateUniqueGameID generates a unique game identifier
 func (e *Engine) generateUniqueGameID() string {
 	for {
-		id := GenerateGameID()
+		id := GenerateGameID(e.rng)
 		if _, exists := e.games[id]; !exists {
 			return id
 		}
@@ -274,48 +814,77 @@ func generateRandomID() string {
 
 // StartRound starts a new round by dealing cards to all players
 func (e *Engine) StartRound(gameID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	return e.dealRound(entry)
+}
+
+// dealRound deals the current round and opens card selection
+// Callers must hold the game's lock
+func (e *Engine) dealRound(entry *gameEntry) error {
+	game := entry.game
+	// Check before dealing, since the dealer fills the hands in place
+	if err := checkRoundStart(game); err != nil {
+		return err
 	}
 
 	// Use the dealer to deal cards
-	err := e.dealer.DealCards(game.Players, game.CurrentRound, game.CardsPerHand)
+	err := e.dealer.DealCards(game, game.CardsPerHand, roundRand(game))
 	if err != nil {
 		return err
 	}
 
-	game.RoundPhase = models.PhaseSelecting
+	// Record the dealt hands so replays don't depend on the dealer
+	hands := make([][]models.Card, len(game.Players))
+	for i, player := range game.Players {
+		hands[i] = player.Hand
+	}
+	if err := startRound(game, hands, game.Deck); err != nil {
+		return err
+	}
+	return e.commit(entry, EventRoundStarted, RoundStartedPayload{Round: game.CurrentRound, Hands: hands, Deck: game.Deck})
+}
+
+// roundRand returns the random source for dealing a round
+// It depends only on the game's seed and round, so a seed plus the move list replays a game exactly
+func roundRand(game *models.Game) *mathrand.Rand {
+	return mathrand.New(mathrand.NewSource(game.Seed + int64(game.CurrentRound)))
+}
+
+// startRound gives each player their dealt hand, keeps the undealt cards and opens card selection
+func startRound(game *models.Game, hands [][]models.Card, deck []models.Card) error {
+	for i, player := range game.Players {
+		if i < len(hands) {
+			player.Hand = hands[i]
+		}
+	}
+	game.Deck = deck
+	game.Turn = 0
 
 	// Clear selected cards
 	for _, player := range game.Players {
 		player.SelectedCard = nil
 	}
-
-	return nil
+	return setPhase(game, models.PhaseSelecting)
 }
 
 // PlayCard allows a player to select a card from their hand
 func (e *Engine) PlayCard(gameID, playerID string, cardIndex int, useChopsticks bool, secondCardIndex *int) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	return e.PlayCardWithOptions(gameID, playerID, cardIndex, PlayOptions{UseChopsticks: useChopsticks, SecondCardIndex: secondCardIndex})
+}
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+// playCard records a player's card selection
+func playCard(game *models.Game, playerID string, cardIndex int, opts PlayOptions) error {
+	if err := checkPhase(game, ActionPlayCard); err != nil {
+		return err
 	}
 
 	// Find the player
-	var player *models.Player
-	for _, p := range game.Players {
-		if p.ID == playerID {
-			player = p
-			break
-		}
-	}
+	player := findPlayer(game, playerID)
 	if player == nil {
 		return errors.New("player not found")
 	}
@@ -330,49 +899,67 @@ func (e *Engine) PlayCard(gameID, playerID string, cardIndex int, useChopsticks
 		return errors.New("player has already selected a card")
 	}
 
-	// If using chopsticks, validate and decrement count
-	if useChopsticks {
+	// If using chopsticks, validate the second card
+	if opts.UseChopsticks {
 		if player.ChopsticksCount <= 0 {
 			return errors.New("player does not have chopsticks available")
 		}
-		if secondCardIndex == nil {
+		if opts.SecondCardIndex == nil {
 			return errors.New("second card index required when using chopsticks")
 		}
-		if *secondCardIndex < 0 || *secondCardIndex >= len(player.Hand) {
+		if *opts.SecondCardIndex < 0 || *opts.SecondCardIndex >= len(player.Hand) {
 			return errors.New("invalid second card index")
 		}
-		if cardIndex == *secondCardIndex {
+		if cardIndex == *opts.SecondCardIndex {
 			return errors.New("cannot select the same card twice")
 		}
+	}
+	if err := checkSpecials(player, cardIndex, opts); err != nil {
+		return err
+	}
+
+	if opts.UseChopsticks {
 		// Decrement chopsticks count (will be restored when chopsticks go back to hand)
 		player.ChopsticksCount--
-		player.SecondCard = secondCardIndex
+		second := *opts.SecondCardIndex
+		player.SecondCard = &second
 	}
 
-	// Store the selected card index
+	// Store the selected card index and the choices its specials resolve with
 	player.SelectedCard = &cardIndex
+	if opts.Spoon != nil {
+		request := *opts.Spoon
+		player.SpoonRequest = &request
+	}
+	player.CopyCardID = opts.CopyCardID
+	player.FlipCardIDs = append([]string(nil), opts.FlipCardIDs...)
 
 	return nil
 }
 
 // WithdrawCard allows a player to withdraw their card selection
 func (e *Engine) WithdrawCard(gameID, playerID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	if err := withdrawCard(game, playerID); err != nil {
+		return err
 	}
+	return e.commit(entry, EventCardWithdrawn, PlayerPayload{PlayerID: playerID})
+}
 
-	// Find the player
-	var player *models.Player
-	for _, p := range game.Players {
-		if p.ID == playerID {
-			player = p
-			break
-		}
+// withdrawCard clears a player's card selection
+func withdrawCard(game *models.Game, playerID string) error {
+	if err := checkPhase(game, ActionWithdrawCard); err != nil {
+		return err
 	}
+
+	// Find the player
+	player := findPlayer(game, playerID)
 	if player == nil {
 		return errors.New("player not found")
 	}
@@ -384,82 +971,120 @@ func (e *Engine) WithdrawCard(gameID, playerID string) error {
 
 	// Clear the selected card
 	player.SelectedCard = nil
+	clearChoices(player)
 
 	return nil
 }
 
 // RevealCards reveals all selected cards and adds them to player collections
 func (e *Engine) RevealCards(gameID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	return e.reveal(entry)
+}
+
+// reveal reveals the turn, keeping the table as it was so a vote can take the reveal back
+// An open undo vote lapses with the next reveal
+// Callers must hold the game's lock
+func (e *Engine) reveal(entry *gameEntry) error {
+	before := entry.game.Clone()
+	if err := revealCards(entry.game); err != nil {
+		return err
+	}
+	if err := e.commit(entry, EventCardsRevealed, nil); err != nil {
+		return err
+	}
+	entry.undo, entry.vote = before, nil
+	return nil
+}
+
+// revealCards moves every selected card into its owner's collection
+func revealCards(game *models.Game) error {
+	if err := checkPhase(game, ActionReveal); err != nil {
+		return err
 	}
 
 	// Check if all players have selected cards
-	for _, player := range game.Players {
-		if player.SelectedCard == nil {
-			return errors.New("not all players have selected cards")
-		}
+	if !allSelected(game) {
+		return errors.New("not all players have selected cards")
 	}
 
+	game.Turn++
+
 	// Reveal and add cards to collections
 	for _, player := range game.Players {
 		// Process first card
 		if player.SelectedCard != nil {
-			cardIndex := *player.SelectedCard
-			if cardIndex >= 0 && cardIndex < len(player.Hand) {
-				selectedCard := player.Hand[cardIndex]
-
-				// Add to appropriate collection
-				if selectedCard.Type == models.CardTypePudding {
-					player.PuddingCards = append(player.PuddingCards, selectedCard)
-				} else {
-					player.Collection = append(player.Collection, selectedCard)
-				}
-
-				// Update Chopsticks count
-				if selectedCard.Type == models.CardTypeChopsticks {
-					player.ChopsticksCount++
-				}
-			}
+			placeCard(game, player, *player.SelectedCard)
 		}
 
 		// Process second card (if using chopsticks)
 		if player.SecondCard != nil {
-			cardIndex := *player.SecondCard
-			if cardIndex >= 0 && cardIndex < len(player.Hand) {
-				selectedCard := player.Hand[cardIndex]
-
-				// Add to appropriate collection
-				if selectedCard.Type == models.CardTypePudding {
-					player.PuddingCards = append(player.PuddingCards, selectedCard)
-				} else {
-					player.Collection = append(player.Collection, selectedCard)
-				}
-
-				// Update Chopsticks count
-				if selectedCard.Type == models.CardTypeChopsticks {
-					player.ChopsticksCount++
-				}
-			}
+			placeCard(game, player, *player.SecondCard)
 		}
 	}
 
-	game.RoundPhase = models.PhaseRevealing
+	// Spoons take cards from the hands still to be passed
+	resolveSpoons(game)
+	for _, player := range game.Players {
+		clearChoices(player)
+	}
+
+	game.TurnDeadline = nil // Every pick is in, so the timer stops; a Menu pick starts another
+	if err := setPhase(game, models.PhaseRevealing); err != nil {
+		return err
+	}
+	finishReveal(game)
 	return nil
 }
 
-// PassHands passes each player's hand to the player on their left
+// placeCard adds the card at cardIndex in the player's hand to their collection,
+// or to their desserts, stamped with the current turn
+// A Menu draws cards instead, a Special Order becomes the card it copies and a Takeout Box turns cards face down
+func placeCard(game *models.Game, player *models.Player, cardIndex int) {
+	if cardIndex < 0 || cardIndex >= len(player.Hand) {
+		return
+	}
+	selectedCard := player.Hand[cardIndex]
+
+	switch selectedCard.Type {
+	case models.CardTypeMenu:
+		drawMenu(game, player)
+		return
+	case models.CardTypeSpecialOrder:
+		selectedCard = copyCard(player, selectedCard)
+	case models.CardTypeTakeoutBox:
+		flipCards(player)
+	}
+	collectCard(game, player, selectedCard)
+}
+
+// PassHands passes each player's hand on, in the direction the game's pass policy gives for the round
 func (e *Engine) PassHands(gameID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	if err := passHands(game); err != nil {
+		return err
+	}
+	return e.commit(entry, EventHandsPassed, nil)
+}
+
+// passHands removes played cards from hands and rotates the remaining hands
+func passHands(game *models.Game) error {
+	if err := checkPhase(game, ActionPass); err != nil {
+		return err
+	}
+	if menuPending(game) {
+		return ErrMenuPickPending
 	}
 
 	numPlayers := len(game.Players)
@@ -523,8 +1148,7 @@ func (e *Engine) PassHands(gameID string) error {
 
 	if roundOver {
 		// All hands are empty, round is over
-		game.RoundPhase = models.PhaseScoring
-		return nil
+		return setPhase(game, models.PhaseScoring)
 	}
 
 	// Save current hands
@@ -533,46 +1157,53 @@ func (e *Engine) PassHands(gameID string) error {
 		savedHands[i] = player.Hand
 	}
 
-	// Pass hands to the left (player i gets hand from player i-1)
-	for i := 0; i < numPlayers; i++ {
-		prevIndex := (i - 1 + numPlayers) % numPlayers
-		game.Players[i].Hand = savedHands[prevIndex]
+	// Pass hands on (to the left, player i gets the hand of player i-1)
+	for from, to := range passTargets(game) {
+		game.Players[to].Hand = savedHands[from]
 	}
 
-	game.RoundPhase = models.PhaseSelecting
-	return nil
+	return setPhase(game, models.PhaseSelecting)
 }
 
 // ScoreRound scores the current round and prepares for the next round or game end
 func (e *Engine) ScoreRound(gameID string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return ErrGameNotFound
+	if err := scoreRound(game); err != nil {
+		return err
 	}
+	return e.commit(entry, EventRoundScored, nil)
+}
 
+// scoreRound adds each player's round score and clears the table for the next round
+func scoreRound(game *models.Game) error {
 	// Verify we're in the scoring phase
-	if game.RoundPhase != models.PhaseScoring {
-		return errors.New("game is not in scoring phase")
+	if err := checkPhase(game, ActionScore); err != nil {
+		return err
 	}
 
 	// Calculate scores for this round
 	for _, player := range game.Players {
-		roundScore := scorePlayerRound(player, game.Players)
-		player.Score += roundScore
-		player.RoundScores = append(player.RoundScores, roundScore)
+		breakdown := scoring.RegistryFor(game.TieMode).ScoreRound(player, game.Players)
+		player.Score += breakdown.Total
+		player.RoundScores = append(player.RoundScores, breakdown.Total)
+		player.RoundBreakdowns = append(player.RoundBreakdowns, breakdown)
 	}
 
-	// Mark round as ended
-	game.RoundPhase = models.PhaseRoundEnd
-
 	// Check if this was the final round
 	if game.CurrentRound >= game.NumRounds {
 		// Game is over, trigger final scoring
-		game.RoundPhase = models.PhaseGameEnd
-		return nil
+		return setPhase(game, models.PhaseGameEnd)
+	}
+
+	// Mark round as ended
+	if err := setPhase(game, models.PhaseRoundEnd); err != nil {
+		return err
 	}
 
 	// Prepare for next round
@@ -593,38 +1224,68 @@ func (e *Engine) ScoreRound(gameID string) error {
 
 // EndGame calculates final scores and determines the winner
 func (e *Engine) EndGame(gameID string) (*GameResult, error) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
 
-	game, exists := e.games[gameID]
-	if !exists {
-		return nil, ErrGameNotFound
+	result, err := endGame(game)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.commit(entry, EventGameEnded, nil); err != nil {
+		return nil, err
 	}
 
-	// Verify game is in end phase
-	if game.RoundPhase != models.PhaseGameEnd {
-		return nil, errors.New("game is not in end phase")
+	return result, nil
+}
+
+// endGame applies Pudding scores and ranks the players
+func endGame(game *models.Game) (*GameResult, error) {
+	// Verify game is in end phase, and only apply the final scores once
+	if err := checkPhase(game, ActionEndGame); err != nil {
+		return nil, err
+	}
+	if gameEnded(game) {
+		return nil, ErrGameOver
 	}
 
-	// Calculate Pudding scores
-	puddingScores := calculatePuddingScores(game.Players)
+	// Calculate dessert scores (Pudding unless the menu says otherwise)
+	puddingScores := dessertScores(game)
 
-	// Add Pudding scores to player final scores
+	// Add dessert scores to player final scores
 	for _, player := range game.Players {
-		if puddingScore, ok := puddingScores[player.ID]; ok {
-			player.Score += puddingScore
-		}
+		puddingScore := puddingScores[player.ID]
+		player.Score += puddingScore.Points
+		player.DessertScore = &puddingScore
 	}
 
+	return rankPlayers(game), nil
+}
+
+// rankPlayers builds the final rankings from the players' current scores
+func rankPlayers(game *models.Game) *GameResult {
 	// Create rankings based on final scores
-	rankings := make([]PlayerRanking, len(game.Players))
-	for i, player := range game.Players {
-		rankings[i] = PlayerRanking{
-			PlayerID:     player.ID,
-			PlayerName:   player.Name,
-			FinalScore:   player.Score,
-			PuddingCount: len(player.PuddingCards),
+	// The dummy competed for Maki and Pudding but can't win, so it is reported apart
+	rankings := make([]PlayerRanking, 0, len(game.Players))
+	var dummy *PlayerRanking
+	for _, player := range game.Players {
+		ranking := PlayerRanking{
+			PlayerID:        player.ID,
+			PlayerName:      player.Name,
+			FinalScore:      player.Score,
+			PuddingCount:    len(player.PuddingCards),
+			Breakdown:       gameBreakdown(player),
+			RoundBreakdowns: player.RoundBreakdowns,
+		}
+		if player.IsDummy {
+			dummy = &ranking
+			continue
 		}
+		rankings = append(rankings, ranking)
 	}
 
 	// Sort rankings by score (descending), then by pudding count (descending) for tiebreaker
@@ -641,74 +1302,11 @@ func (e *Engine) EndGame(gameID string) (*GameResult, error) {
 		winnerID = rankings[0].PlayerID
 	}
 
-	result := &GameResult{
+	return &GameResult{
 		Winner:   winnerID,
 		Rankings: rankings,
+		Dummy:    dummy,
 	}
-
-	return result, nil
-}
-
-// calculatePuddingScores calculates Pudding scores for all players
-// Returns a map of player ID to Pudding score (can be positive or negative)
-func calculatePuddingScores(players []*models.Player) map[string]int {
-	scores := make(map[string]int)
-
-	// Special case: 2-player games have no penalty for fewest Pudding
-	if len(players) == 2 {
-		// Find player with most Pudding
-		maxPudding := -1
-		for _, player := range players {
-			puddingCount := len(player.PuddingCards)
-			if puddingCount > maxPudding {
-				maxPudding = puddingCount
-			}
-		}
-
-		// Award 6 points to player(s) with most Pudding
-		for _, player := range players {
-			if len(player.PuddingCards) == maxPudding && maxPudding > 0 {
-				scores[player.ID] = 6
-			}
-		}
-		return scores
-	}
-
-	// For 3+ players: find most and fewest Pudding counts
-	maxPudding := -1
-	minPudding := 1000000 // Large number
-
-	for _, player := range players {
-		puddingCount := len(player.PuddingCards)
-		if puddingCount > maxPudding {
-			maxPudding = puddingCount
-		}
-		if puddingCount < minPudding {
-			minPudding = puddingCount
-		}
-	}
-
-	// Award 6 points to all players with most Pudding
-	for _, player := range players {
-		puddingCount := len(player.PuddingCards)
-		if puddingCount == maxPudding {
-			scores[player.ID] = 6
-		}
-	}
-
-	// Deduct 6 points from all players with fewest Pudding
-	for _, player := range players {
-		puddingCount := len(player.PuddingCards)
-		if puddingCount == minPudding {
-			if existingScore, ok := scores[player.ID]; ok {
-				scores[player.ID] = existingScore - 6
-			} else {
-				scores[player.ID] = -6
-			}
-		}
-	}
-
-	return scores
 }
 
 // sortRankings sorts player rankings by score (descending), then by pudding count (descending)
@@ -730,7 +1328,14 @@ func sortRankings(rankings []PlayerRanking) {
 	}
 }
 
-// scorePlayerRound calculates the total score for a player's collection in a round
-func scorePlayerRound(player *models.Player, allPlayers []*models.Player) int {
-	return scoring.ScorePlayerRound(player, allPlayers)
+// gameBreakdown sums a player's round breakdowns and dessert score
+func gameBreakdown(player *models.Player) models.ScoreBreakdown {
+	breakdowns := append([]models.ScoreBreakdown{}, player.RoundBreakdowns...)
+	if player.DessertScore != nil {
+		breakdowns = append(breakdowns, models.ScoreBreakdown{
+			Total:      player.DessertScore.Points,
+			Categories: []models.CategoryScore{*player.DessertScore},
+		})
+	}
+	return scoring.SumBreakdowns(breakdowns...)
 }
@@ -0,0 +1,413 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+var (
+	ErrMenuPickPending = errors.New("a Menu pick is still pending")
+	ErrNoMenuDraw      = errors.New("player has no Menu cards to pick from")
+)
+
+// menuDrawSize is how many cards a revealed Menu draws from the deck
+const menuDrawSize = 4
+
+// PlayOptions are the choices a player makes along with a pick
+// Spoon, Special Order and Takeout Box effects are declared with the pick and resolved when it is revealed
+type PlayOptions struct {
+	UseChopsticks   bool
+	SecondCardIndex *int
+	Spoon           *models.CardRequest // Card asked for with a Spoon from an earlier turn
+	CopyCardID      string              // Collected card a Special Order picked this turn copies
+	FlipCardIDs     []string            // Collected cards a Takeout Box picked this turn turns face down
+}
+
+// PlayCardWithOptions selects a card from the player's hand along with the choices its specials need
+func (e *Engine) PlayCardWithOptions(gameID, playerID string, cardIndex int, opts PlayOptions) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+	game := entry.game
+
+	if err := playCard(game, playerID, cardIndex, opts); err != nil {
+		return err
+	}
+	return e.commit(entry, EventCardPlayed, CardPlayedPayload{
+		PlayerID:        playerID,
+		CardIndex:       cardIndex,
+		UseChopsticks:   opts.UseChopsticks,
+		SecondCardIndex: opts.SecondCardIndex,
+		Spoon:           opts.Spoon,
+		CopyCardID:      opts.CopyCardID,
+		FlipCardIDs:     opts.FlipCardIDs,
+	})
+}
+
+// checkSpecials verifies the special choices of a pick against the cards picked and the player's collection
+// The chopsticks choice must already be checked
+func checkSpecials(player *models.Player, cardIndex int, opts PlayOptions) error {
+	picked := []models.Card{player.Hand[cardIndex]}
+	if opts.UseChopsticks {
+		picked = append(picked, player.Hand[*opts.SecondCardIndex])
+	}
+	if countCards(picked, models.CardTypeMenu) > 1 {
+		return errors.New("only one Menu can be played a turn")
+	}
+
+	if opts.Spoon != nil {
+		if !requestable(*opts.Spoon) {
+			return fmt.Errorf("a Spoon can't ask for %q cards", opts.Spoon.Type)
+		}
+		if countCards(player.Collection, models.CardTypeSpoon) == 0 {
+			return errors.New("player does not have a Spoon to use")
+		}
+	}
+
+	if opts.CopyCardID != "" {
+		if countCards(picked, models.CardTypeSpecialOrder) == 0 {
+			return errors.New("only a Special Order copies a card")
+		}
+		target := collectedCard(player, opts.CopyCardID)
+		if target == nil {
+			return errors.New("card to copy is not in the player's collection")
+		}
+		if target.Type == models.CardTypeFaceDown {
+			return errors.New("a face-down card can't be copied")
+		}
+		for _, id := range opts.FlipCardIDs {
+			if id == opts.CopyCardID {
+				return errors.New("a card can't be copied and turned face down in the same turn")
+			}
+		}
+	}
+
+	if len(opts.FlipCardIDs) == 0 {
+		return nil
+	}
+	if countCards(picked, models.CardTypeTakeoutBox) == 0 {
+		return errors.New("only a Takeout Box turns cards face down")
+	}
+	flipped := make(map[string]bool)
+	flippedTypes := make(map[models.CardType]int)
+	for _, id := range opts.FlipCardIDs {
+		card := findCard(player.Collection, id)
+		if card == nil {
+			return errors.New("card to turn face down is not in the player's collection")
+		}
+		if card.Type == models.CardTypeFaceDown {
+			return errors.New("card is already face down")
+		}
+		if flipped[id] {
+			return errors.New("cannot turn the same card face down twice")
+		}
+		flipped[id] = true
+		flippedTypes[card.Type]++
+	}
+	if opts.UseChopsticks && countCards(player.Collection, models.CardTypeChopsticks) <= flippedTypes[models.CardTypeChopsticks] {
+		return errors.New("the Chopsticks used this turn can't be turned face down")
+	}
+	if opts.Spoon != nil && countCards(player.Collection, models.CardTypeSpoon) <= flippedTypes[models.CardTypeSpoon] {
+		return errors.New("the Spoon used this turn can't be turned face down")
+	}
+	return nil
+}
+
+// requestable reports whether a Spoon may ask for a card
+func requestable(request models.CardRequest) bool {
+	if request.Type == models.CardTypeNigiri {
+		return true
+	}
+	return containsCardType(MenuRolls, request.Type) ||
+		containsCardType(MenuAppetizers, request.Type) ||
+		containsCardType(MenuSpecials, request.Type) ||
+		containsCardType(MenuDesserts, request.Type)
+}
+
+// countCards returns how many of the cards are of a type
+func countCards(cards []models.Card, cardType models.CardType) int {
+	count := 0
+	for _, card := range cards {
+		if card.Type == cardType {
+			count++
+		}
+	}
+	return count
+}
+
+// findCard returns the card with an ID, or nil
+func findCard(cards []models.Card, id string) *models.Card {
+	for i := range cards {
+		if cards[i].ID == id {
+			return &cards[i]
+		}
+	}
+	return nil
+}
+
+// collectedCard returns a card in the player's collection or desserts, or nil
+func collectedCard(player *models.Player, id string) *models.Card {
+	if card := findCard(player.Collection, id); card != nil {
+		return card
+	}
+	return findCard(player.PuddingCards, id)
+}
+
+// clearChoices forgets the special choices of a player's pick
+func clearChoices(player *models.Player) {
+	player.SpoonRequest = nil
+	player.CopyCardID = ""
+	player.FlipCardIDs = nil
+}
+
+// collectCard adds a card to the player's collection, or to their desserts, stamped with the current turn
+func collectCard(game *models.Game, player *models.Player, card models.Card) {
+	card.Turn = game.Turn
+	if isDessert(card.Type) {
+		player.PuddingCards = append(player.PuddingCards, card)
+	} else {
+		player.Collection = append(player.Collection, card)
+	}
+
+	// Update Chopsticks count
+	if card.Type == models.CardTypeChopsticks {
+		player.ChopsticksCount++
+	}
+}
+
+// copyCard turns a revealed Special Order into a copy of the card the player chose, keeping its own ID
+// Without a choice it stays a Special Order, worth nothing
+func copyCard(player *models.Player, order models.Card) models.Card {
+	target := collectedCard(player, player.CopyCardID)
+	if target == nil {
+		return order
+	}
+	copied := *target
+	copied.ID = order.ID
+	return copied
+}
+
+// flipCards turns the cards the player chose for a revealed Takeout Box face down
+// Face-down cards keep their ID and turn; a Chopsticks turned over can no longer be used
+func flipCards(player *models.Player) {
+	for _, id := range player.FlipCardIDs {
+		card := findCard(player.Collection, id)
+		if card == nil || card.Type == models.CardTypeFaceDown {
+			continue
+		}
+		if card.Type == models.CardTypeChopsticks {
+			player.ChopsticksCount--
+		}
+		*card = models.Card{ID: card.ID, Type: models.CardTypeFaceDown, Turn: card.Turn}
+	}
+}
+
+// drawMenu draws the top cards of the deck for a revealed Menu, which is discarded
+// The player takes one of them with PickMenuCard; the dummy has nobody to choose for it, so it takes the first
+func drawMenu(game *models.Game, player *models.Player) {
+	n := menuDrawSize
+	if n > len(game.Deck) {
+		n = len(game.Deck)
+	}
+	player.MenuDraw = append([]models.Card(nil), game.Deck[:n]...)
+	game.Deck = game.Deck[n:]
+
+	if player.IsDummy && len(player.MenuDraw) > 0 {
+		takeMenuCard(game, player, 0)
+	}
+}
+
+// takeMenuCard collects one of the player's Menu cards and shuffles the rest back into the deck
+// A Menu taken this way is discarded, and a Special Order or Takeout Box has no effect
+func takeMenuCard(game *models.Game, player *models.Player, cardIndex int) {
+	drawn := player.MenuDraw
+	player.MenuDraw = nil
+
+	card := drawn[cardIndex]
+	if card.Type != models.CardTypeMenu {
+		collectCard(game, player, card)
+	}
+
+	rest := append(append([]models.Card{}, game.Deck...), drawn[:cardIndex]...)
+	rest = append(rest, drawn[cardIndex+1:]...)
+	game.Deck = ShuffleDeck(rest, menuRand(game))
+}
+
+// menuRand returns the random source for shuffling Menu cards back into the deck
+// Like roundRand it depends only on the game's seed and position, so replays shuffle the same way
+func menuRand(game *models.Game) *rand.Rand {
+	return rand.New(rand.NewSource(game.Seed + int64(game.CurrentRound)*1000 + int64(game.Turn)))
+}
+
+// menuPending reports whether a player still has to take one of their Menu cards
+func menuPending(game *models.Game) bool {
+	for _, player := range game.Players {
+		if len(player.MenuDraw) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// finishReveal settles the turn once every card revealed, or taken from a Menu, is in place
+func finishReveal(game *models.Game) {
+	if menuPending(game) {
+		return
+	}
+
+	// Miso Soups played on the same turn are all discarded
+	discardClashingMisoSoup(game)
+}
+
+// resolveSpoons settles the Spoons used this turn, in seat order
+// Starting from the next seat, the first player holding the requested card gives it up and takes the Spoon
+// into their hand; when nobody has it the Spoon is discarded
+func resolveSpoons(game *models.Game) {
+	for i, player := range game.Players {
+		if player.SpoonRequest == nil {
+			continue
+		}
+		spoon := -1
+		for j, card := range player.Collection {
+			if card.Type == models.CardTypeSpoon && card.Turn < game.Turn {
+				spoon = j
+				break
+			}
+		}
+		if spoon < 0 {
+			continue
+		}
+		used := player.Collection[spoon]
+		used.Turn = 0
+		player.Collection = append(player.Collection[:spoon], player.Collection[spoon+1:]...)
+
+		for step := 1; step < len(game.Players); step++ {
+			giver := game.Players[(i+step)%len(game.Players)]
+			if card, ok := giveCard(giver, *player.SpoonRequest); ok {
+				collectCard(game, player, card)
+				giver.Hand = append(giver.Hand, used)
+				break
+			}
+		}
+	}
+}
+
+// giveCard removes the first card in the player's hand matching the request, other than the cards they picked this turn
+func giveCard(player *models.Player, request models.CardRequest) (models.Card, bool) {
+	for i, card := range player.Hand {
+		if isPick(player.SelectedCard, i) || isPick(player.SecondCard, i) {
+			continue
+		}
+		if card.Type != request.Type || (request.Variant != "" && card.Variant != request.Variant) {
+			continue
+		}
+
+		player.Hand = append(player.Hand[:i], player.Hand[i+1:]...)
+		player.SelectedCard = shiftIndex(player.SelectedCard, i)
+		player.SecondCard = shiftIndex(player.SecondCard, i)
+		return card, true
+	}
+	return models.Card{}, false
+}
+
+// isPick reports whether a pick is the card at index
+func isPick(pick *int, index int) bool {
+	return pick != nil && *pick == index
+}
+
+// shiftIndex moves a pick down when a card before it leaves the hand
+func shiftIndex(pick *int, removed int) *int {
+	if pick == nil || *pick < removed {
+		return pick
+	}
+	shifted := *pick - 1
+	return &shifted
+}
+
+// PickMenuCard takes one of the cards a player's revealed Menu drew
+// The hands are passed once every Menu pick of the turn is in
+func (e *Engine) PickMenuCard(gameID, playerID string, cardIndex int) error {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return err
+	}
+	defer entry.mu.Unlock()
+
+	if err := pickMenuCard(entry.game, playerID, cardIndex); err != nil {
+		return err
+	}
+	return e.commit(entry, EventMenuCardPicked, MenuCardPickedPayload{PlayerID: playerID, CardIndex: cardIndex})
+}
+
+// pickMenuCard takes one of the player's Menu cards, settling the turn when it was the last pick
+func pickMenuCard(game *models.Game, playerID string, cardIndex int) error {
+	if err := checkPhase(game, ActionPickMenuCard); err != nil {
+		return err
+	}
+
+	player := findPlayer(game, playerID)
+	if player == nil {
+		return errors.New("player not found")
+	}
+	if len(player.MenuDraw) == 0 {
+		return ErrNoMenuDraw
+	}
+	if cardIndex < 0 || cardIndex >= len(player.MenuDraw) {
+		return errors.New("invalid card index")
+	}
+
+	takeMenuCard(game, player, cardIndex)
+	if !menuPending(game) {
+		finishReveal(game)
+		game.TurnDeadline = nil // Every pick is in, so the timer stops
+	}
+	return nil
+}
+
+// pickMenuFor takes the Menu card the policy chooses on the player's behalf
+// The policy chooses from the drawn cards as if they were the player's hand; an out-of-range choice takes the first
+// Callers must hold the game's lock
+func (e *Engine) pickMenuFor(entry *gameEntry, player *models.Player, policy AutoPlayPolicy, autoPlayed bool) error {
+	game := entry.game
+	choosing := *player
+	choosing.Hand = player.MenuDraw
+	cardIndex := policy.ChooseCard(game, &choosing, entry.rng)
+	if cardIndex < 0 || cardIndex >= len(player.MenuDraw) {
+		cardIndex = 0
+	}
+
+	if err := pickMenuCard(game, player.ID, cardIndex); err != nil {
+		return err
+	}
+	return e.commit(entry, EventMenuCardPicked, MenuCardPickedPayload{
+		PlayerID:   player.ID,
+		CardIndex:  cardIndex,
+		AutoPlayed: autoPlayed,
+	})
+}
+
+// pickBotMenus takes the Menu cards of the bots that drew some
+// Callers must hold the game's lock
+func (e *Engine) pickBotMenus(entry *gameEntry) ([]string, error) {
+	picked := []string{}
+	for _, player := range entry.game.Players {
+		if !player.IsBot || len(player.MenuDraw) == 0 {
+			continue
+		}
+
+		bot, err := botFor(player)
+		if err != nil {
+			return picked, err
+		}
+		if err := e.pickMenuFor(entry, player, bot, false); err != nil {
+			return picked, err
+		}
+		picked = append(picked, player.ID)
+	}
+	return picked, nil
+}
@@ -0,0 +1,317 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// stackedDealer deals the same hands every round and leaves a known deck
+type stackedDealer struct {
+	hands [][]models.Card
+	deck  []models.Card
+}
+
+func (d *stackedDealer) DealCards(game *models.Game, cardsPerHand int, r *rand.Rand) error {
+	for i, player := range game.Players {
+		player.Hand = append([]models.Card(nil), d.hands[i]...)
+	}
+	game.Deck = append([]models.Card(nil), d.deck...)
+	return nil
+}
+
+// card returns a card of a type with an ID
+func card(id string, cardType models.CardType) models.Card {
+	return models.Card{ID: id, Type: cardType}
+}
+
+// salmon returns a Salmon Nigiri with an ID
+func salmon(id string) models.Card {
+	return models.Card{ID: id, Type: models.CardTypeNigiri, Variant: "Salmon", Value: 2}
+}
+
+// TestSpoonTakesRequestedCard tests that a Spoon takes the card from the first player after its owner who
+// holds one they didn't pick, and that the Spoon goes into that player's hand
+func TestSpoonTakesRequestedCard(t *testing.T) {
+	picked := 2
+	game := &models.Game{
+		RoundPhase: models.PhaseSelecting,
+		Turn:       1,
+		Players: []*models.Player{
+			{ID: "p1", Hand: []models.Card{card("tempura", models.CardTypeTempura), card("eel", models.CardTypeEel)},
+				Collection: []models.Card{{ID: "spoon", Type: models.CardTypeSpoon, Turn: 1}}},
+			{ID: "p2", Hand: []models.Card{salmon("salmon1"), card("squid", models.CardTypeNigiri), salmon("salmon2")}, SelectedCard: &picked},
+			{ID: "p3", Hand: []models.Card{salmon("salmon3"), card("tofu", models.CardTypeTofu)}, SelectedCard: new(int)},
+		},
+	}
+
+	request := &models.CardRequest{Type: models.CardTypeNigiri, Variant: "Salmon"}
+	if err := playCard(game, "p1", 0, PlayOptions{Spoon: &models.CardRequest{Type: "sake"}}); err == nil {
+		t.Error("Expected an unknown card type to be refused")
+	}
+	if err := playCard(game, "p3", 1, PlayOptions{Spoon: request}); err == nil {
+		t.Error("Expected a Spoon request without a Spoon to be refused")
+	}
+	if err := playCard(game, "p1", 0, PlayOptions{Spoon: request}); err != nil {
+		t.Fatalf("Failed to play with a Spoon: %v", err)
+	}
+	if err := revealCards(game); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+
+	p1, p2 := game.Players[0], game.Players[1]
+	if len(p1.Collection) != 2 || p1.Collection[1].ID != "salmon1" || p1.Collection[1].Turn != 2 {
+		t.Errorf("Expected p1 to trade the Spoon for p2's first Salmon, got %v", p1.Collection)
+	}
+	if len(p2.Hand) != 3 || p2.Hand[2].ID != "spoon" || *p2.SelectedCard != 1 || p2.Hand[1].ID != "salmon2" {
+		t.Errorf("Expected p2 to keep its pick and take the Spoon, got hand %v picking %d", p2.Hand, *p2.SelectedCard)
+	}
+	if err := passHands(game); err != nil {
+		t.Fatalf("Failed to pass: %v", err)
+	}
+	if len(p2.Collection) != 1 || p2.Collection[0].ID != "salmon2" {
+		t.Errorf("Expected p2 to collect the Salmon it picked, got %v", p2.Collection)
+	}
+
+	// Nobody holds Wasabi, so the next Spoon is simply discarded
+	game.Players[2].Collection = []models.Card{{ID: "spoon2", Type: models.CardTypeSpoon, Turn: 1}}
+	playCard(game, "p1", 0, PlayOptions{})
+	playCard(game, "p2", 0, PlayOptions{})
+	if err := playCard(game, "p3", 0, PlayOptions{Spoon: &models.CardRequest{Type: models.CardTypeWasabi}}); err != nil {
+		t.Fatalf("Failed to play with a Spoon: %v", err)
+	}
+	hands := 0
+	for _, player := range game.Players {
+		hands += len(player.Hand)
+	}
+	revealCards(game)
+	if countCards(game.Players[2].Collection, models.CardTypeSpoon) != 0 {
+		t.Errorf("Expected the unanswered Spoon to be discarded, got %v", game.Players[2].Collection)
+	}
+	after := 0
+	for _, player := range game.Players {
+		after += len(player.Hand)
+	}
+	if after != hands {
+		t.Errorf("Expected no card to change hands, got %d cards in hand for %d", after, hands)
+	}
+}
+
+// TestSpecialOrderCopies tests that a Special Order becomes a copy of a collected card, desserts included
+func TestSpecialOrderCopies(t *testing.T) {
+	game := &models.Game{
+		RoundPhase: models.PhaseSelecting,
+		Turn:       1,
+		Players: []*models.Player{
+			{ID: "p1", Hand: []models.Card{card("order", models.CardTypeSpecialOrder), card("tofu", models.CardTypeTofu)},
+				Collection:   []models.Card{{ID: "squid", Type: models.CardTypeNigiri, Variant: "Squid", Value: 3, Turn: 1}},
+				PuddingCards: []models.Card{{ID: "pudding", Type: models.CardTypePudding, Turn: 1}}},
+			{ID: "p2", Hand: []models.Card{card("order2", models.CardTypeSpecialOrder), card("eel", models.CardTypeEel)},
+				PuddingCards: []models.Card{{ID: "pudding2", Type: models.CardTypePudding, Turn: 1}}},
+		},
+	}
+
+	if err := playCard(game, "p1", 1, PlayOptions{CopyCardID: "squid"}); err == nil {
+		t.Error("Expected a copy without a Special Order to be refused")
+	}
+	if err := playCard(game, "p1", 0, PlayOptions{CopyCardID: "pudding2"}); err == nil {
+		t.Error("Expected copying another player's card to be refused")
+	}
+	if err := playCard(game, "p1", 0, PlayOptions{CopyCardID: "squid"}); err != nil {
+		t.Fatalf("Failed to play a Special Order: %v", err)
+	}
+	if err := playCard(game, "p2", 0, PlayOptions{CopyCardID: "pudding2"}); err != nil {
+		t.Fatalf("Failed to play a Special Order: %v", err)
+	}
+	if err := revealCards(game); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+
+	copied := game.Players[0].Collection[1]
+	if copied.ID != "order" || copied.Type != models.CardTypeNigiri || copied.Variant != "Squid" || copied.Value != 3 || copied.Turn != 2 {
+		t.Errorf("Expected the Special Order to become a turn 2 Squid Nigiri, got %+v", copied)
+	}
+	if desserts := game.Players[1].PuddingCards; len(desserts) != 2 || desserts[1].Type != models.CardTypePudding {
+		t.Errorf("Expected p2's copy to join its desserts, got %v", desserts)
+	}
+	if game.Players[0].CopyCardID != "" {
+		t.Error("Expected the choice to be cleared once revealed")
+	}
+}
+
+// TestTakeoutBoxFlips tests that a Takeout Box turns the chosen cards face down, Chopsticks included
+func TestTakeoutBoxFlips(t *testing.T) {
+	second := 1
+	game := &models.Game{
+		RoundPhase: models.PhaseSelecting,
+		Turn:       2,
+		Players: []*models.Player{
+			{ID: "p1", Hand: []models.Card{card("box", models.CardTypeTakeoutBox), card("tofu", models.CardTypeTofu)},
+				Collection: []models.Card{
+					{ID: "chopsticks", Type: models.CardTypeChopsticks, Turn: 1},
+					{ID: "tempura", Type: models.CardTypeTempura, Turn: 2},
+					{ID: "eel", Type: models.CardTypeEel, Turn: 1},
+				},
+				ChopsticksCount: 1},
+			{ID: "p2", Hand: []models.Card{card("eel2", models.CardTypeEel)}},
+		},
+	}
+
+	invalid := map[string]PlayOptions{
+		"no box":            {FlipCardIDs: []string{"eel"}},
+		"not collected":     {FlipCardIDs: []string{"eel2"}},
+		"twice":             {FlipCardIDs: []string{"eel", "eel"}},
+		"chopsticks in use": {UseChopsticks: true, SecondCardIndex: &second, FlipCardIDs: []string{"chopsticks"}},
+	}
+	for name, opts := range invalid {
+		index := 0
+		if name == "no box" {
+			index = 1
+		}
+		if err := playCard(game, "p1", index, opts); err == nil {
+			t.Errorf("%s: expected the flip to be refused", name)
+			game.Players[0].SelectedCard = nil
+		}
+	}
+
+	if err := playCard(game, "p1", 0, PlayOptions{FlipCardIDs: []string{"chopsticks", "eel"}}); err != nil {
+		t.Fatalf("Failed to play a Takeout Box: %v", err)
+	}
+	playCard(game, "p2", 0, PlayOptions{})
+	if err := revealCards(game); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+
+	p1 := game.Players[0]
+	want := []models.Card{
+		{ID: "chopsticks", Type: models.CardTypeFaceDown, Turn: 1},
+		{ID: "tempura", Type: models.CardTypeTempura, Turn: 2},
+		{ID: "eel", Type: models.CardTypeFaceDown, Turn: 1},
+		{ID: "box", Type: models.CardTypeTakeoutBox, Turn: 3},
+	}
+	if len(p1.Collection) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, p1.Collection)
+	}
+	for i := range want {
+		if p1.Collection[i] != want[i] {
+			t.Errorf("Card %d: expected %+v, got %+v", i, want[i], p1.Collection[i])
+		}
+	}
+	if p1.ChopsticksCount != 0 {
+		t.Errorf("Expected the face-down Chopsticks to be unusable, got %d", p1.ChopsticksCount)
+	}
+}
+
+// TestMenuDrawAndPick tests that a revealed Menu draws four cards, holds the hands until one is taken,
+// shuffles the rest back, and that the game replays through it
+func TestMenuDrawAndPick(t *testing.T) {
+	deck := []models.Card{salmon("d1"), card("d2", models.CardTypeTempura), card("d3", models.CardTypeMenu), card("d4", models.CardTypeEel), card("d5", models.CardTypeTofu)}
+	engine := NewEngineWithDealer(&stackedDealer{
+		hands: [][]models.Card{
+			{card("menu", models.CardTypeMenu), card("tofu", models.CardTypeTofu)},
+			{card("eel", models.CardTypeEel), card("miso", models.CardTypeMisoSoup)},
+		},
+		deck: deck,
+	})
+	engine.SetTurnTimeout(time.Minute)
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	engine.StartGame(created.ID)
+	engine.StartRound(created.ID)
+	game := liveGame(t, engine, created.ID)
+
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	engine.PlayCard(game.ID, "p2", 0, false, nil)
+	if err := engine.RevealCards(game.ID); err != nil {
+		t.Fatalf("Failed to reveal: %v", err)
+	}
+
+	p1 := game.Players[0]
+	if len(p1.MenuDraw) != 4 || p1.MenuDraw[3].ID != "d4" || len(game.Deck) != 1 {
+		t.Fatalf("Expected the Menu to draw the top four cards, got %v leaving %d", p1.MenuDraw, len(game.Deck))
+	}
+	if len(p1.Collection) != 0 {
+		t.Errorf("Expected the Menu to be discarded, got %v", p1.Collection)
+	}
+	if game.TurnDeadline == nil {
+		t.Error("Expected the Menu pick to be timed")
+	}
+	if err := engine.PassHands(game.ID); !errors.Is(err, ErrMenuPickPending) {
+		t.Errorf("Expected ErrMenuPickPending, got %v", err)
+	}
+	if err := engine.PickMenuCard(game.ID, "p2", 0); !errors.Is(err, ErrNoMenuDraw) {
+		t.Errorf("Expected ErrNoMenuDraw, got %v", err)
+	}
+	if err := engine.PickMenuCard(game.ID, "p1", 4); err == nil {
+		t.Error("Expected an out-of-range pick to be refused")
+	}
+
+	if err := engine.PickMenuCard(game.ID, "p1", 1); err != nil {
+		t.Fatalf("Failed to pick a Menu card: %v", err)
+	}
+	if len(p1.Collection) != 1 || p1.Collection[0].ID != "d2" || p1.Collection[0].Turn != 1 {
+		t.Errorf("Expected p1 to collect the Tempura on turn 1, got %v", p1.Collection)
+	}
+	if len(p1.MenuDraw) != 0 || len(game.Deck) != 4 || game.TurnDeadline != nil {
+		t.Errorf("Expected the other three cards back in the deck and the timer stopped, got %d cards", len(game.Deck))
+	}
+	if err := engine.PassHands(game.ID); err != nil {
+		t.Fatalf("Failed to pass: %v", err)
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if got := replayed.Players[0].Collection; len(got) != 1 || got[0].ID != "d2" {
+		t.Errorf("Expected the replay to pick the Tempura, got %v", got)
+	}
+	for i := range game.Deck {
+		if replayed.Deck[i].ID != game.Deck[i].ID {
+			t.Fatalf("Expected the replay to shuffle the deck the same way, got %v and %v", replayed.Deck, game.Deck)
+		}
+	}
+}
+
+// TestAdvanceWaitsOnMenu tests that Advance holds the hands for a human's Menu pick, and that bots and
+// timeouts take Menu cards on their own
+func TestAdvanceWaitsOnMenu(t *testing.T) {
+	engine := NewEngineWithDealer(&stackedDealer{
+		hands: [][]models.Card{
+			{card("menu", models.CardTypeMenu), card("tofu", models.CardTypeTofu), card("eel", models.CardTypeEel)},
+			{card("menu2", models.CardTypeMenu), card("eel2", models.CardTypeEel), card("eel3", models.CardTypeEel)},
+		},
+		deck: []models.Card{salmon("d1"), card("d2", models.CardTypeTempura), card("d3", models.CardTypeEel), card("d4", models.CardTypeTofu), salmon("d5")},
+	})
+	created, _ := engine.CreateGame([]string{"p1"})
+	engine.AddBot(created.ID, BotStrategyGreedy)
+	engine.StartGame(created.ID)
+	engine.StartRound(created.ID)
+	game := liveGame(t, engine, created.ID)
+
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	advance, err := engine.Advance(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to advance: %v", err)
+	}
+	if !advance.WaitingOnMenu || advance.Revealed {
+		t.Fatalf("Expected the turn to wait on p1's Menu pick, got %+v", advance)
+	}
+	if bot := game.Players[1]; len(bot.MenuDraw) != 0 || len(bot.Collection) != 1 {
+		t.Errorf("Expected the bot to take a Menu card at once, got %v", bot.Collection)
+	}
+
+	played, err := engine.AutoPlay(game.ID, FirstCardPolicy{})
+	if err != nil || len(played) != 1 || played[0] != "p1" {
+		t.Fatalf("Expected the timeout to take p1's Menu card, got %v, %v", played, err)
+	}
+	advance, err = engine.Advance(game.ID)
+	if err != nil || !advance.Revealed {
+		t.Fatalf("Expected the hands to be passed once every Menu card was taken, got %+v, %v", advance, err)
+	}
+	if game.RoundPhase != models.PhaseSelecting || len(game.Players[0].Hand) != 2 {
+		t.Errorf("Expected the next pick with two cards left, got %s with %d", game.RoundPhase, len(game.Players[0].Hand))
+	}
+}
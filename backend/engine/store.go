@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// GameStore persists game state so games survive a server restart
+type GameStore interface {
+	Save(game *models.Game) error
+	Load(gameID string) (*models.Game, error)
+	Delete(gameID string) error
+	List() ([]*models.Game, error)
+}
+
+// MemoryStore keeps serialized games in memory
+// Games are stored as JSON so loaded games never share state with the engine
+type MemoryStore struct {
+	games map[string][]byte
+	mu    sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory game store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		games: make(map[string][]byte),
+	}
+}
+
+// Save stores a copy of the game
+func (s *MemoryStore) Save(game *models.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game %s: %w", game.ID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.games[game.ID] = data
+	return nil
+}
+
+// Load retrieves a copy of a stored game
+func (s *MemoryStore) Load(gameID string) (*models.Game, error) {
+	s.mu.RLock()
+	data, exists := s.games[gameID]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrGameNotFound
+	}
+
+	return decodeGame(data)
+}
+
+// Delete removes a stored game
+func (s *MemoryStore) Delete(gameID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.games, gameID)
+	return nil
+}
+
+// List returns copies of all stored games
+func (s *MemoryStore) List() ([]*models.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	games := make([]*models.Game, 0, len(s.games))
+	for _, data := range s.games {
+		game, err := decodeGame(data)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// NopStore discards every game it is given
+// It suits engine-only runs, such as simulations, where nothing needs to outlive the process
+type NopStore struct{}
+
+// Save does nothing
+func (NopStore) Save(game *models.Game) error { return nil }
+
+// Load always reports the game as missing
+func (NopStore) Load(gameID string) (*models.Game, error) { return nil, ErrGameNotFound }
+
+// Delete does nothing
+func (NopStore) Delete(gameID string) error { return nil }
+
+// List returns no games
+func (NopStore) List() ([]*models.Game, error) { return nil, nil }
+
+// FileStore persists each game as a JSON file in a directory
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates a file-backed game store, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Save writes the game to disk, replacing any previous version atomically
+func (s *FileStore) Save(game *models.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game %s: %w", game.ID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Write to a temp file first so a crash never leaves a half-written game
+	tmp, err := os.CreateTemp(s.dir, game.ID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write game %s: %w", game.ID, err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write game %s: %w", game.ID, err)
+	}
+
+	return os.Rename(tmp.Name(), s.path(game.ID))
+}
+
+// Load reads a game from disk
+func (s *FileStore) Load(gameID string) (*models.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(gameID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrGameNotFound
+		}
+		return nil, err
+	}
+
+	return decodeGame(data)
+}
+
+// Delete removes a game file from disk
+func (s *FileStore) Delete(gameID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(gameID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List reads all games stored in the directory
+func (s *FileStore) List() ([]*models.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]*models.Game, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		game, err := decodeGame(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", filepath.Base(file), err)
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+// path returns the file path for a game, keeping IDs from escaping the directory
+func (s *FileStore) path(gameID string) string {
+	name := strings.ReplaceAll(filepath.Base(gameID), string(filepath.Separator), "_")
+	return filepath.Join(s.dir, name+".json")
+}
+
+// decodeGame unmarshals a stored game
+func decodeGame(data []byte) (*models.Game, error) {
+	var game models.Game
+	if err := json.Unmarshal(data, &game); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+	return &game, nil
+}
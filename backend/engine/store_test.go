@@ -0,0 +1,263 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestMemoryStoreSaveLoad tests that saved games can be loaded back as copies
+func TestMemoryStoreSaveLoad(t *testing.T) {
+	store := NewMemoryStore()
+
+	game := &models.Game{ID: "tokyo-sakura-12", NumRounds: 3, CardsPerHand: 10}
+	if err := store.Save(game); err != nil {
+		t.Fatalf("Failed to save game: %v", err)
+	}
+
+	loaded, err := store.Load(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+
+	if loaded == game {
+		t.Error("Loaded game should be a copy, not the saved pointer")
+	}
+	if loaded.ID != game.ID || loaded.NumRounds != 3 {
+		t.Errorf("Loaded game does not match saved game: %+v", loaded)
+	}
+
+	if err := store.Delete(game.ID); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+	if _, err := store.Load(game.ID); err != ErrGameNotFound {
+		t.Errorf("Expected ErrGameNotFound after delete, got %v", err)
+	}
+}
+
+// TestFileStoreSaveLoadList tests the file-backed store round trip
+func TestFileStoreSaveLoadList(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file store: %v", err)
+	}
+
+	selected := 2
+	game := &models.Game{
+		ID:           "kyoto-ume-34",
+		CurrentRound: 2,
+		RoundPhase:   models.PhaseSelecting,
+		Players: []*models.Player{
+			{
+				ID:           "p1",
+				Name:         "Alice",
+				Hand:         []models.Card{{ID: "tempura_0", Type: models.CardTypeTempura}},
+				SelectedCard: &selected,
+			},
+		},
+	}
+
+	if err := store.Save(game); err != nil {
+		t.Fatalf("Failed to save game: %v", err)
+	}
+
+	loaded, err := store.Load(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to load game: %v", err)
+	}
+	if loaded.CurrentRound != 2 || loaded.RoundPhase != models.PhaseSelecting {
+		t.Errorf("Loaded game state mismatch: round=%d phase=%s", loaded.CurrentRound, loaded.RoundPhase)
+	}
+	if len(loaded.Players) != 1 || loaded.Players[0].SelectedCard == nil || *loaded.Players[0].SelectedCard != 2 {
+		t.Errorf("Loaded player state mismatch: %+v", loaded.Players)
+	}
+
+	games, err := store.List()
+	if err != nil {
+		t.Fatalf("Failed to list games: %v", err)
+	}
+	if len(games) != 1 {
+		t.Errorf("Expected 1 stored game, got %d", len(games))
+	}
+
+	if err := store.Delete(game.ID); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+	if _, err := store.Load(game.ID); err != ErrGameNotFound {
+		t.Errorf("Expected ErrGameNotFound after delete, got %v", err)
+	}
+}
+
+// TestEngineReloadsGamesFromStore tests that a new engine picks up persisted games
+func TestEngineReloadsGamesFromStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create file store: %v", err)
+	}
+
+	first := NewEngine()
+	if err := first.SetStore(store); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+
+	game, _ := first.CreateGame([]string{"p1", "p2"})
+	first.StartGame(game.ID)
+	first.StartRound(game.ID)
+	if err := first.PlayCard(game.ID, "p1", 0, false, nil); err != nil {
+		t.Fatalf("Failed to play card: %v", err)
+	}
+
+	// Simulate a restart with a fresh engine on the same store
+	second := NewEngine()
+	if err := second.SetStore(store); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+
+	reloaded, err := second.GetGame(game.ID)
+	if err != nil {
+		t.Fatalf("Expected game to be reloaded: %v", err)
+	}
+	if reloaded.RoundPhase != models.PhaseSelecting {
+		t.Errorf("Expected phase selecting, got %s", reloaded.RoundPhase)
+	}
+	if len(reloaded.Players[0].Hand) != 10 {
+		t.Errorf("Expected reloaded hand of 10 cards, got %d", len(reloaded.Players[0].Hand))
+	}
+	if reloaded.Players[0].SelectedCard == nil {
+		t.Error("Expected p1's selection to survive the restart")
+	}
+
+	// Deleting the game removes it from the store too
+	second.DeleteGame(game.ID)
+	if _, err := store.Load(game.ID); err != ErrGameNotFound {
+		t.Errorf("Expected deleted game to be removed from store, got %v", err)
+	}
+}
+
+// TestNopStore tests that the no-op store keeps nothing
+func TestNopStore(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetStore(NopStore{}); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+
+	game, err := engine.CreateGame([]string{"p1", "p2"})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := engine.GetGame(game.ID); err != nil {
+		t.Errorf("Expected the engine to keep the live game, got %v", err)
+	}
+
+	games, _ := NopStore{}.List()
+	if len(games) != 0 {
+		t.Errorf("Expected no stored games, got %d", len(games))
+	}
+}
+
+// failingStore is a memory store whose saves fail once fail is set
+type failingStore struct {
+	*MemoryStore
+	fail bool
+}
+
+// Save fails while fail is set
+func (s *failingStore) Save(game *models.Game) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	return s.MemoryStore.Save(game)
+}
+
+// TestEngineRollsBackFailedSave tests that a move the store refused leaves the game as it was saved
+func TestEngineRollsBackFailedSave(t *testing.T) {
+	store := &failingStore{MemoryStore: NewMemoryStore()}
+	engine := NewEngine()
+	if err := engine.SetStore(store); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	store.fail = true
+	if err := engine.PlayCard(game.ID, "p1", 0, false, nil); err == nil {
+		t.Fatal("Expected the failed save to be reported")
+	}
+	live, _ := engine.GetGame(game.ID)
+	saved, _ := store.Load(game.ID)
+	if live.Players[0].SelectedCard != nil || saved.Players[0].SelectedCard != nil {
+		t.Error("Expected the pick to be rolled back in memory, as it never reached the store")
+	}
+	if events, _ := engine.Events(game.ID); events[len(events)-1].Type != EventRoundStarted {
+		t.Errorf("Expected no event for the rolled back pick, got %s", events[len(events)-1].Type)
+	}
+
+	store.fail = false
+	if err := engine.PlayCard(game.ID, "p1", 0, false, nil); err != nil {
+		t.Errorf("Expected the pick to succeed once the store recovered, got %v", err)
+	}
+}
+
+// blockingStore is a memory store whose saves wait until release is closed
+type blockingStore struct {
+	*MemoryStore
+	saving  chan struct{}
+	release chan struct{}
+}
+
+// Save signals it started, then waits to be released
+func (s *blockingStore) Save(game *models.Game) error {
+	s.saving <- struct{}{}
+	<-s.release
+	return s.MemoryStore.Save(game)
+}
+
+// TestCreateGameSavesOutsideEngineLock tests that creating a game doesn't hold up other games while it is saved
+func TestCreateGameSavesOutsideEngineLock(t *testing.T) {
+	engine := NewEngine()
+	existing, _ := engine.CreateGame([]string{"p1"})
+
+	store := &blockingStore{MemoryStore: NewMemoryStore(), saving: make(chan struct{}, 1), release: make(chan struct{})}
+	engine.SetStore(store)
+	created := make(chan *models.Game)
+	go func() {
+		game, _ := engine.CreateGame([]string{"p2"})
+		created <- game
+	}()
+	<-store.saving
+
+	looked := make(chan error)
+	go func() {
+		_, err := engine.GetGame(existing.ID)
+		looked <- err
+	}()
+	select {
+	case err := <-looked:
+		if err != nil {
+			t.Errorf("Failed to get the existing game: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the lookup not to wait on the new game's save")
+	}
+
+	close(store.release)
+	if game := <-created; game == nil || len(engine.ListGames()) != 2 {
+		t.Errorf("Expected the new game to be listed once saved, got %v", game)
+	}
+}
+
+// TestCreateGameFailedSave tests that a game the store refused is not left in the engine
+func TestCreateGameFailedSave(t *testing.T) {
+	engine := NewEngine()
+	engine.SetStore(&failingStore{MemoryStore: NewMemoryStore(), fail: true})
+
+	if _, err := engine.CreateGame([]string{"p1"}); err == nil {
+		t.Fatal("Expected the failed save to be reported")
+	}
+	if games := engine.ListGames(); len(games) != 0 {
+		t.Errorf("Expected no games, got %v", games)
+	}
+}
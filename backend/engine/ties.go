@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// ErrInvalidTieMode is returned for a tie mode other than split or full
+var ErrInvalidTieMode = errors.New("tie mode must be split or full")
+
+// ValidateTieMode checks that mode is a known tie mode
+func ValidateTieMode(mode models.TieMode) error {
+	if mode != models.TieSplit && mode != models.TieFull {
+		return ErrInvalidTieMode
+	}
+	return nil
+}
+
+// SetTieMode sets how tied Maki and Pudding points are shared in new games
+func (e *Engine) SetTieMode(mode models.TieMode) error {
+	if err := ValidateTieMode(mode); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.tieMode = mode
+	return nil
+}
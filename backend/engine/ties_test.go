@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestTieModeOptions tests that games record their tie mode and reject unknown ones
+func TestTieModeOptions(t *testing.T) {
+	engine := NewEngine()
+
+	game, _ := engine.CreateGame([]string{"p1", "p2"})
+	if game.TieMode != models.TieFull {
+		t.Errorf("Expected new games to give full points for ties, got %q", game.TieMode)
+	}
+
+	printed, err := engine.CreateGameWithOptions([]string{"p1", "p2"}, GameOptions{TieMode: models.TieSplit})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if printed.TieMode != models.TieSplit {
+		t.Errorf("Expected the printed rules, got %q", printed.TieMode)
+	}
+
+	if _, err := engine.CreateGameWithOptions(nil, GameOptions{TieMode: "half"}); err != ErrInvalidTieMode {
+		t.Errorf("Expected ErrInvalidTieMode, got %v", err)
+	}
+	if err := engine.SetTieMode("half"); err != ErrInvalidTieMode {
+		t.Errorf("Expected ErrInvalidTieMode, got %v", err)
+	}
+
+	if err := engine.SetTieMode(models.TieSplit); err != nil {
+		t.Fatalf("Failed to set tie mode: %v", err)
+	}
+	game, _ = engine.CreateGame(nil)
+	if game.TieMode != models.TieSplit {
+		t.Errorf("Expected the engine default to apply, got %q", game.TieMode)
+	}
+
+	replayed, err := engine.ReplayGame(printed.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if replayed.TieMode != models.TieSplit {
+		t.Errorf("Expected the replay to keep the tie mode, got %q", replayed.TieMode)
+	}
+}
+
+// TestTieModeScoring tests that round and dessert scoring follow the game's tie mode
+func TestTieModeScoring(t *testing.T) {
+	for mode, expected := range map[models.TieMode]struct{ maki, pudding int }{
+		models.TieSplit: {3, 3},
+		models.TieFull:  {6, 6},
+	} {
+		t.Run(string(mode), func(t *testing.T) {
+			maki := []models.Card{{Type: models.CardTypeMakiRoll, Value: 2}}
+			pudding := []models.Card{{Type: models.CardTypePudding}}
+			game := &models.Game{
+				TieMode:      mode,
+				RoundPhase:   models.PhaseScoring,
+				CurrentRound: 3,
+				NumRounds:    3,
+				Players: []*models.Player{
+					{ID: "p1", Collection: maki, PuddingCards: pudding},
+					{ID: "p2", Collection: maki, PuddingCards: pudding},
+					{ID: "p3"},
+				},
+			}
+
+			if err := scoreRound(game); err != nil {
+				t.Fatalf("Failed to score round: %v", err)
+			}
+			if game.Players[0].Score != expected.maki {
+				t.Errorf("Expected tied Maki to score %d, got %d", expected.maki, game.Players[0].Score)
+			}
+
+			if _, err := endGame(game); err != nil {
+				t.Fatalf("Failed to end game: %v", err)
+			}
+			if game.Players[1].DessertScore.Points != expected.pudding {
+				t.Errorf("Expected tied Pudding to score %d, got %d", expected.pudding, game.Players[1].DessertScore.Points)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+var (
+	ErrNothingToUndo = errors.New("there is no reveal to take back")
+	ErrUndoVoteOpen  = errors.New("an undo vote is already open")
+	ErrNoUndoVote    = errors.New("no undo vote is open")
+	ErrNotUndoVoter  = errors.New("only the players at the table vote on an undo")
+)
+
+// UndoStatus tells players whether the last reveal can be taken back and how a vote to do so stands
+type UndoStatus struct {
+	Available bool      `json:"available"`      // The last reveal can still be taken back
+	Vote      *UndoVote `json:"vote,omitempty"` // Open vote, if any
+}
+
+// UndoVote is an open vote to take back the last reveal
+type UndoVote struct {
+	RequestedBy string   `json:"requestedBy"`
+	Agreed      []string `json:"agreed"`  // Players who agreed, in seat order
+	Waiting     []string `json:"waiting"` // Players yet to vote, in seat order
+}
+
+// undoVote records who agreed to take back the last reveal
+type undoVote struct {
+	requestedBy string
+	agreed      map[string]bool
+}
+
+// RequestUndo opens a vote to take back the last reveal, counting the requester in favour
+// Every human player must agree before the next reveal; bots and the dummy don't vote
+// Reports whether the reveal was taken back, which happens at once when the requester is the only voter
+func (e *Engine) RequestUndo(gameID, playerID string) (bool, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return false, err
+	}
+	defer entry.mu.Unlock()
+
+	if err := checkUndoVoter(entry.game, playerID); err != nil {
+		return false, err
+	}
+	if !undoable(entry) {
+		return false, ErrNothingToUndo
+	}
+	if entry.vote != nil {
+		return false, ErrUndoVoteOpen
+	}
+
+	entry.vote = &undoVote{requestedBy: playerID, agreed: map[string]bool{playerID: true}}
+	return e.settleUndo(entry)
+}
+
+// VoteUndo records a player's vote on the open undo vote
+// A single refusal closes the vote; the last agreement takes the reveal back, which the result reports
+func (e *Engine) VoteUndo(gameID, playerID string, agree bool) (bool, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return false, err
+	}
+	defer entry.mu.Unlock()
+
+	if err := checkUndoVoter(entry.game, playerID); err != nil {
+		return false, err
+	}
+	if entry.vote == nil || !undoable(entry) {
+		entry.vote = nil
+		return false, ErrNoUndoVote
+	}
+
+	if !agree {
+		entry.vote = nil
+		return false, nil
+	}
+	entry.vote.agreed[playerID] = true
+	return e.settleUndo(entry)
+}
+
+// UndoStatus reports whether the game's last reveal can be taken back and the open vote, if any
+func (e *Engine) UndoStatus(gameID string) (*UndoStatus, error) {
+	entry, err := e.lockGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.mu.Unlock()
+
+	status := &UndoStatus{Available: undoable(entry)}
+	if !status.Available || entry.vote == nil {
+		return status, nil
+	}
+
+	status.Vote = &UndoVote{RequestedBy: entry.vote.requestedBy, Agreed: []string{}, Waiting: []string{}}
+	for _, player := range undoVoters(entry.game) {
+		if entry.vote.agreed[player.ID] {
+			status.Vote.Agreed = append(status.Vote.Agreed, player.ID)
+		} else {
+			status.Vote.Waiting = append(status.Vote.Waiting, player.ID)
+		}
+	}
+	return status, nil
+}
+
+// settleUndo takes the last reveal back once every voter has agreed
+// Callers must hold the game's lock
+func (e *Engine) settleUndo(entry *gameEntry) (bool, error) {
+	for _, player := range undoVoters(entry.game) {
+		if !entry.vote.agreed[player.ID] {
+			return false, nil
+		}
+	}
+
+	game, err := takeBack(entry.game, entry.undo)
+	if err != nil {
+		return false, err
+	}
+	entry.game = game
+	if err := e.commit(entry, EventRevealUndone, nil); err != nil {
+		return false, err
+	}
+	entry.undo, entry.vote = nil, nil
+	return true, nil
+}
+
+// undoable reports whether the game's last reveal can still be taken back:
+// only while the turn after it is being picked, in the same round
+func undoable(entry *gameEntry) bool {
+	return canTakeBack(entry.game, entry.undo)
+}
+
+// canTakeBack reports whether the table can go back to how it was before the last reveal
+func canTakeBack(game, before *models.Game) bool {
+	return before != nil && game.RoundPhase == models.PhaseSelecting && game.CurrentRound == before.CurrentRound
+}
+
+// takeBack returns the table as it was before the last reveal, with every pick of that turn withdrawn
+// Names and the host are kept as they are now, since they may have changed since the reveal
+func takeBack(game, before *models.Game) (*models.Game, error) {
+	if !canTakeBack(game, before) {
+		return nil, ErrNothingToUndo
+	}
+
+	restored := before.Clone()
+	restored.HostID = game.HostID
+	for i, player := range restored.Players {
+		if i < len(game.Players) {
+			player.Name = game.Players[i].Name
+		}
+		// Chopsticks used for the second card go back to the player
+		if player.SecondCard != nil {
+			player.ChopsticksCount++
+		}
+		player.SelectedCard = nil
+		player.SecondCard = nil
+		clearChoices(player)
+	}
+	return restored, nil
+}
+
+// checkUndoVoter verifies the player votes on undos
+func checkUndoVoter(game *models.Game, playerID string) error {
+	player := findPlayer(game, playerID)
+	if player == nil {
+		return errors.New("player not found")
+	}
+	if player.IsBot || player.IsDummy {
+		return ErrNotUndoVoter
+	}
+	return nil
+}
+
+// undoVoters returns the human players, who must all agree to an undo
+func undoVoters(game *models.Game) []*models.Player {
+	var voters []*models.Player
+	for _, player := range game.Players {
+		if !player.IsBot && !player.IsDummy {
+			voters = append(voters, player)
+		}
+	}
+	return voters
+}
@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestUndoVote tests that the last reveal is taken back once every human agrees
+func TestUndoVote(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(22)
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	game := liveGame(t, engine, created.ID)
+	bot, _ := engine.AddBot(game.ID, BotStrategyGreedy)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	if _, err := engine.RequestUndo(game.ID, "p1"); err != ErrNothingToUndo {
+		t.Errorf("Expected ErrNothingToUndo before any reveal, got %v", err)
+	}
+
+	hand := append([]models.Card(nil), game.Players[0].Hand...)
+	engine.PlayCard(game.ID, "p1", 0, false, nil)
+	engine.PlayCard(game.ID, "p2", 0, false, nil)
+	if advance, err := engine.Advance(game.ID); err != nil || !advance.Revealed {
+		t.Fatalf("Failed to reveal: %+v, %v", advance, err)
+	}
+
+	if _, err := engine.RequestUndo(game.ID, bot.ID); err != ErrNotUndoVoter {
+		t.Errorf("Expected ErrNotUndoVoter for the bot, got %v", err)
+	}
+	if undone, err := engine.RequestUndo(game.ID, "p1"); undone || err != nil {
+		t.Fatalf("Expected the vote to wait on p2, got %v, %v", undone, err)
+	}
+	if _, err := engine.RequestUndo(game.ID, "p2"); err != ErrUndoVoteOpen {
+		t.Errorf("Expected ErrUndoVoteOpen, got %v", err)
+	}
+	status, _ := engine.UndoStatus(game.ID)
+	if !status.Available || status.Vote == nil || !reflect.DeepEqual(status.Vote.Agreed, []string{"p1"}) || !reflect.DeepEqual(status.Vote.Waiting, []string{"p2"}) {
+		t.Errorf("Expected p1 to have agreed and p2 to be waited on, got %+v", status.Vote)
+	}
+
+	// A single refusal closes the vote
+	if undone, err := engine.VoteUndo(game.ID, "p2", false); undone || err != nil {
+		t.Errorf("Expected the refusal to close the vote, got %v, %v", undone, err)
+	}
+	if _, err := engine.VoteUndo(game.ID, "p2", true); err != ErrNoUndoVote {
+		t.Errorf("Expected ErrNoUndoVote, got %v", err)
+	}
+
+	engine.RequestUndo(game.ID, "p2")
+	if undone, err := engine.VoteUndo(game.ID, "p1", true); !undone || err != nil {
+		t.Fatalf("Expected the reveal to be taken back, got %v, %v", undone, err)
+	}
+
+	game = liveGame(t, engine, game.ID)
+	if game.Turn != 0 || game.RoundPhase != models.PhaseSelecting {
+		t.Errorf("Expected the first turn to be picked again, got turn %d in %s", game.Turn, game.RoundPhase)
+	}
+	for _, player := range game.Players {
+		if player.SelectedCard != nil || len(player.Collection)+len(player.PuddingCards) != 0 {
+			t.Errorf("Expected %s to have nothing picked or played, got %v and %d cards", player.ID, player.SelectedCard, len(player.Collection))
+		}
+	}
+	if !reflect.DeepEqual(game.Players[0].Hand, hand) {
+		t.Errorf("Expected p1's hand back as dealt, got %v", game.Players[0].Hand)
+	}
+	if status, _ := engine.UndoStatus(game.ID); status.Available {
+		t.Error("Expected a reveal to be taken back only once")
+	}
+
+	replayed, err := engine.ReplayGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	for i, player := range replayed.Players {
+		if !reflect.DeepEqual(player.Hand, game.Players[i].Hand) || len(player.Collection) != len(game.Players[i].Collection) {
+			t.Errorf("Expected the replayed %s to match the table after the undo", player.ID)
+		}
+	}
+}
+
+// TestUndoLapses tests that a vote lapses at the next reveal and nothing is taken back across a round end
+func TestUndoLapses(t *testing.T) {
+	engine := NewEngine()
+	engine.SetSeed(22)
+	created, _ := engine.CreateGame([]string{"p1", "p2"})
+	game := liveGame(t, engine, created.ID)
+	engine.StartGame(game.ID)
+	engine.StartRound(game.ID)
+
+	playTurn := func() {
+		t.Helper()
+		engine.PlayCard(game.ID, "p1", 0, false, nil)
+		engine.PlayCard(game.ID, "p2", 0, false, nil)
+		if _, err := engine.Advance(game.ID); err != nil {
+			t.Fatalf("Failed to advance: %v", err)
+		}
+	}
+
+	playTurn()
+	engine.RequestUndo(game.ID, "p1")
+	playTurn()
+	status, _ := engine.UndoStatus(game.ID)
+	if !status.Available || status.Vote != nil {
+		t.Errorf("Expected the vote to lapse and the new reveal to be undoable, got %+v", status)
+	}
+
+	for game.CurrentRound == 1 {
+		playTurn()
+	}
+	if _, err := engine.RequestUndo(game.ID, "p1"); err != ErrNothingToUndo {
+		t.Errorf("Expected ErrNothingToUndo after the round was scored, got %v", err)
+	}
+}
+
+// TestUndoAlone tests that a lone human playing bots takes a reveal back without waiting on anyone
+func TestUndoAlone(t *testing.T) {
+	engine := NewEngine()
+	created, _ := engine.CreateGame([]string{"p1"})
+	engine.AddBot(created.ID, BotStrategyGreedy)
+	engine.StartGame(created.ID)
+	engine.StartRound(created.ID)
+	engine.PlayCard(created.ID, "p1", 0, false, nil)
+	engine.Advance(created.ID)
+
+	if undone, err := engine.RequestUndo(created.ID, "p1"); !undone || err != nil {
+		t.Errorf("Expected the reveal to be taken back at once, got %v, %v", undone, err)
+	}
+}
+
+// TestTakeBackChopsticks tests that chopsticks used on the taken-back turn return to the player
+func TestTakeBackChopsticks(t *testing.T) {
+	first, second := 0, 1
+	before := &models.Game{
+		RoundPhase:   models.PhaseSelecting,
+		CurrentRound: 1,
+		Players:      []*models.Player{{ID: "p1", Name: "Old", SelectedCard: &first, SecondCard: &second}},
+	}
+	game := &models.Game{
+		RoundPhase:   models.PhaseSelecting,
+		CurrentRound: 1,
+		HostID:       "p1",
+		Players:      []*models.Player{{ID: "p1", Name: "New"}},
+	}
+
+	restored, err := takeBack(game, before)
+	if err != nil {
+		t.Fatalf("Failed to take back: %v", err)
+	}
+	player := restored.Players[0]
+	if player.ChopsticksCount != 1 || player.SelectedCard != nil || player.SecondCard != nil {
+		t.Errorf("Expected the chopsticks back and no picks, got %+v", player)
+	}
+	if player.Name != "New" || restored.HostID != "p1" {
+		t.Errorf("Expected the current name and host to be kept, got %q and %q", player.Name, restored.HostID)
+	}
+	if before.Players[0].SelectedCard == nil {
+		t.Error("Expected the kept table to be left untouched")
+	}
+}
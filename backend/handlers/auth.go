@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// Error codes sent with rejected privileged messages
+const (
+	ErrCodeNotHost   = "not_host"
+	ErrCodeNotInGame = "not_in_game"
+)
+
+var (
+	ErrNotHost   = errors.New("only the host can do that")
+	ErrNotInGame = errors.New("you are not a player in this game")
+)
+
+// AuthError is a privileged message rejected for the client that sent it
+type AuthError struct {
+	Action models.MessageType
+	GameID string
+	Err    error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s rejected for game %s: %v", e.Action, e.GameID, e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the error code clients can switch on
+func (e *AuthError) Code() string {
+	if errors.Is(e.Err, ErrNotInGame) {
+		return ErrCodeNotInGame
+	}
+	return ErrCodeNotHost
+}
+
+// authorizeHost checks that the client is a player in the game and currently its host
+func (h *WSHandler) authorizeHost(client *Client, action models.MessageType, gameID string) (*models.Game, error) {
+	game, err := h.engine.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.gameID != gameID || !hasPlayer(game, client.playerID) {
+		return nil, &AuthError{Action: action, GameID: gameID, Err: ErrNotInGame}
+	}
+	if game.HostID != client.playerID {
+		return nil, &AuthError{Action: action, GameID: gameID, Err: ErrNotHost}
+	}
+	return game, nil
+}
+
+// sendAuthorizationError reports why a message was rejected, with a code for authorization failures
+func (h *WSHandler) sendAuthorizationError(client *Client, err error) {
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		h.sendError(client, "Failed to get game: "+err.Error())
+		return
+	}
+
+	log.Printf("Authorization failed for player %s: %v", client.playerID, authErr)
+	msg := models.Message{
+		Type: models.MsgTypeError,
+		Payload: json.RawMessage(mustMarshal(map[string]string{
+			"error":  authErr.Err.Error(),
+			"code":   authErr.Code(),
+			"action": string(authErr.Action),
+		})),
+	}
+	h.sendToClient(client, msg)
+}
+
+// migrateHost hands the host role to a connected human when the host's seat is empty
+// It reports whether the host changed; callers broadcast the new state
+func (h *WSHandler) migrateHost(gameID string) bool {
+	game, err := h.engine.GetGame(gameID)
+	if err != nil || h.seatClaimed(gameID, game.HostID) {
+		return false
+	}
+
+	for _, player := range game.Players {
+		if player.IsBot || player.IsDummy || !h.seatClaimed(gameID, player.ID) {
+			continue
+		}
+
+		if err := h.engine.SetHost(gameID, player.ID); err != nil {
+			log.Printf("migrateHost: Failed to make %s host of game %s: %v", player.ID, gameID, err)
+			return false
+		}
+		log.Printf("migrateHost: %s is now host of game %s", player.ID, gameID)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// handlePlayDummyCard handles play_dummy_card messages
+// In the two-player variant the players take turns picking the dummy's card
+func (h *WSHandler) handlePlayDummyCard(client *Client, payload json.RawMessage) {
+	var data models.SelectCardPayload
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid play_dummy_card payload")
+		return
+	}
+
+	if err := h.engine.PlayDummyCard(client.gameID, client.playerID, data.CardIndex); err != nil {
+		log.Printf("handlePlayDummyCard: PlayDummyCard failed for player %s: %v", client.playerID, err)
+		h.sendError(client, "Failed to pick for the dummy: "+err.Error())
+		return
+	}
+
+	h.broadcastGameState(client.gameID)
+	h.advanceGame(client.gameID)
+}
+
+// handleWithdrawDummyCard handles withdraw_dummy_card messages
+func (h *WSHandler) handleWithdrawDummyCard(client *Client) {
+	if err := h.engine.WithdrawDummyCard(client.gameID, client.playerID); err != nil {
+		log.Printf("handleWithdrawDummyCard: WithdrawDummyCard failed for player %s: %v", client.playerID, err)
+		h.sendError(client, "Failed to withdraw the dummy's card: "+err.Error())
+		return
+	}
+
+	h.broadcastGameState(client.gameID)
+}
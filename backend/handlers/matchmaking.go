@@ -0,0 +1,390 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/models"
+)
+
+// matchKey identifies a matchmaking queue: clients asking for the same table size and rules wait together
+type matchKey struct {
+	players int
+	rules   string // MatchRules as JSON, so equal rule sets compare equal
+}
+
+// matchQueue is the clients waiting for one kind of match, oldest first
+type matchQueue struct {
+	key      matchKey
+	rules    models.MatchRules
+	waiting  []*matchSeeker
+	backfill *time.Timer // Fills the table with bots once it fires; nil when backfill is off
+}
+
+// matchSeeker is a client waiting in a queue, with the name it plays under
+type matchSeeker struct {
+	client *Client
+	name   string
+}
+
+// matchStatus is the payload of match_status messages
+type matchStatus struct {
+	Searching       bool `json:"searching"`
+	Waiting         int  `json:"waiting"` // Clients in the queue, this one included
+	Players         int  `json:"players"` // Table size being matched
+	BackfillSeconds int  `json:"backfillSeconds,omitempty"`
+}
+
+// SetMatchBackfill sets how long a matchmaking queue waits before its empty seats are filled with bots
+// Zero waits for human players only
+func (h *WSHandler) SetMatchBackfill(backfill time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.matchBackfill = backfill
+}
+
+// handleFindMatch handles find_match messages
+// The client waits in the queue for its table size and rules; the game is created and started
+// as soon as enough clients are waiting, or filled with bots once the backfill timeout passes
+func (h *WSHandler) handleFindMatch(client *Client, payload json.RawMessage) {
+	var data models.FindMatchPayload
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid find_match payload")
+		return
+	}
+	if client.gameID != "" {
+		h.sendError(client, "Already in a game; leave it first")
+		return
+	}
+
+	key, err := h.matchKeyFor(data)
+	if err != nil {
+		h.sendError(client, "Failed to find a match: "+err.Error())
+		return
+	}
+	name := data.PlayerName
+	if name == "" {
+		name = h.engine.GeneratePlayerName()
+	}
+
+	h.mu.Lock()
+	if client.spectating != "" {
+		h.mu.Unlock()
+		h.sendError(client, "Spectators cannot find a match; leave first")
+		return
+	}
+	if client.matching.players != 0 {
+		h.mu.Unlock()
+		h.sendError(client, "Already searching for a match")
+		return
+	}
+
+	queue := h.matchQueues[key]
+	if queue == nil {
+		queue = &matchQueue{key: key, rules: data.Rules}
+		h.matchQueues[key] = queue
+	}
+	queue.waiting = append(queue.waiting, &matchSeeker{client: client, name: name})
+	client.matching = key
+
+	var matched []*matchSeeker
+	if len(queue.waiting) >= key.players {
+		matched = h.takeSeekers(queue, key.players)
+	} else {
+		h.armBackfill(queue)
+	}
+	h.sendMatchStatus(queue)
+	h.mu.Unlock()
+
+	if matched != nil {
+		h.startMatch(queue.key, queue.rules, matched, client)
+	}
+}
+
+// handleCancelMatch handles cancel_match messages
+func (h *WSHandler) handleCancelMatch(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if client.matching.players == 0 {
+		h.sendError(client, "Not searching for a match")
+		return
+	}
+	h.leaveMatchQueue(client)
+	h.sendToClient(client, models.Message{
+		Type:    models.MsgTypeMatchStatus,
+		Payload: json.RawMessage(mustMarshal(matchStatus{})),
+	})
+}
+
+// matchKeyFor validates the table size and rules a client asked for
+func (h *WSHandler) matchKeyFor(data models.FindMatchPayload) (matchKey, error) {
+	rules := data.Rules
+	if rules.Menu != nil {
+		if err := engine.ValidateMenu(rules.Menu); err != nil {
+			return matchKey{}, err
+		}
+	}
+	if rules.TieMode != "" {
+		if err := engine.ValidateTieMode(rules.TieMode); err != nil {
+			return matchKey{}, err
+		}
+	}
+	if rules.PassDirection != "" {
+		if err := engine.ValidatePassPolicy(models.PassPolicy{Direction: rules.PassDirection}); err != nil {
+			return matchKey{}, err
+		}
+	}
+
+	limits := h.engine.PlayerLimits()
+	maxPlayers := limits.Max
+	if rules.Menu != nil {
+		maxPlayers = limits.PartyMax
+	}
+	if data.Players < limits.Min || data.Players > maxPlayers {
+		return matchKey{}, fmt.Errorf("players must be between %d and %d, got %d", limits.Min, maxPlayers, data.Players)
+	}
+
+	return matchKey{players: data.Players, rules: string(mustMarshal(rules))}, nil
+}
+
+// takeSeekers removes the oldest n clients from the queue, dropping the queue once it is empty
+// Callers must hold h.mu
+func (h *WSHandler) takeSeekers(queue *matchQueue, n int) []*matchSeeker {
+	matched := queue.waiting[:n:n]
+	queue.waiting = queue.waiting[n:]
+	for _, seeker := range matched {
+		seeker.client.matching = matchKey{}
+	}
+
+	if queue.backfill != nil {
+		queue.backfill.Stop()
+		queue.backfill = nil
+	}
+	if len(queue.waiting) == 0 {
+		delete(h.matchQueues, queue.key)
+	} else {
+		// Whoever is left starts waiting afresh
+		h.armBackfill(queue)
+	}
+	return matched
+}
+
+// leaveMatchQueue takes the client out of the queue it waits in, if any
+// Callers must hold h.mu
+func (h *WSHandler) leaveMatchQueue(client *Client) {
+	queue := h.matchQueues[client.matching]
+	client.matching = matchKey{}
+	if queue == nil {
+		return
+	}
+
+	for i, seeker := range queue.waiting {
+		if seeker.client == client {
+			queue.waiting = append(queue.waiting[:i], queue.waiting[i+1:]...)
+			break
+		}
+	}
+	if len(queue.waiting) == 0 {
+		if queue.backfill != nil {
+			queue.backfill.Stop()
+		}
+		delete(h.matchQueues, queue.key)
+		return
+	}
+	h.sendMatchStatus(queue)
+}
+
+// armBackfill starts the queue's backfill timer unless it is running or backfill is off
+// Callers must hold h.mu
+func (h *WSHandler) armBackfill(queue *matchQueue) {
+	if h.matchBackfill <= 0 || queue.backfill != nil {
+		return
+	}
+	queue.backfill = time.AfterFunc(h.matchBackfill, func() {
+		h.handleMatchBackfill(queue)
+	})
+}
+
+// handleMatchBackfill starts a game for everyone still waiting in the queue, filling the empty seats with bots
+func (h *WSHandler) handleMatchBackfill(queue *matchQueue) {
+	h.mu.Lock()
+	if h.matchQueues[queue.key] != queue || len(queue.waiting) == 0 {
+		h.mu.Unlock()
+		return
+	}
+	queue.backfill = nil
+	matched := h.takeSeekers(queue, len(queue.waiting))
+	h.mu.Unlock()
+
+	log.Printf("handleMatchBackfill: Filling a %d-player table for %d waiting clients with bots", queue.key.players, len(matched))
+	h.startMatch(queue.key, queue.rules, matched, nil)
+}
+
+// sendMatchStatus tells every client in the queue how many are waiting
+// Callers must hold h.mu
+func (h *WSHandler) sendMatchStatus(queue *matchQueue) {
+	msg := models.Message{
+		Type: models.MsgTypeMatchStatus,
+		Payload: json.RawMessage(mustMarshal(matchStatus{
+			Searching:       true,
+			Waiting:         len(queue.waiting),
+			Players:         queue.key.players,
+			BackfillSeconds: int(h.matchBackfill / time.Second),
+		})),
+	}
+	for _, seeker := range queue.waiting {
+		h.sendToClient(seeker.client, msg)
+	}
+}
+
+// startMatch creates a game for the matched clients, seats them and starts it with bots in any empty seats
+// self is the client whose message completed the match, whose own lock is already held
+func (h *WSHandler) startMatch(key matchKey, rules models.MatchRules, matched []*matchSeeker, self *Client) {
+	playerIDs := make([]string, len(matched))
+	for i := range matched {
+		playerIDs[i] = engine.GenerateRandomID()
+	}
+
+	options := engine.GameOptions{
+		Menu:       rules.Menu,
+		TieMode:    rules.TieMode,
+		ReadyCheck: models.ReadyCheckOff, // Everyone asked to play, so the game starts at once
+	}
+	if rules.PassDirection != "" {
+		options.PassPolicy = &models.PassPolicy{Direction: rules.PassDirection}
+	}
+
+	gameID, err := h.createMatch(playerIDs, matched, options)
+	if err != nil {
+		log.Printf("startMatch: Failed to create a match: %v", err)
+		for _, seeker := range matched {
+			h.sendError(seeker.client, "Failed to start a match: "+err.Error())
+		}
+		return
+	}
+
+	seated, err := h.seatMatch(gameID, matched, playerIDs, key.players, self)
+	if err != nil {
+		log.Printf("startMatch: Failed to start game %s: %v", gameID, err)
+		for _, seeker := range matched {
+			h.sendError(seeker.client, "Failed to start a match: "+err.Error())
+		}
+		h.engine.DeleteGame(gameID)
+		return
+	}
+
+	log.Printf("startMatch: Started game %s for %d matched clients", gameID, len(seated))
+	h.broadcastGameState(gameID)
+
+	// Let any bots make their first pick
+	h.advanceGame(gameID)
+	h.broadcastGamesList()
+}
+
+// seatMatch seats the matched clients still connected, fills the other seats with bots, starts the game and deals the first round
+// Every matched client's lock is held until the round is dealt, so nobody can hang up holding a seat,
+// each client's own messages see its seat, and a failed deal leaves nobody seated in a game that can't be played;
+// self's lock is already held
+func (h *WSHandler) seatMatch(gameID string, matched []*matchSeeker, playerIDs []string, players int, self *Client) ([]*Client, error) {
+	for _, seeker := range matched {
+		if seeker.client != self {
+			seeker.client.mu.Lock()
+			defer seeker.client.mu.Unlock()
+		}
+	}
+
+	var seated []*Client
+	var dropped []string
+	for i, seeker := range matched {
+		if h.seatMatched(seeker.client, gameID, playerIDs[i]) {
+			seated = append(seated, seeker.client)
+		} else {
+			dropped = append(dropped, playerIDs[i])
+		}
+	}
+
+	err := h.fillMatch(gameID, dropped, players-len(seated))
+	if err == nil && len(seated) == 0 {
+		err = errors.New("every matched player disconnected")
+	}
+	if err == nil {
+		err = h.engine.StartRound(gameID)
+	}
+	if err != nil {
+		for _, client := range seated {
+			h.unseatMatched(client, gameID)
+		}
+		return nil, err
+	}
+	return seated, nil
+}
+
+// createMatch creates the game for a match and names its players
+func (h *WSHandler) createMatch(playerIDs []string, matched []*matchSeeker, options engine.GameOptions) (string, error) {
+	game, err := h.engine.CreateGameWithOptions(playerIDs, options)
+	if err != nil {
+		return "", err
+	}
+
+	for i, seeker := range matched {
+		if err := h.engine.SetPlayerName(game.ID, playerIDs[i], seeker.name); err != nil {
+			h.engine.DeleteGame(game.ID)
+			return "", err
+		}
+	}
+	return game.ID, nil
+}
+
+// fillMatch gives up the seats of matched clients who hung up, seats bots in the empty seats and starts the game
+func (h *WSHandler) fillMatch(gameID string, dropped []string, bots int) error {
+	for _, playerID := range dropped {
+		if err := h.engine.RemovePlayer(gameID, playerID); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < bots; i++ {
+		if _, err := h.engine.AddBot(gameID, engine.BotStrategyGreedy); err != nil {
+			return err
+		}
+	}
+	return h.engine.StartGame(gameID)
+}
+
+// seatMatched seats a matched client in the game, unless it has disconnected
+// Callers must hold the client's lock
+func (h *WSHandler) seatMatched(client *Client, gameID, playerID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.allConnections[client] {
+		return false
+	}
+	client.playerID = playerID
+	client.gameID = gameID
+	h.clients[playerID] = client
+	if h.games[gameID] == nil {
+		h.games[gameID] = make(map[string]*Client)
+	}
+	h.games[gameID][playerID] = client
+	return true
+}
+
+// unseatMatched takes a seated client back out of a match that couldn't start
+// Callers must hold the client's lock
+func (h *WSHandler) unseatMatched(client *Client, gameID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if client.gameID != gameID {
+		return
+	}
+	delete(h.games, gameID)
+	delete(h.clients, client.playerID)
+	client.gameID, client.playerID = "", ""
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestStartMatchReplacesDisconnectedSeeker checks that a seeker who hangs up between being matched
+// and being seated loses their seat to a bot instead of holding it in a started game
+func TestStartMatchReplacesDisconnectedSeeker(t *testing.T) {
+	h := NewWSHandler(engine.NewEngine())
+	alice := &Client{send: make(chan []byte, 256)}
+	bob := &Client{send: make(chan []byte, 256)}
+	h.allConnections[alice] = true // Bob has already disconnected
+
+	matched := []*matchSeeker{{client: alice, name: "Alice"}, {client: bob, name: "Bob"}}
+	h.startMatch(matchKey{players: 2, rules: "{}"}, models.MatchRules{}, matched, nil)
+
+	if alice.gameID == "" {
+		t.Fatal("connected seeker was not seated")
+	}
+	if bob.gameID != "" || bob.playerID != "" {
+		t.Errorf("disconnected seeker was seated in %q as %q", bob.gameID, bob.playerID)
+	}
+
+	game, err := h.engine.GetGame(alice.gameID)
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+	if game.RoundPhase == models.PhaseWaitingForPlayers {
+		t.Fatal("match did not start")
+	}
+	if len(game.Players) != 2 {
+		t.Fatalf("got %d players, want 2", len(game.Players))
+	}
+	for _, player := range game.Players {
+		switch {
+		case player.ID == alice.playerID:
+		case player.IsBot:
+		default:
+			t.Errorf("seat %s (%s) belongs to nobody", player.ID, player.Name)
+		}
+	}
+}
+
+// failingDealer refuses to deal, like a deck that has run out
+type failingDealer struct{}
+
+func (failingDealer) DealCards(*models.Game, int, *rand.Rand) error {
+	return engine.ErrDeckExhausted
+}
+
+// TestStartMatchDealFails checks that every matched client hears about a first round that can't be dealt,
+// and that none of them is left seated in the game
+func TestStartMatchDealFails(t *testing.T) {
+	h := NewWSHandler(engine.NewEngineWithDealer(failingDealer{}))
+	alice := &Client{send: make(chan []byte, 256)}
+	bob := &Client{send: make(chan []byte, 256)}
+	h.allConnections[alice] = true
+	h.allConnections[bob] = true
+
+	matched := []*matchSeeker{{client: alice, name: "Alice"}, {client: bob, name: "Bob"}}
+	h.startMatch(matchKey{players: 2, rules: "{}"}, models.MatchRules{}, matched, nil)
+
+	for _, client := range []*Client{alice, bob} {
+		if client.gameID != "" || client.playerID != "" {
+			t.Errorf("client left seated in %q as %q", client.gameID, client.playerID)
+		}
+
+		var got []models.MessageType
+		for len(client.send) > 0 {
+			var msg models.Message
+			if err := json.Unmarshal(<-client.send, &msg); err != nil {
+				t.Fatalf("bad message: %v", err)
+			}
+			got = append(got, msg.Type)
+		}
+		if len(got) != 1 || got[0] != models.MsgTypeError {
+			t.Errorf("got %v, want one error", got)
+		}
+	}
+
+	if games := h.engine.ListGames(); len(games) != 0 {
+		t.Errorf("got %d games left behind, want none", len(games))
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// handlePickMenuCard handles pick_menu_card messages
+// A revealed Menu drew cards for the player, who takes one before the hands are passed
+func (h *WSHandler) handlePickMenuCard(client *Client, payload json.RawMessage) {
+	var data models.SelectCardPayload
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid pick_menu_card payload")
+		return
+	}
+
+	if err := h.engine.PickMenuCard(client.gameID, client.playerID, data.CardIndex); err != nil {
+		log.Printf("handlePickMenuCard: PickMenuCard failed for player %s: %v", client.playerID, err)
+		h.sendError(client, "Failed to pick a Menu card: "+err.Error())
+		return
+	}
+
+	h.broadcastGameState(client.gameID)
+	h.advanceGame(client.gameID)
+}
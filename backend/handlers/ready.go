@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// handleSetReady handles set_ready messages
+// When the game starts by itself once everyone is ready, the last player to get ready deals the first round
+func (h *WSHandler) handleSetReady(client *Client, payload json.RawMessage) {
+	var data models.SetReadyPayload
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid set_ready payload")
+		return
+	}
+
+	started, err := h.engine.SetReady(client.gameID, client.playerID, data.Ready)
+	if err != nil {
+		log.Printf("handleSetReady: SetReady failed for player %s: %v", client.playerID, err)
+		h.sendError(client, "Failed to set ready: "+err.Error())
+		return
+	}
+
+	if started {
+		log.Printf("handleSetReady: Everyone is ready, starting game %s", client.gameID)
+		h.dealFirstRound(client, client.gameID)
+		return
+	}
+	h.broadcastGameState(client.gameID)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+)
+
+// sessionTokens issues and verifies the reconnect tokens handed to players
+// A token is an HMAC of the game and player IDs, so it can't be forged without the secret
+// and only unlocks the seat it was issued for
+type sessionTokens struct {
+	secret []byte
+}
+
+// newSessionTokens creates a token issuer; an empty secret is replaced with a random one,
+// which invalidates outstanding tokens when the server restarts
+func newSessionTokens(secret []byte) *sessionTokens {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatalf("Failed to generate token secret: %v", err)
+		}
+	}
+	return &sessionTokens{secret: secret}
+}
+
+// Issue returns the reconnect token for a player's seat in a game
+func (s *sessionTokens) Issue(gameID, playerID string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(gameID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(playerID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether token was issued for the player's seat in the game
+func (s *sessionTokens) Verify(gameID, playerID, token string) bool {
+	expected := s.Issue(gameID, playerID)
+	return hmac.Equal([]byte(expected), []byte(token))
+}
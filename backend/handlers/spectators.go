@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/models"
+)
+
+// spectatorRejected lists the messages a spectator may not send
+// Joining is among them: a spectator's moves are refused, so a seat taken while watching could never be played
+var spectatorRejected = map[models.MessageType]bool{
+	models.MsgTypeJoinGame:          true,
+	models.MsgTypeStartGame:         true,
+	models.MsgTypeSetReady:          true,
+	models.MsgTypeSelectCard:        true,
+	models.MsgTypeWithdrawCard:      true,
+	models.MsgTypePlayDummyCard:     true,
+	models.MsgTypeWithdrawDummyCard: true,
+	models.MsgTypePickMenuCard:      true,
+	models.MsgTypeKickPlayer:        true,
+	models.MsgTypeAddBot:            true,
+	models.MsgTypeRequestUndo:       true,
+	models.MsgTypeVoteUndo:          true,
+}
+
+// handleSpectateGame handles spectate_game messages
+// The client becomes a read-only observer of the game
+func (h *WSHandler) handleSpectateGame(client *Client, payload json.RawMessage) {
+	var data struct {
+		GameID     string `json:"gameId"`
+		InviteCode string `json:"inviteCode,omitempty"` // Code or password for watching a private game
+	}
+
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid spectate_game payload")
+		return
+	}
+
+	if client.gameID != "" {
+		h.sendError(client, "Players cannot spectate; leave your game first")
+		return
+	}
+
+	game, err := h.engine.GetGame(data.GameID)
+	if err != nil {
+		h.sendError(client, "Failed to get game: "+err.Error())
+		return
+	}
+	if err := engine.CheckInvite(game, data.InviteCode); err != nil {
+		h.sendError(client, "Failed to spectate game: "+err.Error())
+		return
+	}
+
+	h.mu.Lock()
+	if client.spectating != "" {
+		delete(h.spectators[client.spectating], client)
+	}
+	if h.spectators[game.ID] == nil {
+		h.spectators[game.ID] = make(map[*Client]bool)
+	}
+	h.spectators[game.ID][client] = true
+	client.spectating = game.ID
+	h.mu.Unlock()
+
+	log.Printf("handleSpectateGame: Client is now spectating game %s", game.ID)
+
+	h.sendToClient(client, models.Message{
+		Type:    models.MsgTypeGameState,
+		Payload: json.RawMessage(mustMarshal(h.buildSpectatorState(game))),
+	})
+}
+
+// stopSpectating removes the client from the game it is watching, if any
+func (h *WSHandler) stopSpectating(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if client.spectating == "" {
+		return
+	}
+	delete(h.spectators[client.spectating], client)
+	if len(h.spectators[client.spectating]) == 0 {
+		delete(h.spectators, client.spectating)
+	}
+	client.spectating = ""
+}
+
+// buildSpectatorState creates the redacted game state sent to spectators
+// It has no hand and no player identity, only what is visible on the table
+func (h *WSHandler) buildSpectatorState(game *models.Game) map[string]interface{} {
+	state := h.buildGameState(game, "")
+	delete(state, "myHand")
+	delete(state, "myPlayerId")
+	state["spectator"] = true
+	return state
+}
+
+// broadcastToSpectators sends a message to everyone watching a game
+func (h *WSHandler) broadcastToSpectators(gameID string, message models.Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.spectators[gameID] {
+		h.sendToClient(client, message)
+	}
+}
+
+// releaseGame drops the handler's per-game state once a game is deleted
+// Spectators are told the game is gone
+func (h *WSHandler) releaseGame(gameID string) {
+	h.stopTurnTimer(gameID)
+
+	h.broadcastToSpectators(gameID, models.Message{
+		Type:    models.MsgTypeGameDeleted,
+		Payload: json.RawMessage(mustMarshal(map[string]string{"message": "This game has been deleted"})),
+	})
+
+	h.mu.Lock()
+	for client := range h.spectators[gameID] {
+		client.spectating = ""
+	}
+	delete(h.spectators, gameID)
+	delete(h.advancing, gameID)
+	h.mu.Unlock()
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"log"
+	"time"
+
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/models"
+)
+
+// defaultAutoPlayPolicy plays a random card for idle players
+var defaultAutoPlayPolicy engine.AutoPlayPolicy = engine.RandomCardPolicy{}
+
+// turnTimer is the pending timeout for the current pick in one game
+type turnTimer struct {
+	deadline time.Time
+	timer    *time.Timer
+}
+
+// SetAutoPlayPolicy sets how cards are chosen for players whose turn times out
+func (h *WSHandler) SetAutoPlayPolicy(policy engine.AutoPlayPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.autoPlay = policy
+}
+
+// ResumeTurnTimers arms the timers of games loaded with a pending pick deadline, e.g. after a restart
+// Timers are otherwise only armed when a game's state is broadcast, which a game nobody acts in never gets
+// A deadline that passed while the server was down times out at once
+func (h *WSHandler) ResumeTurnTimers() {
+	for gameID, deadline := range h.engine.TurnDeadlines() {
+		deadline := deadline
+		h.setTurnTimer(gameID, &deadline)
+	}
+}
+
+// scheduleTurnTimer arms a timer for the game's current pick deadline
+// An existing timer for the same deadline is kept; a stale one is replaced
+func (h *WSHandler) scheduleTurnTimer(game *models.Game) {
+	h.setTurnTimer(game.ID, game.TurnDeadline)
+}
+
+// setTurnTimer arms a timer for a game's pick deadline, or cancels it when there is none
+func (h *WSHandler) setTurnTimer(gameID string, deadline *time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	current, exists := h.turnTimers[gameID]
+	if deadline == nil {
+		if exists {
+			current.timer.Stop()
+			delete(h.turnTimers, gameID)
+		}
+		return
+	}
+
+	at := *deadline
+	if exists {
+		if current.deadline.Equal(at) {
+			return
+		}
+		current.timer.Stop()
+	}
+
+	h.turnTimers[gameID] = &turnTimer{
+		deadline: at,
+		timer: time.AfterFunc(time.Until(at), func() {
+			h.handleTurnTimeout(gameID, at)
+		}),
+	}
+}
+
+// stopTurnTimer cancels any pending pick timeout for the game
+func (h *WSHandler) stopTurnTimer(gameID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if current, exists := h.turnTimers[gameID]; exists {
+		current.timer.Stop()
+		delete(h.turnTimers, gameID)
+	}
+}
+
+// handleTurnTimeout auto-plays for idle players once the pick deadline passes
+func (h *WSHandler) handleTurnTimeout(gameID string, deadline time.Time) {
+	h.mu.Lock()
+	current, exists := h.turnTimers[gameID]
+	if !exists || !current.deadline.Equal(deadline) {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.turnTimers, gameID)
+	policy := h.autoPlay
+	h.mu.Unlock()
+
+	game, err := h.engine.GetGame(gameID)
+	if err != nil || game.TurnDeadline == nil || !game.TurnDeadline.Equal(deadline) {
+		// The turn already moved on
+		return
+	}
+
+	played, err := h.engine.AutoPlay(gameID, policy)
+	if err != nil {
+		log.Printf("handleTurnTimeout: AutoPlay failed for game %s: %v", gameID, err)
+		return
+	}
+	log.Printf("handleTurnTimeout: Turn timed out in game %s, auto-played for %v", gameID, played)
+
+	h.broadcastGameState(gameID)
+	h.advanceGame(gameID)
+}
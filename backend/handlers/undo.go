@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// handleRequestUndo handles request_undo messages
+// The requester opens a vote to take back the last reveal; every player sees it in the game state
+func (h *WSHandler) handleRequestUndo(client *Client) {
+	undone, err := h.engine.RequestUndo(client.gameID, client.playerID)
+	if err != nil {
+		log.Printf("handleRequestUndo: RequestUndo failed for player %s: %v", client.playerID, err)
+		h.sendError(client, "Failed to request an undo: "+err.Error())
+		return
+	}
+
+	h.settleUndo(client.gameID, undone)
+}
+
+// handleVoteUndo handles vote_undo messages
+func (h *WSHandler) handleVoteUndo(client *Client, payload json.RawMessage) {
+	var data models.VoteUndoPayload
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid vote_undo payload")
+		return
+	}
+
+	undone, err := h.engine.VoteUndo(client.gameID, client.playerID, data.Agree)
+	if err != nil {
+		log.Printf("handleVoteUndo: VoteUndo failed for player %s: %v", client.playerID, err)
+		h.sendError(client, "Failed to vote on the undo: "+err.Error())
+		return
+	}
+
+	h.settleUndo(client.gameID, undone)
+}
+
+// settleUndo broadcasts the vote, and once the reveal was taken back lets bots pick the turn again
+func (h *WSHandler) settleUndo(gameID string, undone bool) {
+	if undone {
+		log.Printf("settleUndo: Last reveal taken back in game %s", gameID)
+	}
+	h.broadcastGameState(gameID)
+	if undone {
+		h.advanceGame(gameID)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"sync"
@@ -19,11 +20,15 @@ var upgrader = websocket.Upgrader{
 }
 
 // Client represents a connected WebSocket client
+// mu is held while one of the client's messages is handled, so matchmaking can seat it from another goroutine
 type Client struct {
-	conn     *websocket.Conn
-	send     chan []byte
-	gameID   string
-	playerID string
+	conn       *websocket.Conn
+	send       chan []byte
+	gameID     string
+	playerID   string
+	spectating string   // gameID being watched read-only; guarded by WSHandler.mu
+	matching   matchKey // queue the client waits in for a match; guarded by WSHandler.mu
+	mu         sync.Mutex
 }
 
 // WSHandler implements WebSocketHandler interface
@@ -32,6 +37,14 @@ type WSHandler struct {
 	clients        map[string]*Client            // playerID -> Client
 	games          map[string]map[string]*Client // gameID -> playerID -> Client
 	allConnections map[*Client]bool              // All connected clients (including those not in games)
+	spectators     map[string]map[*Client]bool   // gameID -> read-only observers
+	turnTimers     map[string]*turnTimer         // gameID -> pending pick timeout
+	autoPlay       engine.AutoPlayPolicy         // Picks cards for players who time out
+	tokens         *sessionTokens                // Issues the tokens players need to reclaim their seat
+	advancing      map[string]*sync.Mutex        // gameID -> lock that keeps a game's advance broadcasts in order
+	matchQueues    map[matchKey]*matchQueue      // Clients waiting for a match, by table size and rules
+	matchBackfill  time.Duration                 // How long a queue waits before bots fill its table (0: never)
+	keepGames      bool                          // Keep games everyone disconnected from, for players to reconnect to
 	mu             sync.RWMutex
 }
 
@@ -42,6 +55,12 @@ func NewWSHandler(engine *engine.Engine) *WSHandler {
 		clients:        make(map[string]*Client),
 		games:          make(map[string]map[string]*Client),
 		allConnections: make(map[*Client]bool),
+		spectators:     make(map[string]map[*Client]bool),
+		turnTimers:     make(map[string]*turnTimer),
+		advancing:      make(map[string]*sync.Mutex),
+		matchQueues:    make(map[matchKey]*matchQueue),
+		autoPlay:       defaultAutoPlayPolicy,
+		tokens:         newSessionTokens(nil),
 	}
 }
 
@@ -73,7 +92,9 @@ func (h *WSHandler) HandleConnection(w http.ResponseWriter, r *http.Request) {
 // readPump reads messages from the WebSocket connection
 func (h *WSHandler) readPump(client *Client) {
 	defer func() {
+		client.mu.Lock()
 		h.removeClient(client)
+		client.mu.Unlock()
 		client.conn.Close()
 	}()
 
@@ -86,7 +107,9 @@ func (h *WSHandler) readPump(client *Client) {
 			break
 		}
 
+		client.mu.Lock()
 		h.handleMessage(client, message)
+		client.mu.Unlock()
 	}
 }
 
@@ -115,17 +138,38 @@ func (h *WSHandler) handleMessage(client *Client, message []byte) {
 
 	log.Printf("Message type: %s, PlayerID: %s, GameID: %s", msg.Type, client.playerID, client.gameID)
 
+	// Spectators can watch but not act
+	h.mu.RLock()
+	spectating := client.spectating
+	h.mu.RUnlock()
+	if spectating != "" && spectatorRejected[msg.Type] {
+		h.sendError(client, "Spectators cannot send "+string(msg.Type))
+		return
+	}
+
 	switch msg.Type {
 	case models.MsgTypeJoinGame:
 		h.handleJoinGame(client, msg.Payload)
 	case models.MsgTypeStartGame:
 		h.handleStartGame(client, msg.Payload)
+	case models.MsgTypeSetReady:
+		log.Printf("Handling set_ready for player %s in game %s", client.playerID, client.gameID)
+		h.handleSetReady(client, msg.Payload)
 	case models.MsgTypeSelectCard:
 		log.Printf("Handling select_card for player %s in game %s", client.playerID, client.gameID)
 		h.handleSelectCard(client, msg.Payload)
 	case models.MsgTypeWithdrawCard:
 		log.Printf("Handling withdraw_card for player %s in game %s", client.playerID, client.gameID)
 		h.handleWithdrawCard(client, msg.Payload)
+	case models.MsgTypePlayDummyCard:
+		log.Printf("Handling play_dummy_card for player %s in game %s", client.playerID, client.gameID)
+		h.handlePlayDummyCard(client, msg.Payload)
+	case models.MsgTypeWithdrawDummyCard:
+		log.Printf("Handling withdraw_dummy_card for player %s in game %s", client.playerID, client.gameID)
+		h.handleWithdrawDummyCard(client)
+	case models.MsgTypePickMenuCard:
+		log.Printf("Handling pick_menu_card for player %s in game %s", client.playerID, client.gameID)
+		h.handlePickMenuCard(client, msg.Payload)
 	case models.MsgTypeKickPlayer:
 		log.Printf("Handling kick_player for player %s in game %s", client.playerID, client.gameID)
 		h.handleKickPlayer(client, msg.Payload)
@@ -138,6 +182,24 @@ func (h *WSHandler) handleMessage(client *Client, message []byte) {
 	case models.MsgTypeDeleteGame:
 		log.Printf("Handling delete_game")
 		h.handleDeleteGame(client, msg.Payload)
+	case models.MsgTypeSpectateGame:
+		log.Printf("Handling spectate_game")
+		h.handleSpectateGame(client, msg.Payload)
+	case models.MsgTypeAddBot:
+		log.Printf("Handling add_bot for player %s in game %s", client.playerID, client.gameID)
+		h.handleAddBot(client, msg.Payload)
+	case models.MsgTypeFindMatch:
+		log.Printf("Handling find_match")
+		h.handleFindMatch(client, msg.Payload)
+	case models.MsgTypeCancelMatch:
+		log.Printf("Handling cancel_match")
+		h.handleCancelMatch(client)
+	case models.MsgTypeRequestUndo:
+		log.Printf("Handling request_undo for player %s in game %s", client.playerID, client.gameID)
+		h.handleRequestUndo(client)
+	case models.MsgTypeVoteUndo:
+		log.Printf("Handling vote_undo for player %s in game %s", client.playerID, client.gameID)
+		h.handleVoteUndo(client, msg.Payload)
 	default:
 		log.Printf("Unknown message type: %s", msg.Type)
 		h.sendError(client, "Unknown message type")
@@ -147,8 +209,20 @@ func (h *WSHandler) handleMessage(client *Client, message []byte) {
 // handleJoinGame handles join_game messages
 func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 	var data struct {
-		GameID     string `json:"gameId"`
-		PlayerName string `json:"playerName"`
+		GameID             string                 `json:"gameId"`
+		PlayerName         string                 `json:"playerName"`
+		PlayerID           string                 `json:"playerId,omitempty"`           // Seat to reclaim when reconnecting
+		Token              string                 `json:"token,omitempty"`              // Reconnect token issued for that seat
+		TurnTimeoutSeconds *int                   `json:"turnTimeoutSeconds,omitempty"` // Only used when creating a game
+		Menu               *models.Menu           `json:"menu,omitempty"`               // Sushi Go Party! menu, only used when creating a game
+		TieMode            models.TieMode         `json:"tieMode,omitempty"`            // split or full, only used when creating a game
+		Deck               *models.DeckDefinition `json:"deck,omitempty"`               // Custom deck for the original game, only used when creating a game
+		PassPolicy         *models.PassPolicy     `json:"passPolicy,omitempty"`         // Who receives each hand, only used when creating a game
+		Dummy              bool                   `json:"dummy,omitempty"`              // Two-player variant with a dummy hand, only used when creating a game
+		ReadyCheck         models.ReadyCheck      `json:"readyCheck,omitempty"`         // off, required or auto, only used when creating a game
+		Private            bool                   `json:"private,omitempty"`            // Hide the game from the games list, only used when creating a game
+		Password           string                 `json:"password,omitempty"`           // Code for joining the private game instead of a generated one, only used when creating a game
+		InviteCode         string                 `json:"inviteCode,omitempty"`         // Code or password for joining a private game
 	}
 
 	if err := json.Unmarshal(payload, &data); err != nil {
@@ -159,7 +233,7 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 	// Generate random player name if not provided
 	playerName := data.PlayerName
 	if playerName == "" {
-		playerName = engine.GeneratePlayerName()
+		playerName = h.engine.GeneratePlayerName()
 	}
 
 	var game *models.Game
@@ -170,15 +244,29 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 	if data.GameID == "" {
 		// Create new game
 		playerID = engine.GenerateRandomID()
-		game, err = h.engine.CreateGame([]string{playerID})
+		options := engine.GameOptions{}
+		if data.TurnTimeoutSeconds != nil {
+			timeout := time.Duration(*data.TurnTimeoutSeconds) * time.Second
+			options.TurnTimeout = &timeout
+		}
+		options.Menu = data.Menu
+		options.TieMode = data.TieMode
+		options.Deck = data.Deck
+		options.PassPolicy = data.PassPolicy
+		options.Dummy = data.Dummy
+		options.ReadyCheck = data.ReadyCheck
+		options.Private = data.Private
+		options.Password = data.Password
+		game, err = h.engine.CreateGameWithOptions([]string{playerID}, options)
 		if err != nil {
 			h.sendError(client, "Failed to create game: "+err.Error())
 			return
 		}
 
 		// Set player name
-		if len(game.Players) > 0 {
-			game.Players[0].Name = playerName
+		if err := h.engine.SetPlayerName(game.ID, playerID, playerName); err != nil {
+			h.sendError(client, "Failed to set player name: "+err.Error())
+			return
 		}
 	} else {
 		// Try to join existing game
@@ -197,8 +285,33 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 			}
 		}
 
-		if existingPlayer != nil {
-			// Reconnection: use existing player ID
+		if data.PlayerID != "" {
+			// Reclaiming a specific seat requires the token issued for it
+			if !h.tokens.Verify(game.ID, data.PlayerID, data.Token) {
+				log.Printf("Rejected reconnect to player %s in game %s: invalid token", data.PlayerID, game.ID)
+				h.sendError(client, "Invalid reconnect token")
+				return
+			}
+			if !hasPlayer(game, data.PlayerID) {
+				h.sendError(client, "Player not found in game")
+				return
+			}
+
+			playerID = data.PlayerID
+			isReconnection = true
+			log.Printf("Player %s reconnecting to game %s with token", playerID, data.GameID)
+		} else if err := engine.CheckInvite(game, data.InviteCode); err != nil {
+			// Without a seat token, a private game takes its invite code
+			log.Printf("Rejected join to private game %s: %v", game.ID, err)
+			h.sendError(client, "Failed to join game: "+err.Error())
+			return
+		} else if existingPlayer != nil {
+			// Without a token, a name only reclaims a seat nobody is connected to
+			if existingPlayer.IsBot || existingPlayer.IsDummy || h.seatClaimed(game.ID, existingPlayer.ID) {
+				h.sendError(client, "Name is already taken in this game")
+				return
+			}
+
 			playerID = existingPlayer.ID
 			isReconnection = true
 			log.Printf("Player %s reconnecting to game %s", playerName, data.GameID)
@@ -211,19 +324,10 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 				return
 			}
 
-			// Refresh game state
-			game, err = h.engine.GetGame(data.GameID)
-			if err != nil {
-				h.sendError(client, "Failed to get game: "+err.Error())
-				return
-			}
-
 			// Set player name
-			for _, player := range game.Players {
-				if player.ID == playerID {
-					player.Name = playerName
-					break
-				}
+			if err := h.engine.SetPlayerName(data.GameID, playerID, playerName); err != nil {
+				h.sendError(client, "Failed to set player name: "+err.Error())
+				return
 			}
 		}
 	}
@@ -235,7 +339,7 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 	h.mu.Lock()
 	// Remove old client connection if reconnecting
 	if isReconnection {
-		if oldClient, exists := h.clients[playerID]; exists {
+		if oldClient, exists := h.clients[playerID]; exists && oldClient != client {
 			close(oldClient.send)
 		}
 	}
@@ -247,8 +351,16 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 	gameWasCreated := data.GameID == ""
 	h.mu.Unlock()
 
-	// Broadcast updated game state to all players in the game
-	h.broadcastGameState(game.ID)
+	// A player returning to a game whose host has gone takes over
+	h.migrateHost(game.ID)
+
+	if gameWasCreated && game.Private {
+		// The engine keeps only a hash of the invite code, so the host is shown it once, with the new game
+		h.sendCreatedState(client, game.ID, playerID, game.InviteCode)
+	} else {
+		// Broadcast updated game state to all players in the game
+		h.broadcastGameState(game.ID)
+	}
 
 	// If a new game was created, broadcast updated games list to all clients
 	if gameWasCreated {
@@ -260,6 +372,43 @@ func (h *WSHandler) handleJoinGame(client *Client, payload json.RawMessage) {
 	}
 }
 
+// SetTokenSecret sets the key reconnect tokens are signed with
+// Use a fixed secret with a persistent store so tokens stay valid across restarts
+func (h *WSHandler) SetTokenSecret(secret []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tokens = newSessionTokens(secret)
+}
+
+// SetKeepGames sets whether games stay in the engine once all their players have disconnected
+// Use it with a durable store, so a shutdown that closes every connection doesn't wipe the saved games
+func (h *WSHandler) SetKeepGames(keep bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.keepGames = keep
+}
+
+// seatClaimed reports whether a live connection currently holds the player's seat
+func (h *WSHandler) seatClaimed(gameID, playerID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	_, claimed := h.games[gameID][playerID]
+	return claimed
+}
+
+// hasPlayer reports whether the game has a player with the given ID
+func hasPlayer(game *models.Game, playerID string) bool {
+	for _, player := range game.Players {
+		if player.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
 // handleStartGame handles start_game messages
 func (h *WSHandler) handleStartGame(client *Client, payload json.RawMessage) {
 	var data struct {
@@ -271,20 +420,32 @@ func (h *WSHandler) handleStartGame(client *Client, payload json.RawMessage) {
 		return
 	}
 
+	if _, err := h.authorizeHost(client, models.MsgTypeStartGame, data.GameID); err != nil {
+		h.sendAuthorizationError(client, err)
+		return
+	}
+
 	// Start the game
 	if err := h.engine.StartGame(data.GameID); err != nil {
 		h.sendError(client, "Failed to start game: "+err.Error())
 		return
 	}
 
-	// Start the first round
-	if err := h.engine.StartRound(data.GameID); err != nil {
+	h.dealFirstRound(client, data.GameID)
+}
+
+// dealFirstRound deals the first round of a game that just started and lets any bots make their first pick
+func (h *WSHandler) dealFirstRound(client *Client, gameID string) {
+	if err := h.engine.StartRound(gameID); err != nil {
 		h.sendError(client, "Failed to start round: "+err.Error())
 		return
 	}
 
 	// Broadcast updated game state
-	h.broadcastGameState(data.GameID)
+	h.broadcastGameState(gameID)
+
+	// Let any bots make their first pick
+	h.advanceGame(gameID)
 }
 
 // handleSelectCard handles select_card messages
@@ -301,7 +462,14 @@ func (h *WSHandler) handleSelectCard(client *Client, payload json.RawMessage) {
 	log.Printf("handleSelectCard: Player %s selecting card index %d", client.playerID, data.CardIndex)
 
 	// Play the card
-	if err := h.engine.PlayCard(client.gameID, client.playerID, data.CardIndex, data.UseChopsticks, data.SecondCardIndex); err != nil {
+	opts := engine.PlayOptions{
+		UseChopsticks:   data.UseChopsticks,
+		SecondCardIndex: data.SecondCardIndex,
+		Spoon:           data.Spoon,
+		CopyCardID:      data.CopyCardID,
+		FlipCardIDs:     data.FlipCardIDs,
+	}
+	if err := h.engine.PlayCardWithOptions(client.gameID, client.playerID, data.CardIndex, opts); err != nil {
 		log.Printf("handleSelectCard: PlayCard failed: %v", err)
 		h.sendError(client, "Failed to select card: "+err.Error())
 		return
@@ -312,111 +480,82 @@ func (h *WSHandler) handleSelectCard(client *Client, payload json.RawMessage) {
 	// Broadcast updated game state (without revealing the card)
 	h.broadcastGameState(client.gameID)
 
-	// Check if all players have selected cards
-	game, err := h.engine.GetGame(client.gameID)
-	if err != nil {
-		log.Printf("handleSelectCard: Failed to get game: %v", err)
-		return
-	}
-
-	allSelected := true
-	for _, player := range game.Players {
-		if player.SelectedCard == nil {
-			allSelected = false
-			break
-		}
-	}
+	h.advanceGame(client.gameID)
+	log.Printf("handleSelectCard: Finished for player %s", client.playerID)
+}
 
-	log.Printf("handleSelectCard: All players selected? %v", allSelected)
+// advanceGame lets the engine advance the game, broadcasting each step, while bots alone can complete the next turn
+// It is shared by game start, card selection and the turn timer, so it is serialized per game
+func (h *WSHandler) advanceGame(gameID string) {
+	lock := h.advanceLock(gameID)
+	lock.Lock()
+	defer lock.Unlock()
 
-	if allSelected {
-		log.Printf("handleSelectCard: All players selected, revealing cards")
-		// Reveal cards
-		if err := h.engine.RevealCards(client.gameID); err != nil {
-			log.Printf("Failed to reveal cards: %v", err)
-			return
+	for {
+		advance, err := h.engine.Advance(gameID)
+		if err != nil {
+			// Outside card selection there is nothing to advance
+			if !errors.Is(err, engine.ErrWrongPhase) {
+				log.Printf("advanceGame: Failed to advance game %s: %v", gameID, err)
+				h.broadcastError(gameID, "Failed to continue the game: "+err.Error())
+			}
+			if advance == nil {
+				return
+			}
 		}
 
-		log.Printf("handleSelectCard: Cards revealed, broadcasting")
-		// Broadcast card reveal
-		h.broadcastGameState(client.gameID)
-
-		log.Printf("handleSelectCard: Passing hands")
-		// Pass hands
-		if err := h.engine.PassHands(client.gameID); err != nil {
-			log.Printf("Failed to pass hands: %v", err)
+		if len(advance.BotsPlayed) > 0 {
+			log.Printf("advanceGame: Bots %v played in game %s", advance.BotsPlayed, gameID)
+		}
+		if advance.Result != nil {
+			h.broadcastGameEnd(gameID, advance.Result)
+			h.broadcastGameState(gameID)
+			h.scheduleGameDeletion(gameID)
 			return
 		}
+		if advance.RoundScored > 0 {
+			h.broadcastRoundEnd(gameID)
+		}
+		if len(advance.BotsPlayed) > 0 || advance.Revealed || advance.WaitingOnMenu {
+			h.broadcastGameState(gameID)
+		}
 
-		log.Printf("handleSelectCard: Hands passed, getting updated game state")
-		// Get updated game state
-		game, err = h.engine.GetGame(client.gameID)
-		if err != nil {
-			log.Printf("handleSelectCard: Failed to get game after passing: %v", err)
+		// Stop once the turn waits on a human pick
+		if err != nil || !advance.Revealed {
 			return
 		}
+	}
+}
 
-		// Check if round ended
-		if game.RoundPhase == models.PhaseScoring {
-			// Score the round
-			if err := h.engine.ScoreRound(client.gameID); err != nil {
-				log.Printf("Failed to score round: %v", err)
-				return
-			}
+// advanceLock returns the lock that serializes advancing one game
+// Games advance independently; within a game it keeps a round's end from being broadcast after the next round's state
+func (h *WSHandler) advanceLock(gameID string) *sync.Mutex {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
-			// Get updated game state
-			game, err = h.engine.GetGame(client.gameID)
-			if err != nil {
-				return
-			}
+	lock, exists := h.advancing[gameID]
+	if !exists {
+		lock = &sync.Mutex{}
+		h.advancing[gameID] = lock
+	}
+	return lock
+}
 
-			// Check if game ended
-			if game.RoundPhase == models.PhaseGameEnd {
-				// Calculate final results
-				result, err := h.engine.EndGame(client.gameID)
-				if err != nil {
-					log.Printf("Failed to end game: %v", err)
-					return
-				}
+// scheduleGameDeletion deletes a finished game after a delay to ensure all clients receive the result
+func (h *WSHandler) scheduleGameDeletion(gameID string) {
+	go func() {
+		time.Sleep(5 * time.Second)
+		log.Printf("Deleting completed game: %s", gameID)
+		h.engine.DeleteGame(gameID)
 
-				// Broadcast game end
-				h.broadcastGameEnd(client.gameID, result)
-
-				// Broadcast final game state
-				h.broadcastGameState(client.gameID)
-
-				// Delete the game after a delay to ensure all clients receive the message
-				gameID := client.gameID
-				go func() {
-					time.Sleep(5 * time.Second)
-					log.Printf("Deleting completed game: %s", gameID)
-					h.engine.DeleteGame(gameID)
-
-					// Clean up client mappings
-					h.mu.Lock()
-					delete(h.games, gameID)
-					h.mu.Unlock()
-
-					log.Printf("Game %s deleted successfully", gameID)
-				}()
-			} else {
-				// Broadcast round end
-				h.broadcastRoundEnd(client.gameID)
-
-				// Start next round
-				if err := h.engine.StartRound(client.gameID); err != nil {
-					log.Printf("Failed to start next round: %v", err)
-					return
-				}
-			}
-		}
+		// Clean up client mappings
+		h.mu.Lock()
+		delete(h.games, gameID)
+		h.mu.Unlock()
+		h.releaseGame(gameID)
 
-		log.Printf("handleSelectCard: Broadcasting final game state")
-		// Broadcast updated game state
-		h.broadcastGameState(client.gameID)
-		log.Printf("handleSelectCard: Complete")
-	}
-	log.Printf("handleSelectCard: Finished for player %s", client.playerID)
+		log.Printf("Game %s deleted successfully", gameID)
+	}()
 }
 
 // handleWithdrawCard handles withdraw_card messages
@@ -440,6 +579,15 @@ func (h *WSHandler) handleWithdrawCard(client *Client, payload json.RawMessage)
 
 // handleLeaveGame handles leave_game messages
 func (h *WSHandler) handleLeaveGame(client *Client) {
+	h.mu.RLock()
+	spectating := client.spectating
+	h.mu.RUnlock()
+	if spectating != "" {
+		h.stopSpectating(client)
+		log.Printf("Spectator stopped watching game %s", spectating)
+		return
+	}
+
 	if client.gameID == "" {
 		h.sendError(client, "Not in a game")
 		return
@@ -465,6 +613,7 @@ func (h *WSHandler) handleLeaveGame(client *Client) {
 			h.mu.Unlock()
 
 			log.Printf("All players left game %s, deleting game", gameID)
+			h.releaseGame(gameID)
 			if err := h.engine.DeleteGame(gameID); err != nil {
 				log.Printf("Failed to delete empty game %s: %v", gameID, err)
 			}
@@ -473,6 +622,8 @@ func (h *WSHandler) handleLeaveGame(client *Client) {
 			h.broadcastGamesList()
 		} else {
 			h.mu.Unlock()
+			// Once the game has started the seat stays, so pass the host role on here
+			h.migrateHost(gameID)
 			// Broadcast updated game state to remaining players
 			h.broadcastGameState(gameID)
 		}
@@ -486,6 +637,34 @@ func (h *WSHandler) handleLeaveGame(client *Client) {
 	log.Printf("Player %s left game %s", playerID, gameID)
 }
 
+// handleAddBot handles add_bot messages
+// Only the host can add bots, and only before the game starts
+func (h *WSHandler) handleAddBot(client *Client, payload json.RawMessage) {
+	var data struct {
+		Strategy string `json:"strategy"`
+	}
+
+	if err := json.Unmarshal(payload, &data); err != nil {
+		h.sendError(client, "Invalid add_bot payload")
+		return
+	}
+
+	if _, err := h.authorizeHost(client, models.MsgTypeAddBot, client.gameID); err != nil {
+		h.sendAuthorizationError(client, err)
+		return
+	}
+
+	bot, err := h.engine.AddBot(client.gameID, data.Strategy)
+	if err != nil {
+		h.sendError(client, "Failed to add bot: "+err.Error())
+		return
+	}
+	log.Printf("handleAddBot: Added bot %s (%s) to game %s", bot.ID, bot.BotStrategy, client.gameID)
+
+	h.broadcastGameState(client.gameID)
+	h.broadcastGamesList()
+}
+
 // handleKickPlayer handles kick_player messages
 func (h *WSHandler) handleKickPlayer(client *Client, payload json.RawMessage) {
 	log.Printf("handleKickPlayer: Starting for player %s", client.playerID)
@@ -500,11 +679,11 @@ func (h *WSHandler) handleKickPlayer(client *Client, payload json.RawMessage) {
 		return
 	}
 
-	// Get the game to check if it's in waiting phase
-	game, err := h.engine.GetGame(client.gameID)
+	// Only the host can kick, and only in the waiting phase
+	game, err := h.authorizeHost(client, models.MsgTypeKickPlayer, client.gameID)
 	if err != nil {
-		log.Printf("handleKickPlayer: Failed to get game: %v", err)
-		h.sendError(client, "Failed to get game: "+err.Error())
+		log.Printf("handleKickPlayer: Not authorized: %v", err)
+		h.sendAuthorizationError(client, err)
 		return
 	}
 
@@ -570,6 +749,13 @@ func (h *WSHandler) handleDeleteGame(client *Client, payload json.RawMessage) {
 		return
 	}
 
+	// Only the host of the game can delete it
+	if _, err := h.authorizeHost(client, models.MsgTypeDeleteGame, data.GameID); err != nil {
+		log.Printf("handleDeleteGame: Not authorized: %v", err)
+		h.sendAuthorizationError(client, err)
+		return
+	}
+
 	// Notify all players in the game that it's being deleted
 	h.mu.RLock()
 	if gameClients, ok := h.games[data.GameID]; ok {
@@ -584,6 +770,7 @@ func (h *WSHandler) handleDeleteGame(client *Client, payload json.RawMessage) {
 	h.mu.RUnlock()
 
 	// Delete the game from the engine first
+	h.releaseGame(data.GameID)
 	if err := h.engine.DeleteGame(data.GameID); err != nil {
 		log.Printf("handleDeleteGame: DeleteGame failed: %v", err)
 		h.sendError(client, "Failed to delete game: "+err.Error())
@@ -634,7 +821,33 @@ func (h *WSHandler) broadcastGameState(gameID string) {
 		log.Printf("broadcastGameState: Sending to player %s", playerID)
 		h.sendToClient(client, msg)
 	}
+
+	// Spectators all share one redacted view
+	h.broadcastToSpectators(gameID, models.Message{
+		Type:    models.MsgTypeGameState,
+		Payload: json.RawMessage(mustMarshal(h.buildSpectatorState(game))),
+	})
 	log.Printf("broadcastGameState: Complete for game %s", gameID)
+
+	// Keep the pick timer in line with the state players were just shown
+	h.scheduleTurnTimer(game)
+}
+
+// sendCreatedState sends the host of a new private game its state along with the invite code
+// Nobody else is in the game yet, so this stands in for broadcastGameState
+func (h *WSHandler) sendCreatedState(client *Client, gameID, playerID, inviteCode string) {
+	game, err := h.engine.GetGame(gameID)
+	if err != nil {
+		log.Printf("Failed to get game: %v", err)
+		return
+	}
+
+	state := h.buildGameState(game, playerID)
+	state["inviteCode"] = inviteCode
+	h.sendToClient(client, models.Message{
+		Type:    models.MsgTypeGameState,
+		Payload: json.RawMessage(mustMarshal(state)),
+	})
 }
 
 // broadcastRoundEnd sends round_end message to all players
@@ -644,8 +857,25 @@ func (h *WSHandler) broadcastRoundEnd(gameID string) {
 		return
 	}
 
+	// Each player's score for the round just scored, category by category
+	scores := make([]map[string]interface{}, 0, len(game.Players))
+	for _, player := range game.Players {
+		breakdown := models.ScoreBreakdown{Categories: []models.CategoryScore{}}
+		if n := len(player.RoundBreakdowns); n > 0 {
+			breakdown = player.RoundBreakdowns[n-1]
+		}
+		scores = append(scores, map[string]interface{}{
+			"playerId":   player.ID,
+			"playerName": player.Name,
+			"roundScore": breakdown.Total,
+			"score":      player.Score,
+			"breakdown":  breakdown,
+		})
+	}
+
 	payload := map[string]interface{}{
-		"round": game.CurrentRound,
+		"round":  game.CurrentRound,
+		"scores": scores,
 	}
 
 	msg := models.Message{
@@ -689,7 +919,7 @@ func (h *WSHandler) broadcastGamesList() {
 // buildGameState creates a game state for a specific player
 func (h *WSHandler) buildGameState(game *models.Game, playerID string) map[string]interface{} {
 	players := make([]map[string]interface{}, len(game.Players))
-	var myHand []models.Card
+	var myHand, myMenuDraw, dummyHand []models.Card
 
 	for i, player := range game.Players {
 		hasSelected := player.SelectedCard != nil
@@ -704,22 +934,94 @@ func (h *WSHandler) buildGameState(game *models.Game, playerID string) map[strin
 			"hasSelected":     hasSelected,
 			"roundScores":     player.RoundScores,
 			"chopsticksCount": player.ChopsticksCount,
+			"isBot":           player.IsBot,
+			"isDummy":         player.IsDummy,
+			"isHost":          player.ID == game.HostID,
+			"ready":           player.Ready,
+			"pickingFromMenu": len(player.MenuDraw) > 0,
 		}
 
-		// Include hand only for the requesting player
+		// Include hand and Menu draw only for the requesting player
 		if player.ID == playerID {
 			myHand = player.Hand
+			myMenuDraw = player.MenuDraw
+		}
+		if player.IsDummy {
+			dummyHand = player.Hand
+		}
+	}
+
+	// Before the game starts, show the hand size the current table would get
+	// (a lone host sees the two-player size)
+	cardsPerHand := game.CardsPerHand
+	if game.HandSizeMode == models.HandSizeRules && game.RoundPhase == models.PhaseWaitingForPlayers {
+		cardsPerHand, _ = engine.CardsPerPlayerFor(game, max(len(game.Players), game.MinPlayers))
+	}
+
+	state := map[string]interface{}{
+		"gameId":             game.ID,
+		"players":            players,
+		"currentRound":       game.CurrentRound,
+		"phase":              game.RoundPhase,
+		"myPlayerId":         playerID,
+		"hostId":             game.HostID,
+		"myHand":             myHand,
+		"cardsPerHand":       cardsPerHand,
+		"handSizeMode":       game.HandSizeMode,
+		"turnTimeoutSeconds": int(game.TurnTimeout / time.Second),
+		"tieMode":            game.TieMode,
+		"passPolicy":         game.PassPolicy,
+		"readyCheck":         game.ReadyCheck,
+		"passDirection":      engine.PassDirectionFor(game, max(game.CurrentRound, 1)),
+		"minPlayers":         game.MinPlayers,
+		"maxPlayers":         game.MaxPlayers,
+	}
+
+	if game.Menu != nil {
+		state["menu"] = game.Menu
+	}
+	if len(myMenuDraw) > 0 {
+		state["menuDraw"] = myMenuDraw
+	}
+	if game.DeckDefinition != nil {
+		state["deck"] = game.DeckDefinition
+	}
+
+	// In the dummy variant, only the player picking for the dummy sees its hand
+	if game.Dummy {
+		state["dummy"] = true
+		if picker := engine.DummyPicker(game); picker != nil {
+			state["dummyPickerId"] = picker.ID
+			if picker.ID == playerID {
+				state["dummyHand"] = dummyHand
+			}
 		}
 	}
 
-	return map[string]interface{}{
-		"gameId":       game.ID,
-		"players":      players,
-		"currentRound": game.CurrentRound,
-		"phase":        game.RoundPhase,
-		"myPlayerId":   playerID,
-		"myHand":       myHand,
+	// Let players take back the last reveal while they still can
+	if undo, err := h.engine.UndoStatus(game.ID); err == nil && undo.Available {
+		state["undo"] = undo
+	}
+
+	// Only the player themselves learns the token for their seat
+	if hasPlayer(game, playerID) {
+		state["reconnectToken"] = h.tokens.Issue(game.ID, playerID)
+	}
+	if game.Private {
+		state["private"] = true
+	}
+
+	// Include the pick deadline so clients can render a countdown
+	if game.TurnDeadline != nil {
+		remaining := time.Until(*game.TurnDeadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+		state["turnDeadline"] = game.TurnDeadline
+		state["turnTimeRemainingMs"] = remaining.Milliseconds()
 	}
+
+	return state
 }
 
 // BroadcastToGame sends a message to all players in a game
@@ -731,6 +1033,7 @@ func (h *WSHandler) BroadcastToGame(gameID string, message models.Message) error
 	for _, client := range clients {
 		h.sendToClient(client, message)
 	}
+	h.broadcastToSpectators(gameID, message)
 
 	return nil
 }
@@ -781,24 +1084,45 @@ func (h *WSHandler) sendError(client *Client, errorMsg string) {
 	h.sendToClient(client, msg)
 }
 
+// broadcastError sends an error message to all players in a game
+func (h *WSHandler) broadcastError(gameID string, errorMsg string) {
+	h.BroadcastToGame(gameID, models.Message{
+		Type:    models.MsgTypeError,
+		Payload: json.RawMessage(mustMarshal(map[string]string{"error": errorMsg})),
+	})
+}
+
 // removeClient removes a client from the handler
 func (h *WSHandler) removeClient(client *Client) {
 	h.mu.Lock()
 
 	// Remove from all connections
 	delete(h.allConnections, client)
+	if client.spectating != "" {
+		delete(h.spectators[client.spectating], client)
+	}
+	if client.matching.players != 0 {
+		h.leaveMatchQueue(client)
+	}
+
+	// A reconnected player has already replaced this connection; leave their seat alone
+	replaced := h.clients[client.playerID] != client
 
-	if client.playerID != "" {
+	if client.playerID != "" && !replaced {
 		delete(h.clients, client.playerID)
 	}
 
-	gameToDelete := ""
-	if client.gameID != "" && client.playerID != "" {
+	gameToDelete, gameToKeep := "", ""
+	if client.gameID != "" && client.playerID != "" && !replaced {
 		if gameClients, ok := h.games[client.gameID]; ok {
 			delete(gameClients, client.playerID)
 			if len(gameClients) == 0 {
 				delete(h.games, client.gameID)
-				gameToDelete = client.gameID
+				if h.keepGames {
+					gameToKeep = client.gameID
+				} else {
+					gameToDelete = client.gameID
+				}
 			}
 		}
 	}
@@ -808,14 +1132,24 @@ func (h *WSHandler) removeClient(client *Client) {
 	h.mu.Unlock()
 
 	// If game has no more clients, delete it from the engine
-	if gameToDelete != "" {
+	if gameToKeep != "" {
+		// Nobody is left to pick, so the timer waits until someone reconnects
+		log.Printf("All players disconnected from game %s, keeping it for them to reconnect", gameToKeep)
+		h.stopTurnTimer(gameToKeep)
+	} else if gameToDelete != "" {
 		log.Printf("All players disconnected from game %s, deleting game", gameToDelete)
+		h.releaseGame(gameToDelete)
 		if err := h.engine.DeleteGame(gameToDelete); err != nil {
 			log.Printf("Failed to delete empty game %s: %v", gameToDelete, err)
 		} else {
 			// Broadcast updated games list to all connected clients
 			h.broadcastGamesList()
 		}
+	} else if client.gameID != "" && client.playerID != "" && !replaced {
+		// Players still connected need a host if this was it
+		if h.migrateHost(client.gameID) {
+			h.broadcastGameState(client.gameID)
+		}
 	}
 }
 
@@ -4,15 +4,29 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/models"
 	"github.com/sushi-go-game/backend/server"
 )
 
 func main() {
 	// Command-line flags for game configuration
 	numRounds := flag.Int("rounds", 3, "Number of rounds per game (default: 3)")
-	cardsPerHand := flag.Int("cards", 10, "Number of cards dealt per hand (default: 10)")
+	cardsPerHand := flag.Int("cards", 0, "Fixed number of cards dealt per hand as a house rule (default: 0, official hand size for the player count)")
 	port := flag.String("port", ":8080", "Server port (default: :8080)")
+	seed := flag.Int64("seed", 0, "Seed for game IDs and shuffling, for reproducible games (default: random)")
+	turnTimeout := flag.Duration("turn-timeout", 0, "Time allowed per pick before a random card is played for idle players, e.g. 30s (default: 0, no timer)")
+	tokenSecret := flag.String("token-secret", os.Getenv("SUSHI_TOKEN_SECRET"), "Secret for signing reconnect tokens (default: $SUSHI_TOKEN_SECRET, or random per run)")
+	maxPlayers := flag.Int("max-players", engine.DefaultPlayerLimits.Max, "Seats per game with the original deck (2-5)")
+	maxPartyPlayers := flag.Int("max-party-players", engine.DefaultPlayerLimits.PartyMax, "Seats per game with a Sushi Go Party! menu (2-8)")
+	tieMode := flag.String("tie-mode", string(models.TieFull), "How tied players share Maki and Pudding points: full (every tied player scores the full points) or split (printed rules)")
+	passDirection := flag.String("pass-direction", string(models.PassLeft), "Which way hands are passed: left (printed rules), right or alternate (left in odd rounds, right in even rounds)")
+	readyCheck := flag.String("ready-check", string(models.ReadyCheckOff), "Whether players must be ready before a game starts: off (host starts at will), required (start_game waits for everyone) or auto (the game starts once everyone is ready)")
+	matchBackfill := flag.Duration("match-backfill", 0, "Time find_match waits for players before filling the table with bots, e.g. 30s (default: 0, wait for players)")
+	deckFile := flag.String("deck", "", "YAML or JSON deck definition to deal in place of the original 108 cards, e.g. decks/teaching.yaml (default: original deck)")
+	dataDir := flag.String("data-dir", "", "Directory for persisting games and their event logs across restarts (default: in-memory only)")
 	flag.Parse()
 
 	// Create server with configuration
@@ -21,6 +35,46 @@ func main() {
 			NumRounds:    *numRounds,
 			CardsPerHand: *cardsPerHand,
 		},
+		TurnTimeout: *turnTimeout,
+		TokenSecret: []byte(*tokenSecret),
+		PlayerLimits: &engine.PlayerLimits{
+			Min:      engine.DefaultPlayerLimits.Min,
+			Max:      *maxPlayers,
+			PartyMax: *maxPartyPlayers,
+		},
+		TieMode:       models.TieMode(*tieMode),
+		PassPolicy:    models.PassPolicy{Direction: models.PassDirection(*passDirection)},
+		ReadyCheck:    models.ReadyCheck(*readyCheck),
+		MatchBackfill: *matchBackfill,
+	}
+
+	// Only fix the seed when the flag was given explicitly
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			options.Seed = seed
+		}
+	})
+
+	if *deckFile != "" {
+		deck, err := engine.LoadDeckDefinition(*deckFile)
+		if err != nil {
+			log.Fatalf("Failed to load deck: %v", err)
+		}
+		options.Deck = deck
+	}
+
+	if *dataDir != "" {
+		store, err := engine.NewFileStore(*dataDir)
+		if err != nil {
+			log.Fatalf("Failed to open game store: %v", err)
+		}
+		options.Store = store
+
+		eventLog, err := engine.NewFileEventLog(*dataDir)
+		if err != nil {
+			log.Fatalf("Failed to open event log: %v", err)
+		}
+		options.EventLog = eventLog
 	}
 
 	srv, err := server.NewServer(*port, options)
@@ -29,7 +83,29 @@ func main() {
 	}
 
 	fmt.Printf("Server starting on port %d\n", srv.Port)
-	fmt.Printf("Game configuration: %d rounds, %d cards per hand\n", *numRounds, *cardsPerHand)
+	if *cardsPerHand > 0 {
+		fmt.Printf("Game configuration: %d rounds, %d cards per hand (house rule)\n", *numRounds, *cardsPerHand)
+	} else {
+		fmt.Printf("Game configuration: %d rounds, cards per hand by player count\n", *numRounds)
+	}
+	if *turnTimeout > 0 {
+		fmt.Printf("Turn timeout: %s per pick\n", *turnTimeout)
+	}
+	if models.TieMode(*tieMode) == models.TieSplit {
+		fmt.Println("Tied Maki and Pudding players split the points (printed rules)")
+	}
+	if *passDirection != string(models.PassLeft) {
+		fmt.Printf("Hands are passed %s\n", *passDirection)
+	}
+	if options.Deck != nil {
+		fmt.Printf("Deck: %s\n", options.Deck.Name)
+	}
+	if *matchBackfill > 0 {
+		fmt.Printf("Matchmaking fills tables with bots after %s\n", *matchBackfill)
+	}
+	if *readyCheck != string(models.ReadyCheckOff) {
+		fmt.Printf("Ready check: %s\n", *readyCheck)
+	}
 	if err := srv.Start(); err != nil {
 		log.Fatal("Server error: ", err)
 	}
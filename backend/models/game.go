@@ -14,6 +14,26 @@ const (
 	CardTypeWasabi     CardType = "wasabi"
 	CardTypeChopsticks CardType = "chopsticks"
 	CardTypePudding    CardType = "pudding"
+
+	// Sushi Go Party! cards
+	CardTypeTemaki           CardType = "temaki"
+	CardTypeUramaki          CardType = "uramaki"
+	CardTypeOnigiri          CardType = "onigiri"
+	CardTypeEdamame          CardType = "edamame"
+	CardTypeEel              CardType = "eel"
+	CardTypeTofu             CardType = "tofu"
+	CardTypeMisoSoup         CardType = "miso_soup"
+	CardTypeSoySauce         CardType = "soy_sauce"
+	CardTypeTea              CardType = "tea"
+	CardTypeSpoon            CardType = "spoon"
+	CardTypeMenu             CardType = "menu"
+	CardTypeSpecialOrder     CardType = "special_order"
+	CardTypeTakeoutBox       CardType = "takeout_box"
+	CardTypeGreenTeaIceCream CardType = "green_tea_ice_cream"
+	CardTypeFruit            CardType = "fruit"
+
+	// CardTypeFaceDown is a card a Takeout Box turned face down, worth 2 points
+	CardTypeFaceDown CardType = "face_down"
 )
 
 // RoundPhase represents the current phase of a round
@@ -29,38 +49,155 @@ const (
 	PhaseGameEnd           RoundPhase = "game_end"
 )
 
+// HandSizeMode represents how the number of cards per hand is chosen
+type HandSizeMode string
+
+const (
+	HandSizeRules HandSizeMode = "rules" // Official table: 2→10, 3→9, 4→8, 5→7 cards
+	HandSizeHouse HandSizeMode = "house" // Fixed cards per hand chosen by the server
+)
+
+// TieMode represents how tied players share the Maki and Pudding points
+type TieMode string
+
+const (
+	TieSplit TieMode = "split" // Printed rules: tied players split the points, rounded down
+	TieFull  TieMode = "full"  // Default: every tied player scores the full points
+)
+
+// ReadyCheck represents whether players declare themselves ready before a game starts
+type ReadyCheck string
+
+const (
+	ReadyCheckOff      ReadyCheck = "off"      // The host starts the game whenever they like
+	ReadyCheckRequired ReadyCheck = "required" // The game starts only once every player is ready
+	ReadyCheckAuto     ReadyCheck = "auto"     // The game starts by itself once every player is ready
+)
+
+// PassDirection represents which way hands are passed after each reveal
+type PassDirection string
+
+const (
+	PassLeft      PassDirection = "left"      // Printed rules: player i receives the hand of player i-1
+	PassRight     PassDirection = "right"     // Player i receives the hand of player i+1
+	PassAlternate PassDirection = "alternate" // Left in odd rounds, right in even rounds
+	PassCustom    PassDirection = "custom"    // Each player passes to the seat given by the permutation
+)
+
+// PassPolicy decides who receives each hand
+type PassPolicy struct {
+	Direction PassDirection `json:"direction"`
+	// Permutation maps each seat to the seat it passes to, for PassCustom
+	// It must list every seat at the table exactly once
+	Permutation []int `json:"permutation,omitempty"`
+}
+
 // Card represents a single card in the game
 type Card struct {
 	ID      string   `json:"id"`
 	Type    CardType `json:"type"`
-	Variant string   `json:"variant,omitempty"` // For Nigiri types (Squid, Salmon, Egg)
-	Value   int      `json:"value,omitempty"`   // Maki roll count or base points
+	Variant string   `json:"variant,omitempty"` // Nigiri type (Squid, Salmon, Egg), Onigiri shape or Fruit pair
+	Value   int      `json:"value,omitempty"`   // Maki/Uramaki roll count or base points
+	Turn    int      `json:"turn,omitempty"`    // Turn of the round it was played, once in a collection
+}
+
+// CardRequest names the card a Spoon asks the other players for
+type CardRequest struct {
+	Type    CardType `json:"type"`
+	Variant string   `json:"variant,omitempty"` // Nigiri fish, Onigiri shape or Fruit pair; empty for any card of the type
+}
+
+// Menu selects the Sushi Go Party! categories shuffled into a game's deck
+// Nigiri is always included
+type Menu struct {
+	Roll       CardType   `json:"roll"`
+	Appetizers []CardType `json:"appetizers"` // Exactly three
+	Specials   []CardType `json:"specials"`   // Exactly two
+	Dessert    CardType   `json:"dessert"`
+}
+
+// DeckEntry is one kind of card in a deck definition and how many copies the deck has
+type DeckEntry struct {
+	Type    CardType `json:"type" yaml:"type"`
+	Variant string   `json:"variant,omitempty" yaml:"variant,omitempty"` // Nigiri fish or Onigiri shape
+	Value   int      `json:"value,omitempty" yaml:"value,omitempty"`     // Roll icons
+	Count   int      `json:"count" yaml:"count"`
+}
+
+// DeckDefinition describes the cards of a custom deck played instead of the original Sushi Go! deck
+type DeckDefinition struct {
+	Name  string      `json:"name" yaml:"name"`
+	Cards []DeckEntry `json:"cards" yaml:"cards"`
+}
+
+// CategoryScore is what one scoring category added to a player's score
+type CategoryScore struct {
+	Category string `json:"category"`
+	Points   int    `json:"points"`
+	Cards    int    `json:"cards"`            // Cards of the category counted
+	Sets     int    `json:"sets,omitempty"`   // Completed sets (Tempura pairs, Sashimi triples, ...)
+	Place    int    `json:"place,omitempty"`  // Placement in contested categories (1: most)
+	Detail   string `json:"detail,omitempty"` // Why the category scored what it did
+}
+
+// ScoreBreakdown splits a score into its categories
+type ScoreBreakdown struct {
+	Total      int             `json:"total"`
+	Categories []CategoryScore `json:"categories"`
 }
 
 // Player represents a player in the game
 type Player struct {
-	ID              string `json:"id"`
-	Name            string `json:"name"`
-	Hand            []Card `json:"hand"`
-	Collection      []Card `json:"collection"`
-	PuddingCards    []Card `json:"pudding_cards"`
-	Score           int    `json:"score"`
-	RoundScores     []int  `json:"round_scores"`
-	ChopsticksCount int    `json:"chopsticks_count"`
-	SelectedCard    *int   `json:"selected_card,omitempty"`
-	SecondCard      *int   `json:"second_card,omitempty"` // For chopsticks usage
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Hand            []Card           `json:"hand"`
+	Collection      []Card           `json:"collection"`
+	PuddingCards    []Card           `json:"pudding_cards"` // Desserts, kept across rounds
+	Score           int              `json:"score"`
+	RoundScores     []int            `json:"round_scores"`
+	RoundBreakdowns []ScoreBreakdown `json:"round_breakdowns"`
+	DessertScore    *CategoryScore   `json:"dessert_score,omitempty"` // Set when the game ends
+	ChopsticksCount int              `json:"chopsticks_count"`
+	SelectedCard    *int             `json:"selected_card,omitempty"`
+	SecondCard      *int             `json:"second_card,omitempty"`   // For chopsticks usage
+	SpoonRequest    *CardRequest     `json:"spoon_request,omitempty"` // Card asked for with a Spoon when this pick is revealed
+	CopyCardID      string           `json:"copy_card_id,omitempty"`  // Card a Special Order picked this turn copies
+	FlipCardIDs     []string         `json:"flip_card_ids,omitempty"` // Cards a Takeout Box picked this turn turns face down
+	MenuDraw        []Card           `json:"menu_draw,omitempty"`     // Cards drawn by a revealed Menu, one to be taken
+	IsBot           bool             `json:"is_bot,omitempty"`
+	BotStrategy     string           `json:"bot_strategy,omitempty"` // Strategy name when IsBot is set
+	IsDummy         bool             `json:"is_dummy,omitempty"`     // Dummy hand of the two-player variant, played by the humans
+	Ready           bool             `json:"ready,omitempty"`        // Declared ready in the lobby; bots always are
 }
 
 // Game represents a complete game session
 type Game struct {
-	ID           string     `json:"id"`
-	Players      []*Player  `json:"players"`
-	Deck         []Card     `json:"deck"`
-	CurrentRound int        `json:"current_round"`
-	RoundPhase   RoundPhase `json:"round_phase"`
-	CreatedAt    time.Time  `json:"created_at"`
-	NumRounds    int        `json:"num_rounds"`     // Number of rounds (default: 3)
-	CardsPerHand int        `json:"cards_per_hand"` // Cards dealt per hand (default: 10)
+	ID             string          `json:"id"`
+	Players        []*Player       `json:"players"`
+	HostID         string          `json:"host_id"` // Player allowed to start, kick, add bots and delete
+	Deck           []Card          `json:"deck"`    // Undealt cards, carried over between rounds
+	CurrentRound   int             `json:"current_round"`
+	Turn           int             `json:"turn"` // Turns revealed so far this round
+	RoundPhase     RoundPhase      `json:"round_phase"`
+	Menu           *Menu           `json:"menu,omitempty"`            // Sushi Go Party! menu (nil: original Sushi Go! deck)
+	DeckDefinition *DeckDefinition `json:"deck_definition,omitempty"` // Custom deck played instead of the original one
+	MinPlayers     int             `json:"min_players"`               // Players needed to start
+	MaxPlayers     int             `json:"max_players"`               // Seats at the table
+	CreatedAt      time.Time       `json:"created_at"`
+	NumRounds      int             `json:"num_rounds"`     // Number of rounds (default: 3)
+	CardsPerHand   int             `json:"cards_per_hand"` // Cards dealt per hand (set at start in rules mode)
+	HandSizeMode   HandSizeMode    `json:"hand_size_mode"`
+	TieMode        TieMode         `json:"tie_mode,omitempty"`      // How tied Maki and Pudding points are shared (empty: TieFull)
+	PassPolicy     PassPolicy      `json:"pass_policy"`             // Who receives each hand (empty direction: PassLeft)
+	Dummy          bool            `json:"dummy,omitempty"`         // Two-player variant with a dummy third hand
+	ReadyCheck     ReadyCheck      `json:"ready_check,omitempty"`   // Whether players must be ready to start (empty: ReadyCheckOff)
+	Private        bool            `json:"private,omitempty"`       // Hidden from the games list; joining takes the invite code
+	InviteSalt     []byte          `json:"invite_salt,omitempty"`   // Random salt the invite code is hashed with
+	InviteHash     []byte          `json:"invite_hash,omitempty"`   // Salted hash of the code or password for joining a private game
+	InviteCode     string          `json:"-"`                       // The code itself, only on the game returned to its creator
+	Seed           int64           `json:"seed"`                    // Seed for all shuffles in this game
+	TurnTimeout    time.Duration   `json:"turn_timeout"`            // Time allowed per pick (0: no timer)
+	TurnDeadline   *time.Time      `json:"turn_deadline,omitempty"` // When the current pick times out
 }
 
 // GameState represents the state visible to clients
@@ -80,3 +217,65 @@ type PlayerState struct {
 	Score       int    `json:"score"`
 	HasSelected bool   `json:"has_selected"`
 }
+
+// Clone returns a deep copy of the game that shares no state with it
+func (g *Game) Clone() *Game {
+	clone := *g
+	clone.Deck = cloneSlice(g.Deck)
+	clone.PassPolicy.Permutation = cloneSlice(g.PassPolicy.Permutation)
+	if g.Menu != nil {
+		menu := *g.Menu
+		menu.Appetizers = cloneSlice(g.Menu.Appetizers)
+		menu.Specials = cloneSlice(g.Menu.Specials)
+		clone.Menu = &menu
+	}
+	if g.DeckDefinition != nil {
+		deck := *g.DeckDefinition
+		deck.Cards = cloneSlice(g.DeckDefinition.Cards)
+		clone.DeckDefinition = &deck
+	}
+	clone.TurnDeadline = clonePointer(g.TurnDeadline)
+	if g.Players != nil {
+		clone.Players = make([]*Player, len(g.Players))
+		for i, player := range g.Players {
+			clone.Players[i] = player.Clone()
+		}
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the player that shares no state with it
+func (p *Player) Clone() *Player {
+	clone := *p
+	clone.Hand = cloneSlice(p.Hand)
+	clone.Collection = cloneSlice(p.Collection)
+	clone.PuddingCards = cloneSlice(p.PuddingCards)
+	clone.RoundScores = cloneSlice(p.RoundScores)
+	clone.RoundBreakdowns = cloneSlice(p.RoundBreakdowns)
+	for i := range clone.RoundBreakdowns {
+		clone.RoundBreakdowns[i].Categories = cloneSlice(p.RoundBreakdowns[i].Categories)
+	}
+	clone.DessertScore = clonePointer(p.DessertScore)
+	clone.SelectedCard = clonePointer(p.SelectedCard)
+	clone.SecondCard = clonePointer(p.SecondCard)
+	clone.SpoonRequest = clonePointer(p.SpoonRequest)
+	clone.FlipCardIDs = cloneSlice(p.FlipCardIDs)
+	clone.MenuDraw = cloneSlice(p.MenuDraw)
+	return &clone
+}
+
+// cloneSlice copies a slice, keeping nil and empty apart so a clone serializes like the original
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(values)), values...)
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
@@ -6,21 +6,32 @@ import "encoding/json"
 type MessageType string
 
 const (
-	MsgTypeJoinGame     MessageType = "join_game"
-	MsgTypeStartGame    MessageType = "start_game"
-	MsgTypeSelectCard   MessageType = "select_card"
-	MsgTypeWithdrawCard MessageType = "withdraw_card"
-	MsgTypeKickPlayer   MessageType = "kick_player"
-	MsgTypeLeaveGame    MessageType = "leave_game"
-	MsgTypeListGames    MessageType = "list_games"
-	MsgTypeDeleteGame   MessageType = "delete_game"
-	MsgTypeGameDeleted  MessageType = "game_deleted"
-	MsgTypePlayerKicked MessageType = "player_kicked"
-	MsgTypeGameState    MessageType = "game_state"
-	MsgTypeCardRevealed MessageType = "card_revealed"
-	MsgTypeRoundEnd     MessageType = "round_end"
-	MsgTypeGameEnd      MessageType = "game_end"
-	MsgTypeError        MessageType = "error"
+	MsgTypeJoinGame          MessageType = "join_game"
+	MsgTypeStartGame         MessageType = "start_game"
+	MsgTypeSetReady          MessageType = "set_ready"
+	MsgTypeSelectCard        MessageType = "select_card"
+	MsgTypeWithdrawCard      MessageType = "withdraw_card"
+	MsgTypePlayDummyCard     MessageType = "play_dummy_card"
+	MsgTypeWithdrawDummyCard MessageType = "withdraw_dummy_card"
+	MsgTypePickMenuCard      MessageType = "pick_menu_card"
+	MsgTypeKickPlayer        MessageType = "kick_player"
+	MsgTypeLeaveGame         MessageType = "leave_game"
+	MsgTypeListGames         MessageType = "list_games"
+	MsgTypeDeleteGame        MessageType = "delete_game"
+	MsgTypeAddBot            MessageType = "add_bot"
+	MsgTypeRequestUndo       MessageType = "request_undo"
+	MsgTypeVoteUndo          MessageType = "vote_undo"
+	MsgTypeSpectateGame      MessageType = "spectate_game"
+	MsgTypeFindMatch         MessageType = "find_match"
+	MsgTypeCancelMatch       MessageType = "cancel_match"
+	MsgTypeMatchStatus       MessageType = "match_status"
+	MsgTypeGameDeleted       MessageType = "game_deleted"
+	MsgTypePlayerKicked      MessageType = "player_kicked"
+	MsgTypeGameState         MessageType = "game_state"
+	MsgTypeCardRevealed      MessageType = "card_revealed"
+	MsgTypeRoundEnd          MessageType = "round_end"
+	MsgTypeGameEnd           MessageType = "game_end"
+	MsgTypeError             MessageType = "error"
 )
 
 // Message represents a WebSocket message
@@ -30,8 +41,37 @@ type Message struct {
 }
 
 // SelectCardPayload represents the payload for card selection
+// The Spoon, Special Order and Takeout Box choices take effect when the pick is revealed
 type SelectCardPayload struct {
-	CardIndex       int  `json:"cardIndex"`
-	UseChopsticks   bool `json:"useChopsticks"`
-	SecondCardIndex *int `json:"secondCardIndex,omitempty"`
+	CardIndex       int          `json:"cardIndex"`
+	UseChopsticks   bool         `json:"useChopsticks"`
+	SecondCardIndex *int         `json:"secondCardIndex,omitempty"`
+	Spoon           *CardRequest `json:"spoon,omitempty"`       // Card to ask for with a Spoon from the collection
+	CopyCardID      string       `json:"copyCardId,omitempty"`  // Collected card a picked Special Order copies
+	FlipCardIDs     []string     `json:"flipCardIds,omitempty"` // Collected cards a picked Takeout Box turns face down
+}
+
+// SetReadyPayload represents the payload for declaring readiness in the lobby
+type SetReadyPayload struct {
+	Ready bool `json:"ready"`
+}
+
+// FindMatchPayload represents the payload for joining the matchmaking queue
+type FindMatchPayload struct {
+	PlayerName string     `json:"playerName,omitempty"`
+	Players    int        `json:"players"` // Table size to be matched into
+	Rules      MatchRules `json:"rules"`
+}
+
+// MatchRules is the rule set a matched game is played with
+// Only clients asking for the same table size and rules are matched; empty fields use the server's rules
+type MatchRules struct {
+	Menu          *Menu         `json:"menu,omitempty"`
+	TieMode       TieMode       `json:"tieMode,omitempty"`
+	PassDirection PassDirection `json:"passDirection,omitempty"`
+}
+
+// VoteUndoPayload represents the payload for a vote on taking back the last reveal
+type VoteUndoPayload struct {
+	Agree bool `json:"agree"`
 }
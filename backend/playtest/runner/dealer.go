@@ -2,6 +2,7 @@ package runner
 
 import (
 	"fmt"
+	"math/rand"
 
 	"github.com/sushi-go-game/backend/models"
 )
@@ -20,7 +21,11 @@ func NewPlaytestDealer(deals map[int][][]models.Card) *PlaytestDealer {
 
 // DealCards deals cards according to the predefined script
 // Players are dealt cards in the order they appear in the players slice
-func (d *PlaytestDealer) DealCards(players []*models.Player, round int, cardsPerHand int) error {
+// The random source is ignored since deals are fully scripted
+func (d *PlaytestDealer) DealCards(game *models.Game, cardsPerHand int, r *rand.Rand) error {
+	players := game.Players
+	round := game.CurrentRound
+
 	roundDeals, ok := d.deals[round]
 	if !ok {
 		return fmt.Errorf("no card deals defined for round %d", round)
@@ -0,0 +1,226 @@
+package scoring
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// Score categories reported in breakdowns
+const (
+	CategoryMaki             = "maki"
+	CategoryTempura          = "tempura"
+	CategorySashimi          = "sashimi"
+	CategoryDumpling         = "dumpling"
+	CategoryNigiri           = "nigiri" // Includes the Wasabi bonus
+	CategoryPudding          = "pudding"
+	CategoryTemaki           = "temaki"
+	CategoryUramaki          = "uramaki"
+	CategoryOnigiri          = "onigiri"
+	CategoryEdamame          = "edamame"
+	CategoryEel              = "eel"
+	CategoryTofu             = "tofu"
+	CategoryMisoSoup         = "miso_soup"
+	CategorySoySauce         = "soy_sauce"
+	CategoryTea              = "tea"
+	CategoryTakeoutBox       = "takeout_box" // Cards turned face down
+	CategoryGreenTeaIceCream = "green_tea_ice_cream"
+	CategoryFruit            = "fruit"
+)
+
+// ScorePlayerRoundBreakdown scores a player's round category by category with the default rules
+// Categories the player has no cards in and no points from are left out
+func ScorePlayerRoundBreakdown(player *models.Player, allPlayers []*models.Player) models.ScoreBreakdown {
+	return DefaultRegistry.ScoreRound(player, allPlayers)
+}
+
+// ScoreDessert scores a dessert for every player at the end of the game with the default rules
+func ScoreDessert(dessert models.CardType, players []*models.Player) map[string]models.CategoryScore {
+	return DefaultRegistry.ScoreDessert(dessert, players)
+}
+
+// SumBreakdowns adds breakdowns together category by category, in order of first appearance
+// Places and details only describe a single round, so they are dropped
+func SumBreakdowns(breakdowns ...models.ScoreBreakdown) models.ScoreBreakdown {
+	sum := models.ScoreBreakdown{Categories: []models.CategoryScore{}}
+	index := make(map[string]int)
+	for _, breakdown := range breakdowns {
+		sum.Total += breakdown.Total
+		for _, category := range breakdown.Categories {
+			i, exists := index[category.Category]
+			if !exists {
+				i = len(sum.Categories)
+				index[category.Category] = i
+				sum.Categories = append(sum.Categories, models.CategoryScore{Category: category.Category})
+			}
+			sum.Categories[i].Points += category.Points
+			sum.Categories[i].Cards += category.Cards
+			sum.Categories[i].Sets += category.Sets
+		}
+	}
+	return sum
+}
+
+// plural formats a count with a singular or plural noun
+func plural(count int, singular, pluralForm string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, pluralForm)
+}
+
+// ordinal formats a place as 1st, 2nd, 3rd...
+func ordinal(place int) string {
+	switch place {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", place)
+	}
+}
+
+// describeMaki reports Maki icons and placement
+func describeMaki(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	if category.Cards == 0 {
+		return
+	}
+
+	icons := 0
+	for _, card := range player.Collection {
+		if card.Type == models.CardTypeMakiRoll {
+			icons += card.Value
+		}
+	}
+
+	category.Detail = plural(icons, "icon", "icons")
+	if category.Points > 0 {
+		category.Place = makiPlaces(players)[player.ID]
+		category.Detail += ", " + ordinal(category.Place) + " place"
+	}
+}
+
+// describeTempura reports completed Tempura pairs
+func describeTempura(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	category.Sets = category.Cards / 2
+	category.Detail = plural(category.Sets, "pair", "pairs")
+}
+
+// describeSashimi reports completed Sashimi sets
+func describeSashimi(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	category.Sets = category.Cards / 3
+	category.Detail = plural(category.Sets, "set of three", "sets of three")
+}
+
+// describeDumplings reports the Dumpling count
+func describeDumplings(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	category.Detail = plural(category.Cards, "dumpling", "dumplings")
+}
+
+// describeNigiri lists each Nigiri with its Wasabi multiplier, following ScoreNigiri
+func describeNigiri(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	parts := []string{}
+	wasabiCount := 0
+	for _, card := range player.Collection {
+		if card.Type == models.CardTypeWasabi {
+			wasabiCount++
+		} else if card.Type == models.CardTypeNigiri {
+			if wasabiCount > 0 {
+				parts = append(parts, card.Variant+" on Wasabi ×3")
+				wasabiCount--
+			} else {
+				parts = append(parts, card.Variant)
+			}
+		}
+	}
+	if wasabiCount > 0 {
+		parts = append(parts, plural(wasabiCount, "unused Wasabi", "unused Wasabi"))
+	}
+	category.Detail = strings.Join(parts, ", ")
+}
+
+// describeTemaki reports whether the player had the most or fewest Temaki
+func describeTemaki(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	switch {
+	case category.Points > 0:
+		category.Place = 1
+		category.Detail = "most Temaki"
+	case category.Points < 0:
+		category.Detail = "fewest Temaki"
+	}
+}
+
+// describeUramaki reports Uramaki icons and which award the player took
+func describeUramaki(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	icons := 0
+	for _, card := range player.Collection {
+		if card.Type == models.CardTypeUramaki {
+			icons += card.Value
+		}
+	}
+
+	category.Detail = plural(icons, "icon", "icons")
+	for i, award := range uramakiAwards {
+		if category.Points == award {
+			category.Place = i + 1
+			category.Detail += ", " + ordinal(category.Place) + " place"
+		}
+	}
+}
+
+// describeOnigiri reports the sets of different shapes
+func describeOnigiri(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	shapes := make(map[string]int)
+	for _, card := range player.Collection {
+		if card.Type == models.CardTypeOnigiri {
+			shapes[card.Variant]++
+			if shapes[card.Variant] > category.Sets {
+				category.Sets = shapes[card.Variant]
+			}
+		}
+	}
+	category.Detail = plural(len(shapes), "shape", "shapes")
+}
+
+// describeEdamame reports what each Edamame was worth
+func describeEdamame(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	if category.Cards > 0 {
+		category.Detail = fmt.Sprintf("%d point(s) each", category.Points/category.Cards)
+	}
+}
+
+// describeSoySauce reports whether the player had the most card types
+func describeSoySauce(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	if category.Points > 0 {
+		category.Place = 1
+		category.Detail = "most card types"
+	}
+}
+
+// describeTea reports the largest group the Tea scored
+func describeTea(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	if category.Cards > 0 {
+		category.Detail = fmt.Sprintf("largest group of %d", category.Points/category.Cards)
+	}
+}
+
+// describePudding reports whether the player had the most or fewest Pudding
+func describePudding(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	switch {
+	case category.Points > 0:
+		category.Place = 1
+		category.Detail = "most Pudding"
+	case category.Points < 0:
+		category.Detail = "fewest Pudding"
+	}
+}
+
+// describeGreenTeaIceCream reports completed sets of four
+func describeGreenTeaIceCream(category *models.CategoryScore, player *models.Player, players []*models.Player) {
+	category.Sets = category.Cards / 4
+	category.Detail = plural(category.Sets, "set of four", "sets of four")
+}
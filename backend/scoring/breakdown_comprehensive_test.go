@@ -0,0 +1,134 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// findCategory returns the named category of a breakdown
+func findCategory(t *testing.T, breakdown models.ScoreBreakdown, name string) models.CategoryScore {
+	t.Helper()
+	for _, category := range breakdown.Categories {
+		if category.Category == name {
+			return category
+		}
+	}
+	t.Fatalf("Expected a %s category in %+v", name, breakdown.Categories)
+	return models.CategoryScore{}
+}
+
+// TestScorePlayerRoundBreakdown_Categories tests each category of a classic round
+func TestScorePlayerRoundBreakdown_Categories(t *testing.T) {
+	p1 := &models.Player{ID: "p1", Collection: []models.Card{
+		{Type: models.CardTypeMakiRoll, Value: 3},
+		{Type: models.CardTypeTempura},
+		{Type: models.CardTypeTempura},
+		{Type: models.CardTypeTempura},
+		{Type: models.CardTypeSashimi},
+		{Type: models.CardTypeDumpling},
+		{Type: models.CardTypeDumpling},
+		{Type: models.CardTypeWasabi},
+		{Type: models.CardTypeNigiri, Variant: "Squid", Value: 3},
+		{Type: models.CardTypeNigiri, Variant: "Egg", Value: 1},
+		{Type: models.CardTypeWasabi},
+	}}
+	p2 := &models.Player{ID: "p2", Collection: []models.Card{
+		{Type: models.CardTypeMakiRoll, Value: 1},
+	}}
+	players := []*models.Player{p1, p2}
+
+	breakdown := ScorePlayerRoundBreakdown(p1, players)
+
+	maki := findCategory(t, breakdown, CategoryMaki)
+	if maki.Points != 6 || maki.Place != 1 || maki.Detail != "3 icons, 1st place" {
+		t.Errorf("Unexpected maki category %+v", maki)
+	}
+	tempura := findCategory(t, breakdown, CategoryTempura)
+	if tempura.Points != 5 || tempura.Cards != 3 || tempura.Sets != 1 {
+		t.Errorf("Unexpected tempura category %+v", tempura)
+	}
+	sashimi := findCategory(t, breakdown, CategorySashimi)
+	if sashimi.Points != 0 || sashimi.Cards != 1 || sashimi.Sets != 0 {
+		t.Errorf("Unexpected sashimi category %+v", sashimi)
+	}
+	dumpling := findCategory(t, breakdown, CategoryDumpling)
+	if dumpling.Points != 3 || dumpling.Cards != 2 {
+		t.Errorf("Unexpected dumpling category %+v", dumpling)
+	}
+	nigiri := findCategory(t, breakdown, CategoryNigiri)
+	if nigiri.Points != 10 || nigiri.Cards != 2 {
+		t.Errorf("Unexpected nigiri category %+v", nigiri)
+	}
+	if nigiri.Detail != "Squid on Wasabi ×3, Egg, 1 unused Wasabi" {
+		t.Errorf("Unexpected nigiri detail %q", nigiri.Detail)
+	}
+
+	if breakdown.Total != 24 || breakdown.Total != ScorePlayerRound(p1, players) {
+		t.Errorf("Expected a total of 24 matching ScorePlayerRound, got %d", breakdown.Total)
+	}
+
+	second := findCategory(t, ScorePlayerRoundBreakdown(p2, players), CategoryMaki)
+	if second.Points != 3 || second.Place != 2 {
+		t.Errorf("Unexpected second place maki category %+v", second)
+	}
+}
+
+// TestScorePlayerRoundBreakdown_SkipsEmptyCategories tests that unplayed categories are left out
+func TestScorePlayerRoundBreakdown_SkipsEmptyCategories(t *testing.T) {
+	player := &models.Player{ID: "p1", Collection: []models.Card{{Type: models.CardTypeChopsticks}}}
+
+	breakdown := ScorePlayerRoundBreakdown(player, []*models.Player{player})
+	if breakdown.Total != 0 || len(breakdown.Categories) != 0 {
+		t.Errorf("Expected an empty breakdown, got %+v", breakdown)
+	}
+}
+
+// TestScorePlayerRoundBreakdown_PartyCategories tests that table-wide Party scores appear, penalties included
+func TestScorePlayerRoundBreakdown_PartyCategories(t *testing.T) {
+	p1 := &models.Player{ID: "p1", Collection: append(cardsOf(models.CardTypeTemaki, 2), cardsOf(models.CardTypeEel, 2)...)}
+	p2 := &models.Player{ID: "p2", Collection: cardsOf(models.CardTypeTemaki, 1)}
+	p3 := &models.Player{ID: "p3", Collection: cardsOf(models.CardTypeTofu, 1)}
+	players := []*models.Player{p1, p2, p3}
+
+	breakdown := ScorePlayerRoundBreakdown(p1, players)
+	if temaki := findCategory(t, breakdown, CategoryTemaki); temaki.Points != 4 || temaki.Place != 1 {
+		t.Errorf("Unexpected temaki category %+v", temaki)
+	}
+	if eel := findCategory(t, breakdown, CategoryEel); eel.Points != 7 {
+		t.Errorf("Unexpected eel category %+v", eel)
+	}
+
+	// p3 has no Temaki but still loses points for it
+	breakdown = ScorePlayerRoundBreakdown(p3, players)
+	if temaki := findCategory(t, breakdown, CategoryTemaki); temaki.Points != -4 || temaki.Cards != 0 {
+		t.Errorf("Unexpected temaki penalty %+v", temaki)
+	}
+	if breakdown.Total != ScorePlayerRound(p3, players) {
+		t.Errorf("Expected the total to match ScorePlayerRound, got %d", breakdown.Total)
+	}
+}
+
+// TestSumBreakdowns tests that categories are merged across rounds
+func TestSumBreakdowns(t *testing.T) {
+	first := models.ScoreBreakdown{Total: 11, Categories: []models.CategoryScore{
+		{Category: CategoryMaki, Points: 6, Cards: 2, Place: 1, Detail: "4 icons, 1st place"},
+		{Category: CategoryTempura, Points: 5, Cards: 2, Sets: 1},
+	}}
+	second := models.ScoreBreakdown{Total: 8, Categories: []models.CategoryScore{
+		{Category: CategoryTempura, Points: 5, Cards: 3, Sets: 1},
+		{Category: CategoryPudding, Points: 3, Cards: 2},
+	}}
+
+	sum := SumBreakdowns(first, second)
+	if sum.Total != 19 || len(sum.Categories) != 3 {
+		t.Fatalf("Expected 3 categories totalling 19, got %+v", sum)
+	}
+	tempura := sum.Categories[1]
+	if tempura.Category != CategoryTempura || tempura.Points != 10 || tempura.Cards != 5 || tempura.Sets != 2 {
+		t.Errorf("Unexpected summed tempura %+v", tempura)
+	}
+	if sum.Categories[0].Place != 0 || sum.Categories[0].Detail != "" {
+		t.Errorf("Expected places and details to be dropped, got %+v", sum.Categories[0])
+	}
+}
@@ -0,0 +1,333 @@
+package scoring
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// Onigiri shapes; a set scores more the more different shapes it has
+var OnigiriShapes = []string{"Circle", "Triangle", "Square", "Rectangle"}
+
+// Fruits shown on Fruit cards; each card's Variant names two, joined by "+"
+var Fruits = []string{"Watermelon", "Pineapple", "Orange"}
+
+// countType returns how many cards of a type are in cards
+func countType(cards []models.Card, cardType models.CardType) int {
+	count := 0
+	for _, card := range cards {
+		if card.Type == cardType {
+			count++
+		}
+	}
+	return count
+}
+
+// ScoreTemaki calculates Temaki scores for all players
+// Most Temaki: 4 points, fewest: -4 points (no penalty in a 2-player game)
+// Nobody scores when everyone has the same number
+func ScoreTemaki(players []*models.Player) map[string]int {
+	scores := make(map[string]int)
+	if len(players) == 0 {
+		return scores
+	}
+
+	counts := make(map[string]int)
+	maxCount, minCount := -1, -1
+	for _, player := range players {
+		count := countType(player.Collection, models.CardTypeTemaki)
+		counts[player.ID] = count
+		if maxCount < 0 || count > maxCount {
+			maxCount = count
+		}
+		if minCount < 0 || count < minCount {
+			minCount = count
+		}
+	}
+
+	if maxCount == minCount {
+		return scores
+	}
+
+	for _, player := range players {
+		switch counts[player.ID] {
+		case maxCount:
+			scores[player.ID] = 4
+		case minCount:
+			if len(players) > 2 {
+				scores[player.ID] = -4
+			}
+		}
+	}
+	return scores
+}
+
+// uramakiAwards are the points for the first, second and third player to reach 10 icons
+var uramakiAwards = []int{8, 5, 2}
+
+// uramakiCount is a player's Uramaki icon total
+type uramakiCount struct {
+	playerID string
+	icons    int
+}
+
+// ScoreUramaki calculates Uramaki scores for all players
+// The first player to reach 10 icons scores 8, the next 5, then 2. The round is walked
+// turn by turn using each card's Turn; awards still open at the end of the round go to
+// the players with the most icons. Cards without a Turn only count at the end of the round
+func ScoreUramaki(players []*models.Player) map[string]int {
+	scores := make(map[string]int)
+	awards := uramakiAwards
+
+	lastTurn := 0
+	for _, player := range players {
+		for _, card := range player.Collection {
+			if card.Type == models.CardTypeUramaki && card.Turn > lastTurn {
+				lastTurn = card.Turn
+			}
+		}
+	}
+
+	running := make(map[string]int)
+	for turn := 1; turn <= lastTurn && len(awards) > 0; turn++ {
+		reached := []uramakiCount{}
+		for _, player := range players {
+			if _, scored := scores[player.ID]; scored {
+				continue
+			}
+			for _, card := range player.Collection {
+				if card.Type == models.CardTypeUramaki && card.Turn == turn {
+					running[player.ID] += card.Value
+				}
+			}
+			if running[player.ID] >= 10 {
+				reached = append(reached, uramakiCount{player.ID, running[player.ID]})
+			}
+		}
+		awards = awardUramaki(reached, awards, scores)
+	}
+
+	// At the end of the round the remaining awards go by icon count
+	remaining := []uramakiCount{}
+	for _, player := range players {
+		if _, scored := scores[player.ID]; scored {
+			continue
+		}
+		icons := 0
+		for _, card := range player.Collection {
+			if card.Type == models.CardTypeUramaki {
+				icons += card.Value
+			}
+		}
+		if icons > 0 {
+			remaining = append(remaining, uramakiCount{player.ID, icons})
+		}
+	}
+	awardUramaki(remaining, awards, scores)
+
+	return scores
+}
+
+// awardUramaki hands out awards in order of icons, most first
+// Tied players all take the same award and use up one award each
+// Returns the awards still open
+func awardUramaki(counts []uramakiCount, awards []int, scores map[string]int) []int {
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].icons > counts[j].icons
+	})
+
+	for i := 0; i < len(counts) && len(awards) > 0; {
+		tied := 1
+		for i+tied < len(counts) && counts[i+tied].icons == counts[i].icons {
+			tied++
+		}
+		for _, count := range counts[i : i+tied] {
+			scores[count.playerID] = awards[0]
+		}
+		if tied >= len(awards) {
+			awards = nil
+		} else {
+			awards = awards[tied:]
+		}
+		i += tied
+	}
+	return awards
+}
+
+// ScoreOnigiri calculates the score for Onigiri cards
+// Cards are grouped into sets of different shapes: 1=1, 2=4, 3=9, 4=16 points per set
+func ScoreOnigiri(cards []models.Card) int {
+	shapes := make(map[string]int)
+	for _, card := range cards {
+		if card.Type == models.CardTypeOnigiri {
+			shapes[card.Variant]++
+		}
+	}
+
+	score := 0
+	for {
+		setSize := 0
+		for shape, count := range shapes {
+			if count > 0 {
+				setSize++
+				shapes[shape]--
+			}
+		}
+		if setSize == 0 {
+			return score
+		}
+		score += setSize * setSize
+	}
+}
+
+// ScoreEdamame calculates Edamame scores for all players
+// Each Edamame scores 1 point per opponent with at least one Edamame, up to 4
+func ScoreEdamame(players []*models.Player) map[string]int {
+	scores := make(map[string]int)
+
+	counts := make(map[string]int)
+	withEdamame := 0
+	for _, player := range players {
+		counts[player.ID] = countType(player.Collection, models.CardTypeEdamame)
+		if counts[player.ID] > 0 {
+			withEdamame++
+		}
+	}
+
+	for _, player := range players {
+		if counts[player.ID] == 0 {
+			continue
+		}
+		opponents := withEdamame - 1
+		if opponents > 4 {
+			opponents = 4
+		}
+		scores[player.ID] = counts[player.ID] * opponents
+	}
+	return scores
+}
+
+// ScoreEel calculates the score for Eel cards
+// 1 Eel = -3 points, 2 or more = 7 points
+func ScoreEel(cards []models.Card) int {
+	switch count := countType(cards, models.CardTypeEel); {
+	case count >= 2:
+		return 7
+	case count == 1:
+		return -3
+	default:
+		return 0
+	}
+}
+
+// ScoreTofu calculates the score for Tofu cards
+// 1 Tofu = 2 points, 2 = 6 points, 3 or more = 0 points
+func ScoreTofu(cards []models.Card) int {
+	switch countType(cards, models.CardTypeTofu) {
+	case 1:
+		return 2
+	case 2:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// ScoreMisoSoup calculates the score for Miso Soup cards
+// Each Miso Soup is worth 3 points; the engine discards Miso Soups that clash on the same turn
+func ScoreMisoSoup(cards []models.Card) int {
+	return countType(cards, models.CardTypeMisoSoup) * 3
+}
+
+// ScoreSoySauce calculates Soy Sauce scores for all players
+// Each Soy Sauce is worth 4 points if its owner has the most card types in their collection
+// Tied players all score; face-down cards have no type
+func ScoreSoySauce(players []*models.Player) map[string]int {
+	scores := make(map[string]int)
+
+	colors := make(map[string]int)
+	maxColors := 0
+	for _, player := range players {
+		types := make(map[models.CardType]bool)
+		for _, card := range player.Collection {
+			if card.Type != models.CardTypeFaceDown {
+				types[card.Type] = true
+			}
+		}
+		colors[player.ID] = len(types)
+		if len(types) > maxColors {
+			maxColors = len(types)
+		}
+	}
+
+	for _, player := range players {
+		soySauce := countType(player.Collection, models.CardTypeSoySauce)
+		if soySauce > 0 && colors[player.ID] == maxColors {
+			scores[player.ID] = soySauce * 4
+		}
+	}
+	return scores
+}
+
+// ScoreTea calculates the score for Tea cards
+// Each Tea is worth 1 point per card in the largest group of one type in the collection;
+// face-down cards have no type, so they are no group
+func ScoreTea(cards []models.Card) int {
+	tea := countType(cards, models.CardTypeTea)
+	if tea == 0 {
+		return 0
+	}
+
+	groups := make(map[models.CardType]int)
+	largest := 0
+	for _, card := range cards {
+		if card.Type == models.CardTypeFaceDown {
+			continue
+		}
+		groups[card.Type]++
+		if groups[card.Type] > largest {
+			largest = groups[card.Type]
+		}
+	}
+	return tea * largest
+}
+
+// ScoreFaceDown calculates the score for cards turned face down by a Takeout Box
+// Each is worth 2 points, whatever it was before
+func ScoreFaceDown(cards []models.Card) int {
+	return countType(cards, models.CardTypeFaceDown) * 2
+}
+
+// ScoreGreenTeaIceCream calculates the end-of-game score for Green Tea Ice Cream
+// Every complete set of 4 is worth 12 points
+func ScoreGreenTeaIceCream(cards []models.Card) int {
+	return (countType(cards, models.CardTypeGreenTeaIceCream) / 4) * 12
+}
+
+// fruitScores is the score for 0 through 5+ of one fruit
+var fruitScores = []int{-2, 0, 1, 3, 6, 10}
+
+// ScoreFruit calculates the end-of-game score for Fruit cards
+// Each fruit scores separately: 0=-2, 1=0, 2=1, 3=3, 4=6, 5+=10 points
+func ScoreFruit(cards []models.Card) int {
+	counts := make(map[string]int)
+	for _, card := range cards {
+		if card.Type != models.CardTypeFruit {
+			continue
+		}
+		for _, fruit := range strings.Split(card.Variant, "+") {
+			counts[fruit]++
+		}
+	}
+
+	score := 0
+	for _, fruit := range Fruits {
+		count := counts[fruit]
+		if count >= len(fruitScores) {
+			count = len(fruitScores) - 1
+		}
+		score += fruitScores[count]
+	}
+	return score
+}
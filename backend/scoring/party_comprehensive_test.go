@@ -0,0 +1,354 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// cardsOf returns count cards of one type
+func cardsOf(cardType models.CardType, count int) []models.Card {
+	cards := make([]models.Card, count)
+	for i := range cards {
+		cards[i] = models.Card{Type: cardType}
+	}
+	return cards
+}
+
+// TestScoreTemaki_MostAndFewest tests the Temaki bonus and penalty
+func TestScoreTemaki_MostAndFewest(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: cardsOf(models.CardTypeTemaki, 3)},
+		{ID: "p2", Collection: cardsOf(models.CardTypeTemaki, 3)},
+		{ID: "p3", Collection: cardsOf(models.CardTypeTemaki, 1)},
+		{ID: "p4", Collection: cardsOf(models.CardTypeTemaki, 0)},
+	}
+
+	scores := ScoreTemaki(players)
+
+	expected := map[string]int{"p1": 4, "p2": 4, "p3": 0, "p4": -4}
+	for id, want := range expected {
+		if scores[id] != want {
+			t.Errorf("Expected %s to score %d, got %d", id, want, scores[id])
+		}
+	}
+}
+
+// TestScoreTemaki_TwoPlayers tests that nobody loses points in a 2-player game
+func TestScoreTemaki_TwoPlayers(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: cardsOf(models.CardTypeTemaki, 2)},
+		{ID: "p2", Collection: cardsOf(models.CardTypeTemaki, 0)},
+	}
+
+	scores := ScoreTemaki(players)
+
+	if scores["p1"] != 4 {
+		t.Errorf("Expected p1 to score 4, got %d", scores["p1"])
+	}
+	if scores["p2"] != 0 {
+		t.Errorf("Expected p2 to score 0 in a 2-player game, got %d", scores["p2"])
+	}
+}
+
+// TestScoreTemaki_AllTied tests that nobody scores when everyone has the same Temaki
+func TestScoreTemaki_AllTied(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: cardsOf(models.CardTypeTemaki, 2)},
+		{ID: "p2", Collection: cardsOf(models.CardTypeTemaki, 2)},
+		{ID: "p3", Collection: cardsOf(models.CardTypeTemaki, 2)},
+	}
+
+	for id, score := range ScoreTemaki(players) {
+		if score != 0 {
+			t.Errorf("Expected %s to score 0, got %d", id, score)
+		}
+	}
+}
+
+// uramaki returns an Uramaki card with icons played on turn
+func uramaki(icons, turn int) models.Card {
+	return models.Card{Type: models.CardTypeUramaki, Value: icons, Turn: turn}
+}
+
+// TestScoreUramaki_RaceToTen tests that awards go in the order players reach 10 icons
+func TestScoreUramaki_RaceToTen(t *testing.T) {
+	players := []*models.Player{
+		// Reaches 10 on turn 3
+		{ID: "p1", Collection: []models.Card{uramaki(5, 1), uramaki(3, 2), uramaki(3, 3)}},
+		// Reaches 10 on turn 2, first
+		{ID: "p2", Collection: []models.Card{uramaki(5, 1), uramaki(5, 2)}},
+		// Never reaches 10 but has the most left at the end
+		{ID: "p3", Collection: []models.Card{uramaki(4, 1), uramaki(4, 2)}},
+		{ID: "p4", Collection: []models.Card{uramaki(3, 1)}},
+	}
+
+	scores := ScoreUramaki(players)
+
+	expected := map[string]int{"p1": 5, "p2": 8, "p3": 2, "p4": 0}
+	for id, want := range expected {
+		if scores[id] != want {
+			t.Errorf("Expected %s to score %d, got %d", id, want, scores[id])
+		}
+	}
+}
+
+// TestScoreUramaki_SameTurn tests that the player with more icons wins a same-turn race
+func TestScoreUramaki_SameTurn(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: []models.Card{uramaki(5, 1), uramaki(5, 2)}},
+		{ID: "p2", Collection: []models.Card{uramaki(5, 1), uramaki(4, 1), uramaki(4, 2)}},
+		{ID: "p3", Collection: []models.Card{uramaki(5, 1), uramaki(4, 1), uramaki(4, 2)}},
+	}
+
+	scores := ScoreUramaki(players)
+
+	// p2 and p3 tie on 13 icons and take 8 each, using up the 5 award; p1 gets the 2
+	expected := map[string]int{"p1": 2, "p2": 8, "p3": 8}
+	for id, want := range expected {
+		if scores[id] != want {
+			t.Errorf("Expected %s to score %d, got %d", id, want, scores[id])
+		}
+	}
+}
+
+// TestScoreUramaki_EndOfRound tests awards given by icon count when nobody reaches 10
+func TestScoreUramaki_EndOfRound(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: []models.Card{uramaki(3, 0)}},
+		{ID: "p2", Collection: []models.Card{uramaki(5, 0)}},
+		{ID: "p3", Collection: []models.Card{}},
+	}
+
+	scores := ScoreUramaki(players)
+
+	if scores["p2"] != 8 || scores["p1"] != 5 {
+		t.Errorf("Expected p2=8 and p1=5, got %v", scores)
+	}
+	if _, ok := scores["p3"]; ok {
+		t.Errorf("Expected p3 without Uramaki not to score, got %d", scores["p3"])
+	}
+}
+
+// onigiri returns Onigiri cards of the given shapes
+func onigiri(shapes ...string) []models.Card {
+	cards := make([]models.Card, len(shapes))
+	for i, shape := range shapes {
+		cards[i] = models.Card{Type: models.CardTypeOnigiri, Variant: shape}
+	}
+	return cards
+}
+
+// TestScoreOnigiri tests Onigiri sets of different shapes
+func TestScoreOnigiri(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []models.Card
+		expected int
+	}{
+		{"none", nil, 0},
+		{"one shape", onigiri("Circle"), 1},
+		{"pair of the same shape", onigiri("Circle", "Circle"), 2},
+		{"two shapes", onigiri("Circle", "Square"), 4},
+		{"three shapes", onigiri("Circle", "Square", "Triangle"), 9},
+		{"all four shapes", onigiri("Circle", "Square", "Triangle", "Rectangle"), 16},
+		{"four shapes and a repeat", onigiri("Circle", "Square", "Triangle", "Rectangle", "Circle"), 17},
+		{"two sets of two", onigiri("Circle", "Square", "Circle", "Square"), 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if score := ScoreOnigiri(tt.cards); score != tt.expected {
+				t.Errorf("Expected %d points, got %d", tt.expected, score)
+			}
+		})
+	}
+}
+
+// TestScoreEdamame tests Edamame scoring by opponents with Edamame
+func TestScoreEdamame(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: cardsOf(models.CardTypeEdamame, 2)},
+		{ID: "p2", Collection: cardsOf(models.CardTypeEdamame, 1)},
+		{ID: "p3", Collection: cardsOf(models.CardTypeEdamame, 0)},
+	}
+
+	scores := ScoreEdamame(players)
+
+	if scores["p1"] != 2 || scores["p2"] != 1 || scores["p3"] != 0 {
+		t.Errorf("Expected p1=2, p2=1, p3=0, got %v", scores)
+	}
+
+	// Alone with Edamame scores nothing
+	alone := ScoreEdamame([]*models.Player{
+		{ID: "p1", Collection: cardsOf(models.CardTypeEdamame, 3)},
+		{ID: "p2"},
+	})
+	if alone["p1"] != 0 {
+		t.Errorf("Expected lone Edamame to score 0, got %d", alone["p1"])
+	}
+}
+
+// TestScoreEdamame_Cap tests that Edamame counts at most 4 opponents
+func TestScoreEdamame_Cap(t *testing.T) {
+	players := []*models.Player{}
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		players = append(players, &models.Player{ID: id, Collection: cardsOf(models.CardTypeEdamame, 1)})
+	}
+
+	if score := ScoreEdamame(players)["p1"]; score != 4 {
+		t.Errorf("Expected Edamame capped at 4, got %d", score)
+	}
+}
+
+// TestScoreEel tests the Eel penalty and bonus
+func TestScoreEel(t *testing.T) {
+	for count, expected := range map[int]int{0: 0, 1: -3, 2: 7, 3: 7} {
+		if score := ScoreEel(cardsOf(models.CardTypeEel, count)); score != expected {
+			t.Errorf("Expected %d Eel to score %d, got %d", count, expected, score)
+		}
+	}
+}
+
+// TestScoreTofu tests that too much Tofu scores nothing
+func TestScoreTofu(t *testing.T) {
+	for count, expected := range map[int]int{0: 0, 1: 2, 2: 6, 3: 0, 4: 0} {
+		if score := ScoreTofu(cardsOf(models.CardTypeTofu, count)); score != expected {
+			t.Errorf("Expected %d Tofu to score %d, got %d", count, expected, score)
+		}
+	}
+}
+
+// TestScoreMisoSoup tests that each Miso Soup is worth 3
+func TestScoreMisoSoup(t *testing.T) {
+	if score := ScoreMisoSoup(cardsOf(models.CardTypeMisoSoup, 2)); score != 6 {
+		t.Errorf("Expected 6 points, got %d", score)
+	}
+}
+
+// TestScoreSoySauce tests Soy Sauce scoring for the most card types
+func TestScoreSoySauce(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: []models.Card{
+			{Type: models.CardTypeSoySauce}, {Type: models.CardTypeTempura}, {Type: models.CardTypeEel},
+		}},
+		{ID: "p2", Collection: []models.Card{
+			{Type: models.CardTypeSoySauce}, {Type: models.CardTypeSoySauce}, {Type: models.CardTypeTofu},
+		}},
+		{ID: "p3", Collection: []models.Card{
+			{Type: models.CardTypeTempura}, {Type: models.CardTypeEel}, {Type: models.CardTypeTofu},
+		}},
+	}
+
+	scores := ScoreSoySauce(players)
+
+	if scores["p1"] != 4 {
+		t.Errorf("Expected p1 (tied for most types) to score 4, got %d", scores["p1"])
+	}
+	if scores["p2"] != 0 {
+		t.Errorf("Expected p2 (fewer types) to score 0, got %d", scores["p2"])
+	}
+	if scores["p3"] != 0 {
+		t.Errorf("Expected p3 (no Soy Sauce) to score 0, got %d", scores["p3"])
+	}
+}
+
+// TestScoreTea tests Tea scoring by the largest group of one type
+func TestScoreTea(t *testing.T) {
+	cards := append(cardsOf(models.CardTypeTempura, 3), cardsOf(models.CardTypeEel, 1)...)
+	cards = append(cards, cardsOf(models.CardTypeTea, 2)...)
+
+	// Two Tea, largest group is 3 Tempura
+	if score := ScoreTea(cards); score != 6 {
+		t.Errorf("Expected 6 points, got %d", score)
+	}
+	if score := ScoreTea(cardsOf(models.CardTypeTempura, 3)); score != 0 {
+		t.Errorf("Expected no points without Tea, got %d", score)
+	}
+}
+
+// TestScoreFaceDown tests that cards a Takeout Box turned over score 2 each and count for nothing else
+func TestScoreFaceDown(t *testing.T) {
+	if score := ScoreFaceDown(cardsOf(models.CardTypeFaceDown, 3)); score != 6 {
+		t.Errorf("Expected 6 points, got %d", score)
+	}
+
+	// Three face-down cards are no group for Tea, the two Tempura are
+	cards := append(cardsOf(models.CardTypeFaceDown, 3), cardsOf(models.CardTypeTempura, 2)...)
+	cards = append(cards, cardsOf(models.CardTypeTea, 1)...)
+	if score := ScoreTea(cards); score != 2 {
+		t.Errorf("Expected Tea to ignore face-down cards and score 2, got %d", score)
+	}
+
+	// Face-down cards are no type for Soy Sauce
+	players := []*models.Player{
+		{ID: "p1", Collection: append(cardsOf(models.CardTypeSoySauce, 1), cardsOf(models.CardTypeFaceDown, 2)...)},
+		{ID: "p2", Collection: []models.Card{{Type: models.CardTypeSoySauce}, {Type: models.CardTypeTempura}}},
+	}
+	scores := ScoreSoySauce(players)
+	if scores["p1"] != 0 || scores["p2"] != 4 {
+		t.Errorf("Expected Soy Sauce to ignore face-down cards (p1 0, p2 4), got %v", scores)
+	}
+}
+
+// TestScoreGreenTeaIceCream tests that only complete sets of four score
+func TestScoreGreenTeaIceCream(t *testing.T) {
+	for count, expected := range map[int]int{0: 0, 3: 0, 4: 12, 7: 12, 8: 24} {
+		if score := ScoreGreenTeaIceCream(cardsOf(models.CardTypeGreenTeaIceCream, count)); score != expected {
+			t.Errorf("Expected %d Green Tea Ice Cream to score %d, got %d", count, expected, score)
+		}
+	}
+}
+
+// fruit returns Fruit cards with the given variants
+func fruit(variants ...string) []models.Card {
+	cards := make([]models.Card, len(variants))
+	for i, variant := range variants {
+		cards[i] = models.Card{Type: models.CardTypeFruit, Variant: variant}
+	}
+	return cards
+}
+
+// TestScoreFruit tests that each fruit scores on its own
+func TestScoreFruit(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    []models.Card
+		expected int
+	}{
+		{"no fruit", nil, -6},
+		{"one mixed card", fruit("Watermelon+Pineapple"), -2},
+		{"one double card", fruit("Orange+Orange"), -3},
+		{"three watermelon", fruit("Watermelon+Watermelon", "Watermelon+Orange"), 3 + 0 - 2},
+		{"five or more caps at 10", fruit("Pineapple+Pineapple", "Pineapple+Pineapple", "Pineapple+Pineapple"), -2 + 10 - 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if score := ScoreFruit(tt.cards); score != tt.expected {
+				t.Errorf("Expected %d points, got %d", tt.expected, score)
+			}
+		})
+	}
+}
+
+// TestScorePlayerRound_PartyCards tests that a round total includes the Party cards
+func TestScorePlayerRound_PartyCards(t *testing.T) {
+	p1 := &models.Player{ID: "p1", Collection: []models.Card{
+		{Type: models.CardTypeEel}, {Type: models.CardTypeEel}, // 7
+		{Type: models.CardTypeTofu},     // 2
+		{Type: models.CardTypeMisoSoup}, // 3
+		{Type: models.CardTypeTemaki},   // Most Temaki: 4
+		{Type: models.CardTypeEdamame},  // 1 opponent with Edamame: 1
+	}}
+	p2 := &models.Player{ID: "p2", Collection: []models.Card{
+		{Type: models.CardTypeEdamame}, // 1
+	}}
+
+	if score := ScorePlayerRound(p1, []*models.Player{p1, p2}); score != 17 {
+		t.Errorf("Expected p1 to score 17, got %d", score)
+	}
+	if score := ScorePlayerRound(p2, []*models.Player{p1, p2}); score != 1 {
+		t.Errorf("Expected p2 to score 1, got %d", score)
+	}
+}
@@ -0,0 +1,219 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// ErrInvalidRule is returned when a scoring rule can't be registered
+var ErrInvalidRule = errors.New("invalid scoring rule")
+
+// PlayerScorer scores one player's cards without looking at the rest of the table
+type PlayerScorer func(cards []models.Card) int
+
+// TableScorer scores every player at once, for cards that compare players (Maki, Pudding...)
+// Returns a map of player ID to score; players left out score 0
+type TableScorer func(players []*models.Player) map[string]int
+
+// Describer fills in the sets, place and detail of a category that has been scored
+type Describer func(category *models.CategoryScore, player *models.Player, players []*models.Player)
+
+// Rule scores one card type
+// Exactly one of Player and Table must be set
+type Rule struct {
+	CardType models.CardType
+	Category string       // Breakdown category; defaults to the card type
+	Player   PlayerScorer // Scores the player's own cards
+	Table    TableScorer  // Scores the whole table
+	Dessert  bool         // Scored once at the end of the game from the desserts players kept
+	Describe Describer    // Optional
+}
+
+// Registry holds the scoring rules for every card type, in breakdown order
+type Registry struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a rule for a card type
+// A rule for a card type that is already registered replaces it in place,
+// which is how house rules change the scoring of a card
+func (r *Registry) Register(rule Rule) error {
+	if rule.CardType == "" {
+		return fmt.Errorf("%w: no card type", ErrInvalidRule)
+	}
+	if (rule.Player == nil) == (rule.Table == nil) {
+		return fmt.Errorf("%w: %s needs exactly one of a player or table scorer", ErrInvalidRule, rule.CardType)
+	}
+	if rule.Category == "" {
+		rule.Category = string(rule.CardType)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.rules {
+		if existing.CardType == rule.CardType {
+			r.rules[i] = rule
+			return nil
+		}
+	}
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Rule returns the rule registered for a card type
+func (r *Registry) Rule(cardType models.CardType) (Rule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rule := range r.rules {
+		if rule.CardType == cardType {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Clone copies the registry so rules can be changed without affecting the original
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &Registry{rules: append([]Rule{}, r.rules...)}
+}
+
+// ScoreRound scores a player's round category by category
+// Dessert rules are skipped, and categories the player has no cards in and no points from are left out
+func (r *Registry) ScoreRound(player *models.Player, players []*models.Player) models.ScoreBreakdown {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breakdown := models.ScoreBreakdown{Categories: []models.CategoryScore{}}
+	for _, rule := range r.rules {
+		if rule.Dessert {
+			continue
+		}
+		category := rule.score(player, players, player.Collection)
+		if category.Cards == 0 && category.Points == 0 {
+			continue
+		}
+		breakdown.Categories = append(breakdown.Categories, category)
+		breakdown.Total += category.Points
+	}
+	return breakdown
+}
+
+// ScoreDessert scores a dessert for every player at the end of the game
+// Returns a map of player ID to category; a dessert without a rule scores nothing
+func (r *Registry) ScoreDessert(dessert models.CardType, players []*models.Player) map[string]models.CategoryScore {
+	rule, ok := r.Rule(dessert)
+	if !ok {
+		rule = Rule{CardType: dessert, Category: string(dessert), Player: func([]models.Card) int { return 0 }}
+	}
+
+	scores := make(map[string]models.CategoryScore)
+	for _, player := range players {
+		scores[player.ID] = rule.score(player, players, player.PuddingCards)
+	}
+	return scores
+}
+
+// score applies the rule to one player, counting the card type in cards
+func (rule Rule) score(player *models.Player, players []*models.Player, cards []models.Card) models.CategoryScore {
+	category := models.CategoryScore{
+		Category: rule.Category,
+		Cards:    countType(cards, rule.CardType),
+	}
+	if rule.Player != nil {
+		category.Points = rule.Player(cards)
+	} else {
+		category.Points = rule.Table(players)[player.ID]
+	}
+	if rule.Describe != nil {
+		rule.Describe(&category, player, players)
+	}
+	return category
+}
+
+// DefaultRegistry holds the rules of Sushi Go! and Sushi Go Party!, giving every tied Maki and Pudding player the full points
+// Expansion cards and house rules can be added with Register
+var DefaultRegistry = newDefaultRegistry()
+
+// splitTieRegistry is the printed rules, splitting tied Maki and Pudding points
+var splitTieRegistry = newSplitTieRegistry()
+
+// RegistryFor returns the rules for a tie mode
+// An empty mode gets the default full points
+func RegistryFor(mode models.TieMode) *Registry {
+	if mode == models.TieSplit {
+		return splitTieRegistry
+	}
+	return DefaultRegistry
+}
+
+// Register adds or replaces a rule for every tie mode
+func Register(rule Rule) error {
+	for _, registry := range []*Registry{DefaultRegistry, splitTieRegistry} {
+		if err := registry.Register(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newDefaultRegistry registers every card that scores, in breakdown order
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+	for _, rule := range []Rule{
+		{CardType: models.CardTypeMakiRoll, Category: CategoryMaki, Table: ScoreMakiRolls, Describe: describeMaki},
+		{CardType: models.CardTypeTempura, Category: CategoryTempura, Player: ScoreTempura, Describe: describeTempura},
+		{CardType: models.CardTypeSashimi, Category: CategorySashimi, Player: ScoreSashimi, Describe: describeSashimi},
+		{CardType: models.CardTypeDumpling, Category: CategoryDumpling, Player: ScoreDumplings, Describe: describeDumplings},
+		{CardType: models.CardTypeNigiri, Category: CategoryNigiri, Player: ScoreNigiri, Describe: describeNigiri},
+
+		// Sushi Go Party!
+		{CardType: models.CardTypeTemaki, Category: CategoryTemaki, Table: ScoreTemaki, Describe: describeTemaki},
+		{CardType: models.CardTypeUramaki, Category: CategoryUramaki, Table: ScoreUramaki, Describe: describeUramaki},
+		{CardType: models.CardTypeOnigiri, Category: CategoryOnigiri, Player: ScoreOnigiri, Describe: describeOnigiri},
+		{CardType: models.CardTypeEdamame, Category: CategoryEdamame, Table: ScoreEdamame, Describe: describeEdamame},
+		{CardType: models.CardTypeEel, Category: CategoryEel, Player: ScoreEel},
+		{CardType: models.CardTypeTofu, Category: CategoryTofu, Player: ScoreTofu},
+		{CardType: models.CardTypeMisoSoup, Category: CategoryMisoSoup, Player: ScoreMisoSoup},
+		{CardType: models.CardTypeSoySauce, Category: CategorySoySauce, Table: ScoreSoySauce, Describe: describeSoySauce},
+		{CardType: models.CardTypeTea, Category: CategoryTea, Player: ScoreTea, Describe: describeTea},
+		{CardType: models.CardTypeFaceDown, Category: CategoryTakeoutBox, Player: ScoreFaceDown},
+
+		// Desserts
+		{CardType: models.CardTypePudding, Category: CategoryPudding, Table: ScorePudding, Dessert: true, Describe: describePudding},
+		{CardType: models.CardTypeGreenTeaIceCream, Category: CategoryGreenTeaIceCream, Player: ScoreGreenTeaIceCream, Dessert: true, Describe: describeGreenTeaIceCream},
+		{CardType: models.CardTypeFruit, Category: CategoryFruit, Player: ScoreFruit, Dessert: true},
+	} {
+		if err := registry.Register(rule); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// newSplitTieRegistry swaps the default Maki and Pudding rules for the printed ones, which split ties
+func newSplitTieRegistry() *Registry {
+	registry := DefaultRegistry.Clone()
+	for _, rule := range []Rule{
+		{CardType: models.CardTypeMakiRoll, Category: CategoryMaki, Table: ScoreMakiRollsSplit, Describe: describeMaki},
+		{CardType: models.CardTypePudding, Category: CategoryPudding, Table: ScorePuddingSplit, Dessert: true, Describe: describePudding},
+	} {
+		if err := registry.Register(rule); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
@@ -0,0 +1,205 @@
+package scoring
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sushi-go-game/backend/models"
+)
+
+// TestRegistryRegister_Validation tests that rules need a card type and exactly one scorer
+func TestRegistryRegister_Validation(t *testing.T) {
+	registry := NewRegistry()
+	perCard := func(cards []models.Card) int { return len(cards) }
+	table := func(players []*models.Player) map[string]int { return nil }
+
+	invalid := map[string]Rule{
+		"no card type": {Player: perCard},
+		"no scorer":    {CardType: models.CardTypeTofu},
+		"both scorers": {CardType: models.CardTypeTofu, Player: perCard, Table: table},
+	}
+	for name, rule := range invalid {
+		if err := registry.Register(rule); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("%s: expected ErrInvalidRule, got %v", name, err)
+		}
+	}
+
+	if err := registry.Register(Rule{CardType: models.CardTypeTofu, Player: perCard}); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	rule, ok := registry.Rule(models.CardTypeTofu)
+	if !ok || rule.Category != string(models.CardTypeTofu) {
+		t.Errorf("Expected the category to default to the card type, got %+v", rule)
+	}
+}
+
+// TestRegistryRegister_HouseRule tests that replacing a rule keeps its place and leaves the original registry alone
+func TestRegistryRegister_HouseRule(t *testing.T) {
+	player := &models.Player{ID: "p1", Collection: append(cardsOf(models.CardTypeTempura, 2), cardsOf(models.CardTypeDumpling, 3)...)}
+	players := []*models.Player{player}
+
+	house := DefaultRegistry.Clone()
+	err := house.Register(Rule{
+		CardType: models.CardTypeDumpling,
+		Category: CategoryDumpling,
+		Player:   func(cards []models.Card) int { return 3 * countType(cards, models.CardTypeDumpling) },
+	})
+	if err != nil {
+		t.Fatalf("Failed to register house rule: %v", err)
+	}
+
+	breakdown := house.ScoreRound(player, players)
+	if breakdown.Total != 14 {
+		t.Errorf("Expected 5 for Tempura and 9 for Dumplings, got %d", breakdown.Total)
+	}
+	if len(breakdown.Categories) != 2 || breakdown.Categories[1].Category != CategoryDumpling {
+		t.Errorf("Expected Dumplings to stay after Tempura, got %+v", breakdown.Categories)
+	}
+
+	if got := ScorePlayerRound(player, players); got != 11 {
+		t.Errorf("Expected the default rules to be unchanged, got %d", got)
+	}
+}
+
+// TestRegistryRegister_NewCard tests that a table-wide rule for a new card type is scored
+func TestRegistryRegister_NewCard(t *testing.T) {
+	const sake models.CardType = "sake"
+	registry := DefaultRegistry.Clone()
+	err := registry.Register(Rule{
+		CardType: sake,
+		Table: func(players []*models.Player) map[string]int {
+			// Everyone scores 1 per Sake played at the table
+			total := 0
+			for _, player := range players {
+				total += countType(player.Collection, sake)
+			}
+			scores := make(map[string]int)
+			for _, player := range players {
+				scores[player.ID] = total
+			}
+			return scores
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	p1 := &models.Player{ID: "p1", Collection: []models.Card{{Type: sake}, {Type: sake}}}
+	p2 := &models.Player{ID: "p2"}
+	players := []*models.Player{p1, p2}
+
+	// p2 has no Sake but still scores from the table
+	breakdown := registry.ScoreRound(p2, players)
+	if breakdown.Total != 2 || len(breakdown.Categories) != 1 || breakdown.Categories[0].Category != "sake" {
+		t.Errorf("Expected 2 points from sake, got %+v", breakdown)
+	}
+	if ScorePlayerRound(p2, players) != 0 {
+		t.Error("Expected the default registry not to know sake")
+	}
+}
+
+// TestScorePudding tests the Pudding bonus and penalty
+func TestScorePudding(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   []int
+		expected []int
+	}{
+		{"most and fewest", []int{3, 1, 0}, []int{6, 0, -6}},
+		{"tied for most", []int{2, 2, 1}, []int{6, 6, -6}},
+		{"all equal", []int{1, 1, 1}, []int{0, 0, 0}},
+		{"two players", []int{0, 2}, []int{0, 6}},
+		{"two players without Pudding", []int{0, 0}, []int{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make([]*models.Player, len(tt.counts))
+			for i, count := range tt.counts {
+				players[i] = &models.Player{ID: string(rune('a' + i)), PuddingCards: cardsOf(models.CardTypePudding, count)}
+			}
+
+			scores := ScorePudding(players)
+			for i, player := range players {
+				if scores[player.ID] != tt.expected[i] {
+					t.Errorf("Expected %s to score %d, got %d", player.ID, tt.expected[i], scores[player.ID])
+				}
+			}
+		})
+	}
+}
+
+// TestScorePuddingSplit tests the printed rules' split of the Pudding bonus and penalty
+func TestScorePuddingSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   []int
+		expected []int
+	}{
+		{"most and fewest", []int{3, 1, 0}, []int{6, 0, -6}},
+		{"tied for most", []int{2, 2, 1}, []int{3, 3, -6}},
+		{"tied for fewest", []int{3, 0, 0, 0, 0}, []int{6, -1, -1, -1, -1}},
+		{"all equal", []int{1, 1, 1}, []int{0, 0, 0}},
+		{"two players tied", []int{2, 2}, []int{3, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make([]*models.Player, len(tt.counts))
+			for i, count := range tt.counts {
+				players[i] = &models.Player{ID: string(rune('a' + i)), PuddingCards: cardsOf(models.CardTypePudding, count)}
+			}
+
+			scores := ScorePuddingSplit(players)
+			for i, player := range players {
+				if scores[player.ID] != tt.expected[i] {
+					t.Errorf("Expected %s to score %d, got %d", player.ID, tt.expected[i], scores[player.ID])
+				}
+			}
+		})
+	}
+}
+
+// TestRegistryFor tests that each tie mode scores tied Maki its own way
+func TestRegistryFor(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", Collection: []models.Card{{Type: models.CardTypeMakiRoll, Value: 2}}},
+		{ID: "p2", Collection: []models.Card{{Type: models.CardTypeMakiRoll, Value: 2}}},
+	}
+
+	if got := RegistryFor(models.TieSplit).ScoreRound(players[0], players).Total; got != 3 {
+		t.Errorf("Expected a split first place to score 3, got %d", got)
+	}
+	if got := RegistryFor(models.TieFull).ScoreRound(players[0], players).Total; got != 6 {
+		t.Errorf("Expected the house rule to score 6, got %d", got)
+	}
+	if RegistryFor("") != DefaultRegistry {
+		t.Error("Expected an empty tie mode to use the default rules")
+	}
+}
+
+// TestScoreDessert tests that desserts score the cards players kept, and only registered desserts score
+func TestScoreDessert(t *testing.T) {
+	players := []*models.Player{
+		{ID: "p1", PuddingCards: cardsOf(models.CardTypeGreenTeaIceCream, 5)},
+		{ID: "p2", Collection: cardsOf(models.CardTypeGreenTeaIceCream, 4)},
+	}
+
+	scores := ScoreDessert(models.CardTypeGreenTeaIceCream, players)
+	if score := scores["p1"]; score.Points != 12 || score.Cards != 5 || score.Sets != 1 {
+		t.Errorf("Unexpected ice cream score for p1 %+v", score)
+	}
+	if score := scores["p2"]; score.Points != 0 {
+		t.Errorf("Expected cards still in the collection not to count, got %+v", score)
+	}
+
+	scores = ScoreDessert("cake", players)
+	if score := scores["p1"]; score.Points != 0 || score.Category != "cake" {
+		t.Errorf("Expected an unknown dessert to score nothing, got %+v", score)
+	}
+
+	// Desserts are never scored with the round
+	if breakdown := ScorePlayerRoundBreakdown(players[1], players); breakdown.Total != 0 {
+		t.Errorf("Expected no round points for desserts, got %+v", breakdown)
+	}
+}
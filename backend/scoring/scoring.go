@@ -6,21 +6,57 @@ import (
 	"github.com/sushi-go-game/backend/models"
 )
 
-// ScoringSystem handles all scoring logic for different card types
-type ScoringSystem interface {
-	ScoreMakiRolls(players []*models.Player) map[string]int
-	ScoreTempura(cards []models.Card) int
-	ScoreSashimi(cards []models.Card) int
-	ScoreDumplings(cards []models.Card) int
-	ScoreNigiri(cards []models.Card, wasabiCards []models.Card) int
-	ScorePudding(players []*models.Player, playerCount int) map[string]int
-}
-
-// ScoreMakiRolls calculates Maki Roll scores for all players
+// ScoreMakiRolls calculates Maki Roll scores for all players, giving tied players the full points
 // Most maki rolls: 6 points, Second most: 3 points
+// With 6 or more players: 6, 4 and 2 points for the three largest counts
+// Tied players share a place and push the following places down
 func ScoreMakiRolls(players []*models.Player) map[string]int {
+	return scoreMakiRolls(players, false)
+}
+
+// ScoreMakiRollsSplit calculates Maki Roll scores as the printed rules do:
+// tied players split the points for their place, rounded down
+func ScoreMakiRollsSplit(players []*models.Player) map[string]int {
+	return scoreMakiRolls(players, true)
+}
+
+// scoreMakiRolls awards the Maki places, splitting tied places when split is set
+func scoreMakiRolls(players []*models.Player, split bool) map[string]int {
 	scores := make(map[string]int)
 
+	awards := []int{6, 3}
+	if len(players) >= 6 {
+		awards = []int{6, 4, 2}
+	}
+
+	places := makiPlaces(players)
+	tied := make(map[int]int)
+	for _, place := range places {
+		tied[place]++
+	}
+
+	for playerID, place := range places {
+		if place <= len(awards) {
+			scores[playerID] = share(awards[place-1], tied[place], split)
+		}
+	}
+	return scores
+}
+
+// share returns what each of tied players scores from points
+// Split points are rounded down, as the printed rules ignore the remainder
+func share(points, tied int, split bool) int {
+	if !split || tied <= 1 {
+		return points
+	}
+	return points / tied
+}
+
+// makiPlaces ranks the players who have maki rolls by icon count (1: most)
+// Tied players share a place and push the following places down
+func makiPlaces(players []*models.Player) map[string]int {
+	places := make(map[string]int)
+
 	// Count maki rolls for each player
 	type playerMaki struct {
 		playerID string
@@ -45,31 +81,20 @@ func ScoreMakiRolls(players []*models.Player) map[string]int {
 		return makiCounts[i].count > makiCounts[j].count
 	})
 
-	if len(makiCounts) == 0 {
-		return scores
-	}
-
-	// Award 6 points to player(s) with most maki
-	maxCount := makiCounts[0].count
-	firstPlaceCount := 0
-	for _, pm := range makiCounts {
-		if pm.count == maxCount {
-			scores[pm.playerID] = 6
-			firstPlaceCount++
-		}
-	}
-
-	// Award 3 points to player(s) with second most (if not tied for first)
-	if firstPlaceCount == 1 && len(makiCounts) > 1 {
-		secondMaxCount := makiCounts[1].count
-		for _, pm := range makiCounts {
-			if pm.count == secondMaxCount && pm.count < maxCount {
-				scores[pm.playerID] = 3
+	// Each group of equal counts takes the next place; ties use up the places below them
+	for i := 0; i < len(makiCounts); {
+		tied := 0
+		for _, pm := range makiCounts[i:] {
+			if pm.count != makiCounts[i].count {
+				break
 			}
+			places[pm.playerID] = i + 1
+			tied++
 		}
+		i += tied
 	}
 
-	return scores
+	return places
 }
 
 // ScoreTempura calculates the score for Tempura cards
@@ -157,21 +182,74 @@ func ScoreNigiri(cards []models.Card) int {
 	return score
 }
 
-// ScorePlayerRound calculates the total score for a player's collection in a round
-func ScorePlayerRound(player *models.Player, allPlayers []*models.Player) int {
-	score := 0
+// ScorePudding calculates Pudding scores for all players at the end of the game, giving tied players the full points
+// Most Pudding: 6 points, fewest: -6 points (no penalty in a 2-player game)
+// Returns a map of player ID to Pudding score (can be positive or negative)
+func ScorePudding(players []*models.Player) map[string]int {
+	return scorePudding(players, false)
+}
 
-	// Score individual card types
-	score += ScoreTempura(player.Collection)
-	score += ScoreSashimi(player.Collection)
-	score += ScoreDumplings(player.Collection)
-	score += ScoreNigiri(player.Collection)
+// ScorePuddingSplit calculates Pudding scores as the printed rules do:
+// tied players split the bonus or penalty, rounded down
+func ScorePuddingSplit(players []*models.Player) map[string]int {
+	return scorePudding(players, true)
+}
+
+// scorePudding awards the Pudding bonus and penalty, splitting them between tied players when split is set
+func scorePudding(players []*models.Player, split bool) map[string]int {
+	scores := make(map[string]int)
 
-	// Maki rolls are scored across all players
-	makiScores := ScoreMakiRolls(allPlayers)
-	if makiScore, ok := makiScores[player.ID]; ok {
-		score += makiScore
+	// Find most and fewest Pudding counts, and how many players have them
+	maxPudding := -1
+	minPudding := 1000000 // Large number
+	for _, player := range players {
+		puddingCount := len(player.PuddingCards)
+		if puddingCount > maxPudding {
+			maxPudding = puddingCount
+		}
+		if puddingCount < minPudding {
+			minPudding = puddingCount
+		}
+	}
+	most, fewest := 0, 0
+	for _, player := range players {
+		if len(player.PuddingCards) == maxPudding {
+			most++
+		}
+		if len(player.PuddingCards) == minPudding {
+			fewest++
+		}
 	}
 
-	return score
+	// Special case: 2-player games have no penalty for fewest Pudding
+	if len(players) == 2 {
+		// Award 6 points to player(s) with most Pudding
+		for _, player := range players {
+			if len(player.PuddingCards) == maxPudding && maxPudding > 0 {
+				scores[player.ID] = share(6, most, split)
+			}
+		}
+		return scores
+	}
+
+	// Award 6 points to all players with most Pudding
+	for _, player := range players {
+		if len(player.PuddingCards) == maxPudding {
+			scores[player.ID] = share(6, most, split)
+		}
+	}
+
+	// Deduct 6 points from all players with fewest Pudding
+	for _, player := range players {
+		if len(player.PuddingCards) == minPudding {
+			scores[player.ID] -= share(6, fewest, split)
+		}
+	}
+
+	return scores
+}
+
+// ScorePlayerRound calculates the total score for a player's collection in a round
+func ScorePlayerRound(player *models.Player, allPlayers []*models.Player) int {
+	return ScorePlayerRoundBreakdown(player, allPlayers).Total
 }
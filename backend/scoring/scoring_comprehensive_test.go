@@ -1,6 +1,7 @@
 package scoring
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/sushi-go-game/backend/models"
@@ -407,3 +408,72 @@ func TestScorePlayerRound_OnlyChopsticks(t *testing.T) {
 		t.Errorf("Expected 0 (chopsticks don't score), got %d", score)
 	}
 }
+
+// TestScoreMakiRolls_SixPlayers tests the 6/4/2 split at tables of 6 or more
+func TestScoreMakiRolls_SixPlayers(t *testing.T) {
+	counts := map[string]int{"p1": 5, "p2": 4, "p3": 3, "p4": 3, "p5": 1, "p6": 0}
+	players := []*models.Player{}
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		player := &models.Player{ID: id}
+		if counts[id] > 0 {
+			player.Collection = []models.Card{{Type: models.CardTypeMakiRoll, Value: counts[id]}}
+		}
+		players = append(players, player)
+	}
+
+	scores := ScoreMakiRolls(players)
+
+	// p3 and p4 tie for third and both take 2
+	expected := map[string]int{"p1": 6, "p2": 4, "p3": 2, "p4": 2, "p5": 0, "p6": 0}
+	for id, want := range expected {
+		if scores[id] != want {
+			t.Errorf("Expected %s to score %d, got %d", id, want, scores[id])
+		}
+	}
+}
+
+// makiTable builds one player per icon count, named p1, p2, ...
+func makiTable(counts ...int) []*models.Player {
+	players := make([]*models.Player, len(counts))
+	for i, count := range counts {
+		players[i] = &models.Player{ID: fmt.Sprintf("p%d", i+1)}
+		if count > 0 {
+			players[i].Collection = []models.Card{{Type: models.CardTypeMakiRoll, Value: count}}
+		}
+	}
+	return players
+}
+
+// TestScoreMakiRollsSplit tests the printed rules' split of tied Maki places
+func TestScoreMakiRollsSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   []int
+		expected []int
+	}{
+		{"single winner", []int{3, 2, 1}, []int{6, 3, 0}},
+		{"tied for first", []int{3, 3, 1}, []int{3, 3, 0}},
+		{"three tied for first", []int{2, 2, 2, 1}, []int{2, 2, 2, 0}},
+		{"tied for second", []int{3, 2, 2}, []int{6, 1, 1}},
+		{"four tied for second", []int{3, 1, 1, 1, 1}, []int{6, 0, 0, 0, 0}},
+		{"six players tied for third", []int{5, 4, 3, 3, 1, 0}, []int{6, 4, 1, 1, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := makiTable(tt.counts...)
+			scores := ScoreMakiRollsSplit(players)
+			for i, player := range players {
+				if scores[player.ID] != tt.expected[i] {
+					t.Errorf("Expected %s to score %d, got %d", player.ID, tt.expected[i], scores[player.ID])
+				}
+			}
+		})
+	}
+
+	// The house rule keeps the full points for the same ties
+	full := ScoreMakiRolls(makiTable(3, 2, 2))
+	if full["p2"] != 3 || full["p3"] != 3 {
+		t.Errorf("Expected full points for tied second place, got %v", full)
+	}
+}
@@ -2,16 +2,20 @@ package server
 
 import (
 	"fmt"
+	"log"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/sushi-go-game/backend/engine"
 	"github.com/sushi-go-game/backend/handlers"
+	"github.com/sushi-go-game/backend/models"
 )
 
 // GameConfig configures game parameters
 type GameConfig struct {
-	NumRounds    int
+	NumRounds int
+	// CardsPerHand fixes the hand size as a house rule; 0 uses the official size for the player count
 	CardsPerHand int
 }
 
@@ -21,6 +25,31 @@ type ServerOptions struct {
 	CustomDealer engine.CardDealer
 	// GameConfig specifies game parameters
 	GameConfig *GameConfig
+	// Store persists games across restarts (default: in-memory only)
+	// Games in it are kept when all their players disconnect, so they can reconnect after a restart
+	Store engine.GameStore
+	// Seed fixes the engine's random source so games are reproducible (default: time-based)
+	Seed *int64
+	// EventLog durably records every game event for replays (default: in-memory only)
+	EventLog engine.EventLog
+	// TurnTimeout is the time allowed per pick before idle players are auto-played (default: 0, no timer)
+	TurnTimeout time.Duration
+	// AutoPlayPolicy chooses the card played for idle players (default: random card)
+	AutoPlayPolicy engine.AutoPlayPolicy
+	// TokenSecret signs players' reconnect tokens (default: random, so tokens don't survive a restart)
+	TokenSecret []byte
+	// PlayerLimits bounds the table size for new games (default: engine.DefaultPlayerLimits)
+	PlayerLimits *engine.PlayerLimits
+	// TieMode sets how tied Maki and Pudding points are shared in new games (default: full points for every tied player)
+	TieMode models.TieMode
+	// Deck is dealt in new games of the original Sushi Go! (default: the 108-card deck)
+	Deck *models.DeckDefinition
+	// PassPolicy sets who receives each hand in new games (default: pass left, as printed)
+	PassPolicy models.PassPolicy
+	// ReadyCheck sets whether players must be ready before new games start (default: off, the host starts at will)
+	ReadyCheck models.ReadyCheck
+	// MatchBackfill is how long find_match waits for players before filling the table with bots (default: 0, never)
+	MatchBackfill time.Duration
 }
 
 // Server represents a game server instance
@@ -60,8 +89,83 @@ func NewServer(addr string, options *ServerOptions) (*Server, error) {
 		gameEngine = engine.NewEngineWithDealer(options.CustomDealer)
 	}
 
+	if options.Seed != nil {
+		gameEngine.SetSeed(*options.Seed)
+	}
+	gameEngine.SetTurnTimeout(options.TurnTimeout)
+	if options.PlayerLimits != nil {
+		if err := gameEngine.SetPlayerLimits(*options.PlayerLimits); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("invalid player limits: %w", err)
+		}
+	}
+
+	if options.TieMode != "" {
+		if err := gameEngine.SetTieMode(options.TieMode); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("invalid tie mode: %w", err)
+		}
+	}
+
+	if options.PassPolicy.Direction != "" {
+		if err := gameEngine.SetPassPolicy(options.PassPolicy); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("invalid pass policy: %w", err)
+		}
+	}
+
+	if options.ReadyCheck != "" {
+		if err := gameEngine.SetReadyCheck(options.ReadyCheck); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("invalid ready check: %w", err)
+		}
+	}
+
+	// The deck is checked against the player limits, so set it after them
+	if options.Deck != nil {
+		if err := gameEngine.SetDeck(options.Deck); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("invalid deck: %w", err)
+		}
+	}
+
+	// Every round of a game is dealt from one deck, so it must cover the configured rounds and hands
+	// A deck that runs dry at a large table only caps the seats of each new game
+	if err := gameEngine.CheckDeck(); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("invalid game config: %w", err)
+	}
+	if limits := gameEngine.PlayerLimits(); gameEngine.DeckSeats() < limits.Max {
+		log.Printf("Warning: the deck can't deal every round to %d players, so games seat at most %d", limits.Max, gameEngine.DeckSeats())
+	}
+
+	// Reload any games saved before the last shutdown
+	if options.Store != nil {
+		if err := gameEngine.SetStore(options.Store); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to load games: %w", err)
+		}
+	}
+	if options.EventLog != nil {
+		if err := gameEngine.SetEventLog(options.EventLog); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to load game events: %w", err)
+		}
+	}
+
 	// Initialize WebSocket handler
 	wsHandler := handlers.NewWSHandler(gameEngine)
+	if options.AutoPlayPolicy != nil {
+		wsHandler.SetAutoPlayPolicy(options.AutoPlayPolicy)
+	}
+	if len(options.TokenSecret) > 0 {
+		wsHandler.SetTokenSecret(options.TokenSecret)
+	}
+	wsHandler.SetMatchBackfill(options.MatchBackfill)
+	// Games in a durable store outlive their connections, which a shutdown closes
+	wsHandler.SetKeepGames(options.Store != nil)
+	// Games reloaded mid-turn time out even if nobody reconnects to them
+	wsHandler.ResumeTurnTimers()
 
 	// Set up routes
 	mux := http.NewServeMux()
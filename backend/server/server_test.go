@@ -2,12 +2,14 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/sushi-go-game/backend/engine"
 	"github.com/sushi-go-game/backend/models"
 )
 
@@ -447,6 +449,24 @@ func TestServerCustomGameConfig(t *testing.T) {
 	if server.Port == 0 {
 		t.Error("Server should have a valid port")
 	}
+
+	// Ten-card hands over three rounds need 30 of the deck's 108 cards per player, so games seat three
+	capped, err := NewServer(":0", &ServerOptions{GameConfig: &GameConfig{NumRounds: 3, CardsPerHand: 10}})
+	if err != nil {
+		t.Fatalf("Expected ten-card hands to cap the table, got %v", err)
+	}
+	defer capped.listener.Close()
+	if _, err := capped.engine.CreateGame([]string{"p1", "p2", "p3", "p4"}); err != engine.ErrTooManyPlayers {
+		t.Errorf("Expected ErrTooManyPlayers for a fourth seat, got %v", err)
+	}
+	if _, err := capped.engine.CreateGame([]string{"p1", "p2", "p3"}); err != nil {
+		t.Errorf("Expected three seats, got %v", err)
+	}
+
+	// Ten rounds can't be dealt even to two players
+	if _, err := NewServer(":0", &ServerOptions{GameConfig: &GameConfig{NumRounds: 10}}); !errors.Is(err, engine.ErrDeckExhausted) {
+		t.Errorf("Expected ErrDeckExhausted for a config the deck can't cover, got %v", err)
+	}
 }
 
 // TestServerConnectionClose tests that connections close gracefully
@@ -511,3 +531,1528 @@ func TestServerInvalidMessage(t *testing.T) {
 		t.Errorf("Expected error response, got %s", respMsg.Type)
 	}
 }
+
+// TestServerReloadsPersistedGames tests that games saved in the store are listed after a restart
+func TestServerReloadsPersistedGames(t *testing.T) {
+	store := engine.NewMemoryStore()
+	store.Save(&models.Game{
+		ID:         "osaka-kiku-42",
+		Players:    []*models.Player{{ID: "p1", Name: "Alice"}},
+		RoundPhase: models.PhaseWaitingForPlayers,
+	})
+
+	server, err := NewServer(":0", &ServerOptions{Store: store})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	listMsg := models.Message{
+		Type:    models.MsgTypeListGames,
+		Payload: json.RawMessage("{}"),
+	}
+	data, _ := json.Marshal(listMsg)
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Fatalf("Failed to send list message: %v", err)
+	}
+
+	_, response, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+
+	var respMsg models.Message
+	if err := json.Unmarshal(response, &respMsg); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	var listPayload struct {
+		Games []map[string]interface{} `json:"games"`
+	}
+	if err := json.Unmarshal(respMsg.Payload, &listPayload); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %v", err)
+	}
+
+	if len(listPayload.Games) != 1 || listPayload.Games[0]["id"] != "osaka-kiku-42" {
+		t.Errorf("Expected reloaded game in list, got %v", listPayload.Games)
+	}
+}
+
+// TestServerKeepsStoredGamesOnDisconnect tests that a durable store keeps games everyone disconnected from
+func TestServerKeepsStoredGamesOnDisconnect(t *testing.T) {
+	store := engine.NewMemoryStore()
+	server, err := NewServer(":0", &ServerOptions{Store: store})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	_, alice := joinAndRead(t, conn, `{"gameId":"","playerName":"Alice"}`)
+	conn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := store.Load(alice.GameID); err != nil {
+		t.Errorf("Expected the game to stay in the store after Alice disconnected, got %v", err)
+	}
+}
+
+// TestServerResumesTurnTimers tests that a game reloaded mid-turn times out even if nobody reconnects
+func TestServerResumesTurnTimers(t *testing.T) {
+	store := engine.NewMemoryStore()
+	before := engine.NewEngine()
+	before.SetTurnTimeout(200 * time.Millisecond)
+	if err := before.SetStore(store); err != nil {
+		t.Fatalf("Failed to set store: %v", err)
+	}
+	game, err := before.CreateGame([]string{"p1", "p2"})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	before.StartGame(game.ID)
+	if err := before.StartRound(game.ID); err != nil {
+		t.Fatalf("Failed to start round: %v", err)
+	}
+
+	server, err := NewServer(":0", &ServerOptions{Store: store, AutoPlayPolicy: engine.FirstCardPolicy{}})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer server.Stop()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		reloaded, err := server.engine.GetGame(game.ID)
+		if err != nil {
+			t.Fatalf("Failed to get game: %v", err)
+		}
+		if reloaded.CurrentRound > 1 || len(reloaded.Players[0].Hand) < reloaded.CardsPerHand {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Error("Expected the reloaded game's idle players to be auto-played")
+}
+
+// TestServerGameStateHandSize tests that game_state reports the hand size and how it was chosen
+func TestServerGameStateHandSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		cardsPerHand int
+		expectedSize int
+		expectedMode string
+	}{
+		{"rules mode", 0, 10, string(models.HandSizeRules)},
+		{"house rule", 6, 6, string(models.HandSizeHouse)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := NewServer(":0", &ServerOptions{
+				GameConfig: &GameConfig{NumRounds: 3, CardsPerHand: tt.cardsPerHand},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+
+			server.StartBackground()
+			defer server.Stop()
+
+			time.Sleep(100 * time.Millisecond)
+
+			u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+			conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			createMsg := models.Message{
+				Type:    models.MsgTypeJoinGame,
+				Payload: json.RawMessage(`{"gameId":"","playerName":"Alice"}`),
+			}
+			data, _ := json.Marshal(createMsg)
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				t.Fatalf("Failed to send create message: %v", err)
+			}
+
+			_, response, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("Failed to read response: %v", err)
+			}
+
+			var respMsg models.Message
+			if err := json.Unmarshal(response, &respMsg); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			var gameState struct {
+				CardsPerHand int    `json:"cardsPerHand"`
+				HandSizeMode string `json:"handSizeMode"`
+			}
+			if err := json.Unmarshal(respMsg.Payload, &gameState); err != nil {
+				t.Fatalf("Failed to unmarshal game state: %v", err)
+			}
+
+			if gameState.CardsPerHand != tt.expectedSize {
+				t.Errorf("Expected cardsPerHand %d, got %d", tt.expectedSize, gameState.CardsPerHand)
+			}
+			if gameState.HandSizeMode != tt.expectedMode {
+				t.Errorf("Expected handSizeMode %s, got %s", tt.expectedMode, gameState.HandSizeMode)
+			}
+		})
+	}
+}
+
+// TestServerTurnTimeoutAutoPlays tests that idle players are auto-played once the pick timer expires
+func TestServerTurnTimeoutAutoPlays(t *testing.T) {
+	server, err := NewServer(":0", &ServerOptions{
+		TurnTimeout:    300 * time.Millisecond,
+		AutoPlayPolicy: engine.FirstCardPolicy{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	conn1, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect player 1: %v", err)
+	}
+	defer conn1.Close()
+
+	conn2, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect player 2: %v", err)
+	}
+	defer conn2.Close()
+
+	type turnState struct {
+		GameID              string        `json:"gameId"`
+		Phase               string        `json:"phase"`
+		Players             []interface{} `json:"players"`
+		MyHand              []models.Card `json:"myHand"`
+		TurnTimeoutSeconds  int           `json:"turnTimeoutSeconds"`
+		TurnDeadline        *time.Time    `json:"turnDeadline"`
+		TurnTimeRemainingMs int64         `json:"turnTimeRemainingMs"`
+	}
+
+	// readState reads game_state messages until one satisfies done
+	readState := func(conn *websocket.Conn, done func(turnState) bool) (turnState, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			_, response, err := conn.ReadMessage()
+			if err != nil {
+				return turnState{}, false
+			}
+			var msg models.Message
+			if json.Unmarshal(response, &msg) != nil || msg.Type != models.MsgTypeGameState {
+				continue
+			}
+			var state turnState
+			if json.Unmarshal(msg.Payload, &state) == nil && done(state) {
+				return state, true
+			}
+		}
+	}
+
+	createMsg, _ := json.Marshal(models.Message{
+		Type:    models.MsgTypeJoinGame,
+		Payload: json.RawMessage(`{"gameId":"","playerName":"Alice"}`),
+	})
+	conn1.WriteMessage(websocket.TextMessage, createMsg)
+	created, ok := readState(conn1, func(s turnState) bool { return s.GameID != "" })
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+
+	joinMsg, _ := json.Marshal(models.Message{
+		Type:    models.MsgTypeJoinGame,
+		Payload: json.RawMessage(fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, created.GameID)),
+	})
+	conn2.WriteMessage(websocket.TextMessage, joinMsg)
+	if _, ok := readState(conn1, func(s turnState) bool { return len(s.Players) == 2 }); !ok {
+		t.Fatal("Expected Bob to join")
+	}
+
+	startMsg, _ := json.Marshal(models.Message{
+		Type:    models.MsgTypeStartGame,
+		Payload: json.RawMessage(fmt.Sprintf(`{"gameId":"%s"}`, created.GameID)),
+	})
+	conn1.WriteMessage(websocket.TextMessage, startMsg)
+
+	started, ok := readState(conn1, func(s turnState) bool {
+		return s.Phase == string(models.PhaseSelecting) && len(s.MyHand) == 10
+	})
+	if !ok {
+		t.Fatal("Expected the first pick to start")
+	}
+	if started.TurnDeadline == nil {
+		t.Error("Expected a turn deadline while picking")
+	}
+	if started.TurnTimeRemainingMs <= 0 || started.TurnTimeRemainingMs > 300 {
+		t.Errorf("Expected remaining time within the timeout, got %dms", started.TurnTimeRemainingMs)
+	}
+
+	// Nobody picks: the timer plays for both players and the hands are passed
+	if _, ok := readState(conn1, func(s turnState) bool {
+		return s.Phase == string(models.PhaseSelecting) && len(s.MyHand) == 9
+	}); !ok {
+		t.Error("Expected the turn to advance after the timeout")
+	}
+}
+
+// TestServerAddBot tests that the host can fill a seat with a bot that plays alongside humans
+func TestServerAddBot(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	conn1, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect player 1: %v", err)
+	}
+	defer conn1.Close()
+
+	conn2, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect player 2: %v", err)
+	}
+	defer conn2.Close()
+
+	type botState struct {
+		GameID  string `json:"gameId"`
+		Phase   string `json:"phase"`
+		Players []struct {
+			ID    string `json:"id"`
+			IsBot bool   `json:"isBot"`
+		} `json:"players"`
+		MyHand  []models.Card `json:"myHand"`
+		Message string        `json:"message"`
+	}
+
+	// readMessage reads messages of the given type until one satisfies done
+	readMessage := func(conn *websocket.Conn, msgType models.MessageType, done func(botState) bool) (botState, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			_, response, err := conn.ReadMessage()
+			if err != nil {
+				return botState{}, false
+			}
+			var msg models.Message
+			if json.Unmarshal(response, &msg) != nil || msg.Type != msgType {
+				continue
+			}
+			var state botState
+			if json.Unmarshal(msg.Payload, &state) == nil && done(state) {
+				return state, true
+			}
+		}
+	}
+
+	send := func(conn *websocket.Conn, msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	send(conn1, models.MsgTypeJoinGame, `{"gameId":"","playerName":"Alice"}`)
+	created, ok := readMessage(conn1, models.MsgTypeGameState, func(s botState) bool { return s.GameID != "" })
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+
+	send(conn2, models.MsgTypeJoinGame, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, created.GameID))
+	if _, ok := readMessage(conn2, models.MsgTypeGameState, func(s botState) bool { return len(s.Players) == 2 }); !ok {
+		t.Fatal("Expected Bob to join")
+	}
+
+	// Only the host may add bots
+	send(conn2, models.MsgTypeAddBot, `{"strategy":"greedy"}`)
+	if _, ok := readMessage(conn2, models.MsgTypeError, func(botState) bool { return true }); !ok {
+		t.Error("Expected an error when a non-host adds a bot")
+	}
+
+	send(conn1, models.MsgTypeAddBot, `{"strategy":"set_completion"}`)
+	withBot, ok := readMessage(conn1, models.MsgTypeGameState, func(s botState) bool { return len(s.Players) == 3 })
+	if !ok {
+		t.Fatal("Expected the bot to join")
+	}
+	if !withBot.Players[2].IsBot {
+		t.Error("Expected the third player to be marked as a bot")
+	}
+
+	send(conn1, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, created.GameID))
+	if _, ok := readMessage(conn1, models.MsgTypeGameState, func(s botState) bool { return len(s.MyHand) == 9 }); !ok {
+		t.Fatal("Expected the first pick to start")
+	}
+
+	// Once both humans pick, the bot has already played and the turn advances
+	send(conn1, models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	send(conn2, models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	if _, ok := readMessage(conn1, models.MsgTypeGameState, func(s botState) bool {
+		return s.Phase == string(models.PhaseSelecting) && len(s.MyHand) == 8
+	}); !ok {
+		t.Error("Expected the turn to advance with the bot's pick")
+	}
+}
+
+// TestServerSpectateGame tests that spectators get a redacted view and cannot act
+func TestServerSpectateGame(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	dial := func(name string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect %s: %v", name, err)
+		}
+		return conn
+	}
+	alice := dial("Alice")
+	defer alice.Close()
+	bob := dial("Bob")
+	defer bob.Close()
+	watcher := dial("spectator")
+	defer watcher.Close()
+
+	send := func(conn *websocket.Conn, msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	// read returns the raw payload of the next message of the given type that satisfies done
+	read := func(conn *websocket.Conn, msgType models.MessageType, done func(map[string]interface{}) bool) (map[string]interface{}, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			_, response, err := conn.ReadMessage()
+			if err != nil {
+				return nil, false
+			}
+			var msg models.Message
+			if json.Unmarshal(response, &msg) != nil || msg.Type != msgType {
+				continue
+			}
+			var payload map[string]interface{}
+			if json.Unmarshal(msg.Payload, &payload) == nil && done(payload) {
+				return payload, true
+			}
+		}
+	}
+	playerCount := func(n int) func(map[string]interface{}) bool {
+		return func(state map[string]interface{}) bool {
+			players, _ := state["players"].([]interface{})
+			return len(players) == n
+		}
+	}
+	anyMessage := func(map[string]interface{}) bool { return true }
+
+	send(alice, models.MsgTypeJoinGame, `{"gameId":"","playerName":"Alice"}`)
+	created, ok := read(alice, models.MsgTypeGameState, anyMessage)
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+	gameID := created["gameId"].(string)
+
+	send(bob, models.MsgTypeJoinGame, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, gameID))
+	if _, ok := read(alice, models.MsgTypeGameState, playerCount(2)); !ok {
+		t.Fatal("Expected Bob to join")
+	}
+
+	send(watcher, models.MsgTypeSpectateGame, fmt.Sprintf(`{"gameId":"%s"}`, gameID))
+	state, ok := read(watcher, models.MsgTypeGameState, anyMessage)
+	if !ok {
+		t.Fatal("Expected a game_state for the spectator")
+	}
+	if state["spectator"] != true {
+		t.Error("Expected the spectator flag in game_state")
+	}
+	if _, exists := state["myHand"]; exists {
+		t.Error("Expected no hand in the spectator's game_state")
+	}
+
+	// Spectators can't drive the game
+	for _, msgType := range []models.MessageType{models.MsgTypeStartGame, models.MsgTypeSelectCard, models.MsgTypeKickPlayer} {
+		send(watcher, msgType, fmt.Sprintf(`{"gameId":"%s","cardIndex":0,"playerId":"x"}`, gameID))
+		if _, ok := read(watcher, models.MsgTypeError, anyMessage); !ok {
+			t.Errorf("Expected %s from a spectator to be rejected", msgType)
+		}
+	}
+
+	// Nor take a seat, since they could never play from it
+	send(watcher, models.MsgTypeJoinGame, fmt.Sprintf(`{"gameId":"%s","playerName":"Carol"}`, gameID))
+	if _, ok := read(watcher, models.MsgTypeError, anyMessage); !ok {
+		t.Error("Expected join_game from a spectator to be rejected")
+	}
+
+	// Spectators follow along without seeing anyone's hand or pick
+	send(alice, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, gameID))
+	state, ok = read(watcher, models.MsgTypeGameState, func(s map[string]interface{}) bool {
+		return s["phase"] == string(models.PhaseSelecting)
+	})
+	if !ok {
+		t.Fatal("Expected the spectator to see the game start")
+	}
+	if !playerCount(2)(state) {
+		t.Errorf("Expected only Alice and Bob to be seated, got %v", state["players"])
+	}
+
+	send(alice, models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	state, ok = read(watcher, models.MsgTypeGameState, func(s map[string]interface{}) bool {
+		players := s["players"].([]interface{})
+		return players[0].(map[string]interface{})["hasSelected"] == true
+	})
+	if !ok {
+		t.Fatal("Expected the spectator to see Alice pick")
+	}
+	if _, exists := state["myHand"]; exists {
+		t.Error("Expected no hand in the spectator's game_state")
+	}
+	for _, p := range state["players"].([]interface{}) {
+		player := p.(map[string]interface{})
+		for _, hidden := range []string{"hand", "selectedCard", "selected_card"} {
+			if _, exists := player[hidden]; exists {
+				t.Errorf("Expected %s to be hidden from spectators", hidden)
+			}
+		}
+	}
+}
+
+// sessionState is the part of game_state the reconnection tests look at
+type sessionState struct {
+	GameID         string        `json:"gameId"`
+	MyPlayerID     string        `json:"myPlayerId"`
+	MyHand         []models.Card `json:"myHand"`
+	ReconnectToken string        `json:"reconnectToken"`
+	Players        []struct {
+		ID string `json:"id"`
+	} `json:"players"`
+}
+
+// joinAndRead sends a join_game payload and returns the first reply, which is either
+// a game_state or an error
+func joinAndRead(t *testing.T, conn *websocket.Conn, payload string) (models.MessageType, sessionState) {
+	t.Helper()
+
+	data, _ := json.Marshal(models.Message{Type: models.MsgTypeJoinGame, Payload: json.RawMessage(payload)})
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Fatalf("Failed to send join message: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		_, response, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Failed to read response: %v", err)
+		}
+		var msg models.Message
+		if err := json.Unmarshal(response, &msg); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if msg.Type != models.MsgTypeGameState && msg.Type != models.MsgTypeError {
+			continue
+		}
+		var state sessionState
+		json.Unmarshal(msg.Payload, &state)
+		return msg.Type, state
+	}
+}
+
+// TestServerReconnectToken tests that players get a token that lets them reclaim their seat
+func TestServerReconnectToken(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	conn1, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn1.Close()
+
+	msgType, alice := joinAndRead(t, conn1, `{"gameId":"","playerName":"Alice"}`)
+	if msgType != models.MsgTypeGameState {
+		t.Fatalf("Expected game_state, got %s", msgType)
+	}
+	if alice.ReconnectToken == "" {
+		t.Fatal("Expected a reconnect token in the player's game_state")
+	}
+
+	// Alice comes back on a new connection with her token
+	conn2, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn2.Close()
+
+	msgType, back := joinAndRead(t, conn2, fmt.Sprintf(`{"gameId":"%s","playerName":"Alice","playerId":"%s","token":"%s"}`,
+		alice.GameID, alice.MyPlayerID, alice.ReconnectToken))
+	if msgType != models.MsgTypeGameState {
+		t.Fatalf("Expected game_state after reconnecting, got %s", msgType)
+	}
+	if back.MyPlayerID != alice.MyPlayerID {
+		t.Errorf("Expected to reclaim seat %s, got %s", alice.MyPlayerID, back.MyPlayerID)
+	}
+	if len(back.Players) != 1 {
+		t.Errorf("Expected reconnecting not to add a player, got %d players", len(back.Players))
+	}
+}
+
+// TestServerRejectsSeatHijack tests that another client can't take over a seat by name or forged token
+func TestServerRejectsSeatHijack(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn
+	}
+
+	aliceConn := dial()
+	defer aliceConn.Close()
+	_, alice := joinAndRead(t, aliceConn, `{"gameId":"","playerName":"Alice"}`)
+
+	bobConn := dial()
+	defer bobConn.Close()
+	_, bob := joinAndRead(t, bobConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, alice.GameID))
+
+	attacker := dial()
+	defer attacker.Close()
+
+	attempts := []struct {
+		name    string
+		payload string
+	}{
+		{"same name", fmt.Sprintf(`{"gameId":"%s","playerName":"Alice"}`, alice.GameID)},
+		{"player ID without token", fmt.Sprintf(`{"gameId":"%s","playerName":"Mallory","playerId":"%s"}`, alice.GameID, alice.MyPlayerID)},
+		{"forged token", fmt.Sprintf(`{"gameId":"%s","playerName":"Mallory","playerId":"%s","token":"deadbeef"}`, alice.GameID, alice.MyPlayerID)},
+		{"another seat's token", fmt.Sprintf(`{"gameId":"%s","playerName":"Mallory","playerId":"%s","token":"%s"}`, alice.GameID, alice.MyPlayerID, bob.ReconnectToken)},
+	}
+
+	for _, attempt := range attempts {
+		t.Run(attempt.name, func(t *testing.T) {
+			msgType, state := joinAndRead(t, attacker, attempt.payload)
+			if msgType != models.MsgTypeError {
+				t.Errorf("Expected an error, got %s as player %s", msgType, state.MyPlayerID)
+			}
+		})
+	}
+
+	// Alice still holds her seat
+	msgType, _ := joinAndRead(t, aliceConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Alice","playerId":"%s","token":"%s"}`,
+		alice.GameID, alice.MyPlayerID, alice.ReconnectToken))
+	if msgType != models.MsgTypeGameState {
+		t.Errorf("Expected Alice's own token to keep working, got %s", msgType)
+	}
+}
+
+// TestServerNameReuseForUnclaimedSeat tests that a name reclaims a seat only once nobody holds it
+func TestServerNameReuseForUnclaimedSeat(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	aliceConn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer aliceConn.Close()
+	_, alice := joinAndRead(t, aliceConn, `{"gameId":"","playerName":"Alice"}`)
+
+	bobConn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	_, bob := joinAndRead(t, bobConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, alice.GameID))
+
+	// Bob drops; his seat is no longer claimed
+	bobConn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	newConn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer newConn.Close()
+
+	msgType, state := joinAndRead(t, newConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, alice.GameID))
+	if msgType != models.MsgTypeGameState {
+		t.Fatalf("Expected to reuse the unclaimed seat, got %s", msgType)
+	}
+	if state.MyPlayerID != bob.MyPlayerID {
+		t.Errorf("Expected seat %s, got %s", bob.MyPlayerID, state.MyPlayerID)
+	}
+}
+
+// TestServerHostAuthorization tests that only the host may send privileged messages
+func TestServerHostAuthorization(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn
+	}
+
+	type authReply struct {
+		Error  string `json:"error"`
+		Code   string `json:"code"`
+		Action string `json:"action"`
+		HostID string `json:"hostId"`
+	}
+
+	// request sends a message and returns the next error or game_state reply
+	request := func(conn *websocket.Conn, msgType models.MessageType, payload string) (models.MessageType, authReply) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			_, response, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("Failed to read reply to %s: %v", msgType, err)
+			}
+			var msg models.Message
+			if json.Unmarshal(response, &msg) != nil {
+				continue
+			}
+			if msg.Type != models.MsgTypeError && msg.Type != models.MsgTypeGameState {
+				continue
+			}
+			var reply authReply
+			json.Unmarshal(msg.Payload, &reply)
+			return msg.Type, reply
+		}
+	}
+
+	aliceConn := dial()
+	defer aliceConn.Close()
+	_, alice := joinAndRead(t, aliceConn, `{"gameId":"","playerName":"Alice"}`)
+
+	bobConn := dial()
+	defer bobConn.Close()
+	joinAndRead(t, bobConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, alice.GameID))
+
+	outsiderConn := dial()
+	defer outsiderConn.Close()
+
+	attempts := []struct {
+		name    string
+		conn    *websocket.Conn
+		msgType models.MessageType
+		payload string
+		code    string
+	}{
+		{"guest starts", bobConn, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID), "not_host"},
+		{"guest kicks", bobConn, models.MsgTypeKickPlayer, fmt.Sprintf(`{"playerId":"%s"}`, alice.MyPlayerID), "not_host"},
+		{"guest adds bot", bobConn, models.MsgTypeAddBot, `{"strategy":"random"}`, "not_host"},
+		{"guest deletes", bobConn, models.MsgTypeDeleteGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID), "not_host"},
+		{"outsider starts", outsiderConn, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID), "not_in_game"},
+		{"outsider deletes", outsiderConn, models.MsgTypeDeleteGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID), "not_in_game"},
+	}
+
+	for _, attempt := range attempts {
+		t.Run(attempt.name, func(t *testing.T) {
+			msgType, reply := request(attempt.conn, attempt.msgType, attempt.payload)
+			if msgType != models.MsgTypeError {
+				t.Fatalf("Expected an error, got %s", msgType)
+			}
+			if reply.Code != attempt.code {
+				t.Errorf("Expected code %s, got %q (%s)", attempt.code, reply.Code, reply.Error)
+			}
+			if reply.Action != string(attempt.msgType) {
+				t.Errorf("Expected action %s, got %q", attempt.msgType, reply.Action)
+			}
+		})
+	}
+
+	// The host can still start the game
+	msgType, reply := request(aliceConn, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID))
+	if msgType != models.MsgTypeGameState {
+		t.Errorf("Expected the host to start the game, got %s: %s", msgType, reply.Error)
+	}
+}
+
+// TestServerHostMigration tests that the host role moves on when the host leaves or disconnects
+func TestServerHostMigration(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn
+	}
+
+	// waitForHost reads game_state messages until the given player is host
+	waitForHost := func(conn *websocket.Conn, playerID string) bool {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			_, response, err := conn.ReadMessage()
+			if err != nil {
+				return false
+			}
+			var msg models.Message
+			if json.Unmarshal(response, &msg) != nil || msg.Type != models.MsgTypeGameState {
+				continue
+			}
+			var state struct {
+				HostID string `json:"hostId"`
+			}
+			if json.Unmarshal(msg.Payload, &state) == nil && state.HostID == playerID {
+				return true
+			}
+		}
+	}
+
+	send := func(conn *websocket.Conn, msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	t.Run("leave before start", func(t *testing.T) {
+		aliceConn := dial()
+		defer aliceConn.Close()
+		_, alice := joinAndRead(t, aliceConn, `{"gameId":"","playerName":"Alice"}`)
+
+		bobConn := dial()
+		defer bobConn.Close()
+		_, bob := joinAndRead(t, bobConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, alice.GameID))
+
+		send(aliceConn, models.MsgTypeLeaveGame, `{}`)
+		if !waitForHost(bobConn, bob.MyPlayerID) {
+			t.Error("Expected Bob to become host after Alice left")
+		}
+	})
+
+	t.Run("disconnect during play", func(t *testing.T) {
+		aliceConn := dial()
+		_, alice := joinAndRead(t, aliceConn, `{"gameId":"","playerName":"Alice"}`)
+
+		bobConn := dial()
+		defer bobConn.Close()
+		_, bob := joinAndRead(t, bobConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, alice.GameID))
+
+		send(aliceConn, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID))
+		if !waitForHost(bobConn, alice.MyPlayerID) {
+			t.Fatal("Expected Alice to host the started game")
+		}
+
+		aliceConn.Close()
+		if !waitForHost(bobConn, bob.MyPlayerID) {
+			t.Fatal("Expected Bob to become host after Alice disconnected")
+		}
+
+		// Alice comes back but Bob keeps the role
+		aliceConn = dial()
+		defer aliceConn.Close()
+		_, back := joinAndRead(t, aliceConn, fmt.Sprintf(`{"gameId":"%s","playerName":"Alice","playerId":"%s","token":"%s"}`,
+			alice.GameID, alice.MyPlayerID, alice.ReconnectToken))
+		if back.MyPlayerID != alice.MyPlayerID {
+			t.Fatalf("Expected Alice to reclaim her seat, got %q", back.MyPlayerID)
+		}
+		send(aliceConn, models.MsgTypeDeleteGame, fmt.Sprintf(`{"gameId":"%s"}`, alice.GameID))
+		aliceConn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		for {
+			_, response, err := aliceConn.ReadMessage()
+			if err != nil {
+				t.Fatalf("Failed to read reply to delete_game: %v", err)
+			}
+			var msg models.Message
+			json.Unmarshal(response, &msg)
+			if msg.Type == models.MsgTypeGameDeleted {
+				t.Fatal("Expected the former host's delete_game to be rejected")
+			}
+			if msg.Type == models.MsgTypeError {
+				break
+			}
+		}
+	})
+}
+
+// TestServerScoreBreakdowns tests that round_end and game_end explain each score by category
+func TestServerScoreBreakdowns(t *testing.T) {
+	server, err := NewServer(":0", &ServerOptions{
+		TurnTimeout:    20 * time.Millisecond,
+		AutoPlayPolicy: engine.FirstCardPolicy{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	send := func(msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	_, created := joinAndRead(t, conn, `{"gameId":"","playerName":"Alice"}`)
+	send(models.MsgTypeAddBot, `{"strategy":"greedy"}`)
+	send(models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, created.GameID))
+
+	// Nobody picks, so the turn timer plays the whole game
+	type roundEnd struct {
+		Scores []struct {
+			PlayerID   string                `json:"playerId"`
+			RoundScore int                   `json:"roundScore"`
+			Breakdown  models.ScoreBreakdown `json:"breakdown"`
+		} `json:"scores"`
+	}
+	var roundEnds []roundEnd
+	var result engine.GameResult
+
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for result.Winner == "" {
+		_, response, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Expected the game to end, got %v", err)
+		}
+		var msg models.Message
+		json.Unmarshal(response, &msg)
+		switch msg.Type {
+		case models.MsgTypeRoundEnd:
+			var ended roundEnd
+			json.Unmarshal(msg.Payload, &ended)
+			roundEnds = append(roundEnds, ended)
+		case models.MsgTypeGameEnd:
+			json.Unmarshal(msg.Payload, &result)
+		}
+	}
+
+	// The last round's breakdown comes with game_end instead
+	if len(roundEnds) != 2 {
+		t.Fatalf("Expected 2 round_end messages, got %d", len(roundEnds))
+	}
+	for _, roundEnd := range roundEnds {
+		if len(roundEnd.Scores) != 2 {
+			t.Fatalf("Expected scores for 2 players, got %d", len(roundEnd.Scores))
+		}
+		for _, score := range roundEnd.Scores {
+			points := 0
+			for _, category := range score.Breakdown.Categories {
+				points += category.Points
+			}
+			if points != score.RoundScore || score.Breakdown.Total != score.RoundScore {
+				t.Errorf("%s: categories add up to %d, round score %d", score.PlayerID, points, score.RoundScore)
+			}
+		}
+	}
+
+	for _, ranking := range result.Rankings {
+		if len(ranking.RoundBreakdowns) != 3 {
+			t.Errorf("%s: expected 3 round breakdowns, got %d", ranking.PlayerID, len(ranking.RoundBreakdowns))
+		}
+		if ranking.Breakdown.Total != ranking.FinalScore {
+			t.Errorf("%s: breakdown total %d, final score %d", ranking.PlayerID, ranking.Breakdown.Total, ranking.FinalScore)
+		}
+	}
+}
+
+// TestServerPassPolicy tests that game_state reports the pass policy and this round's direction
+func TestServerPassPolicy(t *testing.T) {
+	server, err := NewServer(":0", &ServerOptions{PassPolicy: models.PassPolicy{Direction: models.PassRight}})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	tests := []struct {
+		name              string
+		payload           string
+		expectedPolicy    models.PassDirection
+		expectedDirection models.PassDirection
+	}{
+		{"server default", `{"gameId":"","playerName":"Alice"}`, models.PassRight, models.PassRight},
+		{"alternate", `{"gameId":"","playerName":"Bob","passPolicy":{"direction":"alternate"}}`, models.PassAlternate, models.PassLeft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			data, _ := json.Marshal(models.Message{Type: models.MsgTypeJoinGame, Payload: json.RawMessage(tt.payload)})
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				t.Fatalf("Failed to send create message: %v", err)
+			}
+
+			// Skip lobby broadcasts about the other game
+			var respMsg models.Message
+			for respMsg.Type != models.MsgTypeGameState {
+				if err := conn.ReadJSON(&respMsg); err != nil {
+					t.Fatalf("Failed to read response: %v", err)
+				}
+			}
+			var gameState struct {
+				PassPolicy    models.PassPolicy    `json:"passPolicy"`
+				PassDirection models.PassDirection `json:"passDirection"`
+			}
+			if err := json.Unmarshal(respMsg.Payload, &gameState); err != nil {
+				t.Fatalf("Failed to unmarshal game state: %v", err)
+			}
+			if gameState.PassPolicy.Direction != tt.expectedPolicy || gameState.PassDirection != tt.expectedDirection {
+				t.Errorf("Expected policy %s passing %s in round 1, got %+v", tt.expectedPolicy, tt.expectedDirection, gameState)
+			}
+		})
+	}
+
+	if _, err := NewServer(":0", &ServerOptions{PassPolicy: models.PassPolicy{Direction: "across"}}); err == nil {
+		t.Error("Expected an unknown pass direction to be rejected")
+	}
+}
+
+// TestServerDummyVariant tests that players take turns picking the dummy's card, bots included
+func TestServerDummyVariant(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	type dummyState struct {
+		GameID        string        `json:"gameId"`
+		Phase         string        `json:"phase"`
+		MyPlayerID    string        `json:"myPlayerId"`
+		MyHand        []models.Card `json:"myHand"`
+		DummyPickerID string        `json:"dummyPickerId"`
+		DummyHand     []models.Card `json:"dummyHand"`
+		Players       []struct {
+			IsDummy bool `json:"isDummy"`
+		} `json:"players"`
+	}
+
+	readState := func(done func(dummyState) bool) (dummyState, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			var msg models.Message
+			if err := conn.ReadJSON(&msg); err != nil {
+				return dummyState{}, false
+			}
+			var state dummyState
+			if msg.Type == models.MsgTypeGameState && json.Unmarshal(msg.Payload, &state) == nil && done(state) {
+				return state, true
+			}
+		}
+	}
+	send := func(msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	send(models.MsgTypeJoinGame, `{"gameId":"","playerName":"Alice","dummy":true}`)
+	created, ok := readState(func(s dummyState) bool { return s.GameID != "" })
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+	send(models.MsgTypeAddBot, `{"strategy":"greedy"}`)
+	send(models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, created.GameID))
+
+	// Alice picks for the dummy on the first turn
+	first, ok := readState(func(s dummyState) bool { return len(s.MyHand) == 9 })
+	if !ok {
+		t.Fatal("Expected the first pick to start")
+	}
+	if first.DummyPickerID != created.MyPlayerID || len(first.DummyHand) != 9 {
+		t.Fatalf("Expected Alice to see the dummy's 9 cards, got picker %q with %d cards", first.DummyPickerID, len(first.DummyHand))
+	}
+	if len(first.Players) != 3 || !first.Players[2].IsDummy {
+		t.Error("Expected the dummy in the third seat")
+	}
+
+	send(models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	send(models.MsgTypePlayDummyCard, `{"cardIndex":0}`)
+
+	// The bot picks for the dummy on the second turn, so Alice's own card completes it
+	second, ok := readState(func(s dummyState) bool { return s.Phase == string(models.PhaseSelecting) && len(s.MyHand) == 8 })
+	if !ok {
+		t.Fatal("Expected the turn to advance once the dummy's card was picked")
+	}
+	if second.DummyPickerID == created.MyPlayerID || second.DummyHand != nil {
+		t.Errorf("Expected the bot to pick for the dummy, got picker %q", second.DummyPickerID)
+	}
+	send(models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	if _, ok := readState(func(s dummyState) bool { return len(s.MyHand) == 7 }); !ok {
+		t.Error("Expected the bot to pick for the dummy and the turn to advance")
+	}
+}
+
+// TestServerUndoVote tests that both players agreeing takes back the last reveal
+func TestServerUndoVote(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn
+	}
+	alice, bob := dial(), dial()
+	defer alice.Close()
+	defer bob.Close()
+
+	type undoState struct {
+		GameID string        `json:"gameId"`
+		MyHand []models.Card `json:"myHand"`
+		Undo   *struct {
+			Available bool `json:"available"`
+			Vote      *struct {
+				RequestedBy string   `json:"requestedBy"`
+				Waiting     []string `json:"waiting"`
+			} `json:"vote"`
+		} `json:"undo"`
+	}
+
+	readState := func(conn *websocket.Conn, done func(undoState) bool) (undoState, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			var msg models.Message
+			if err := conn.ReadJSON(&msg); err != nil {
+				return undoState{}, false
+			}
+			var state undoState
+			if msg.Type == models.MsgTypeGameState && json.Unmarshal(msg.Payload, &state) == nil && done(state) {
+				return state, true
+			}
+		}
+	}
+	send := func(conn *websocket.Conn, msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	send(alice, models.MsgTypeJoinGame, `{"gameId":"","playerName":"Alice"}`)
+	created, ok := readState(alice, func(s undoState) bool { return s.GameID != "" })
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+	send(bob, models.MsgTypeJoinGame, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob"}`, created.GameID))
+	if _, ok := readState(bob, func(s undoState) bool { return s.GameID == created.GameID }); !ok {
+		t.Fatal("Expected Bob to join")
+	}
+	send(alice, models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, created.GameID))
+	if _, ok := readState(bob, func(s undoState) bool { return len(s.MyHand) == 10 }); !ok {
+		t.Fatal("Expected the first pick to start")
+	}
+
+	send(alice, models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	send(bob, models.MsgTypeSelectCard, `{"cardIndex":0}`)
+	if _, ok := readState(bob, func(s undoState) bool { return len(s.MyHand) == 9 && s.Undo != nil && s.Undo.Available }); !ok {
+		t.Fatal("Expected the reveal to be undoable")
+	}
+
+	send(alice, models.MsgTypeRequestUndo, `{}`)
+	if _, ok := readState(bob, func(s undoState) bool { return s.Undo != nil && s.Undo.Vote != nil && len(s.Undo.Vote.Waiting) == 1 }); !ok {
+		t.Fatal("Expected Bob to be asked to vote")
+	}
+	send(bob, models.MsgTypeVoteUndo, `{"agree":true}`)
+	restored, ok := readState(alice, func(s undoState) bool { return len(s.MyHand) == 10 })
+	if !ok {
+		t.Fatal("Expected Alice's hand back after both agreed")
+	}
+	if restored.Undo != nil {
+		t.Errorf("Expected nothing left to undo, got %+v", restored.Undo)
+	}
+}
+
+// TestServerReadyCheck tests that readiness is broadcast and a game with the auto ready check starts by itself
+func TestServerReadyCheck(t *testing.T) {
+	server, err := NewServer(":0", &ServerOptions{ReadyCheck: models.ReadyCheckAuto})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	type readyState struct {
+		GameID     string        `json:"gameId"`
+		Phase      string        `json:"phase"`
+		ReadyCheck string        `json:"readyCheck"`
+		MyHand     []models.Card `json:"myHand"`
+		Players    []struct {
+			Ready bool `json:"ready"`
+		} `json:"players"`
+	}
+
+	readState := func(done func(readyState) bool) (readyState, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			var msg models.Message
+			if err := conn.ReadJSON(&msg); err != nil {
+				return readyState{}, false
+			}
+			var state readyState
+			if msg.Type == models.MsgTypeGameState && json.Unmarshal(msg.Payload, &state) == nil && done(state) {
+				return state, true
+			}
+		}
+	}
+	send := func(msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	send(models.MsgTypeJoinGame, `{"gameId":"","playerName":"Alice"}`)
+	created, ok := readState(func(s readyState) bool { return s.GameID != "" })
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+	if created.ReadyCheck != string(models.ReadyCheckAuto) || created.Players[0].Ready {
+		t.Errorf("Expected the auto ready check with Alice not ready, got %q", created.ReadyCheck)
+	}
+
+	send(models.MsgTypeAddBot, `{"strategy":"greedy"}`)
+	if _, ok := readState(func(s readyState) bool { return len(s.Players) == 2 && s.Players[1].Ready }); !ok {
+		t.Fatal("Expected the bot to sit down ready")
+	}
+	send(models.MsgTypeStartGame, fmt.Sprintf(`{"gameId":"%s"}`, created.GameID))
+	send(models.MsgTypeSetReady, `{"ready":true}`)
+	if _, ok := readState(func(s readyState) bool { return s.Phase == string(models.PhaseSelecting) && len(s.MyHand) == 10 }); !ok {
+		t.Fatal("Expected the game to start once Alice was ready")
+	}
+}
+
+// TestServerPrivateGame tests that a private game is unlisted and only joined or watched with its invite code
+func TestServerPrivateGame(t *testing.T) {
+	server, err := NewServer(":0", nil)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn
+	}
+	alice, bob, watcher := dial(), dial(), dial()
+	defer alice.Close()
+	defer bob.Close()
+	defer watcher.Close()
+
+	// readUntil returns the payload of the first message of one of the wanted types
+	readUntil := func(conn *websocket.Conn, types ...models.MessageType) (models.Message, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			var msg models.Message
+			if err := conn.ReadJSON(&msg); err != nil {
+				return models.Message{}, false
+			}
+			for _, msgType := range types {
+				if msg.Type == msgType {
+					return msg, true
+				}
+			}
+		}
+	}
+	send := func(conn *websocket.Conn, msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	send(alice, models.MsgTypeJoinGame, `{"gameId":"","playerName":"Alice","private":true}`)
+	msg, ok := readUntil(alice, models.MsgTypeGameState)
+	if !ok {
+		t.Fatal("Expected game_state after creating a game")
+	}
+	var created struct {
+		GameID     string `json:"gameId"`
+		Private    bool   `json:"private"`
+		InviteCode string `json:"inviteCode"`
+	}
+	json.Unmarshal(msg.Payload, &created)
+	if !created.Private || created.InviteCode == "" {
+		t.Fatalf("Expected Alice to be shown the invite code, got %+v", created)
+	}
+
+	send(bob, models.MsgTypeListGames, `{}`)
+	msg, ok = readUntil(bob, models.MsgTypeListGames)
+	var list struct {
+		Games []map[string]interface{} `json:"games"`
+	}
+	if !ok || json.Unmarshal(msg.Payload, &list) != nil || len(list.Games) != 0 {
+		t.Errorf("Expected the private game to be unlisted, got %s", msg.Payload)
+	}
+
+	for _, code := range []string{"", "WRONG1"} {
+		send(bob, models.MsgTypeJoinGame, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob","inviteCode":"%s"}`, created.GameID, code))
+		if msg, _ := readUntil(bob, models.MsgTypeError, models.MsgTypeGameState); msg.Type != models.MsgTypeError {
+			t.Errorf("Expected Bob to be refused with code %q, got %s", code, msg.Type)
+		}
+	}
+	send(watcher, models.MsgTypeSpectateGame, fmt.Sprintf(`{"gameId":"%s"}`, created.GameID))
+	if msg, _ := readUntil(watcher, models.MsgTypeError, models.MsgTypeGameState); msg.Type != models.MsgTypeError {
+		t.Errorf("Expected a spectator without the code to be refused, got %s", msg.Type)
+	}
+
+	send(bob, models.MsgTypeJoinGame, fmt.Sprintf(`{"gameId":"%s","playerName":"Bob","inviteCode":"%s"}`, created.GameID, created.InviteCode))
+	msg, ok = readUntil(bob, models.MsgTypeError, models.MsgTypeGameState)
+	if !ok || msg.Type != models.MsgTypeGameState {
+		t.Fatalf("Expected Bob to join with the invite code, got %s", msg.Payload)
+	}
+	var joined struct {
+		InviteCode string `json:"inviteCode"`
+	}
+	json.Unmarshal(msg.Payload, &joined)
+	if joined.InviteCode != "" {
+		t.Errorf("Expected only the host to be shown the invite code, got %q", joined.InviteCode)
+	}
+}
+
+// TestServerMatchmaking tests that find_match seats clients asking for the same table together,
+// and fills the table with bots once the backfill timeout passes
+func TestServerMatchmaking(t *testing.T) {
+	server, err := NewServer(":0", &ServerOptions{MatchBackfill: 300 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	server.StartBackground()
+	defer server.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("127.0.0.1:%d", server.Port), Path: "/ws"}
+	dial := func() *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+		if err != nil {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+		return conn
+	}
+	alice, bob, carol := dial(), dial(), dial()
+	defer alice.Close()
+	defer bob.Close()
+	defer carol.Close()
+
+	type matchState struct {
+		GameID  string        `json:"gameId"`
+		MyHand  []models.Card `json:"myHand"`
+		TieMode string        `json:"tieMode"`
+		Players []struct {
+			Name  string `json:"name"`
+			IsBot bool   `json:"isBot"`
+		} `json:"players"`
+	}
+
+	readState := func(conn *websocket.Conn) (matchState, bool) {
+		conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		defer conn.SetReadDeadline(time.Time{})
+		for {
+			var msg models.Message
+			if err := conn.ReadJSON(&msg); err != nil {
+				return matchState{}, false
+			}
+			var state matchState
+			if msg.Type == models.MsgTypeGameState && json.Unmarshal(msg.Payload, &state) == nil && len(state.MyHand) > 0 {
+				return state, true
+			}
+		}
+	}
+	send := func(conn *websocket.Conn, msgType models.MessageType, payload string) {
+		data, _ := json.Marshal(models.Message{Type: msgType, Payload: json.RawMessage(payload)})
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			t.Fatalf("Failed to send %s: %v", msgType, err)
+		}
+	}
+
+	// Alice and Bob want the same two-player game; Carol wants tied points split, so waits apart
+	send(alice, models.MsgTypeFindMatch, `{"playerName":"Alice","players":2}`)
+	send(carol, models.MsgTypeFindMatch, `{"playerName":"Carol","players":3,"rules":{"tieMode":"split"}}`)
+	send(bob, models.MsgTypeFindMatch, `{"playerName":"Bob","players":2}`)
+
+	aliceState, ok := readState(alice)
+	if !ok {
+		t.Fatal("Expected Alice to be matched")
+	}
+	bobState, ok := readState(bob)
+	if !ok {
+		t.Fatal("Expected Bob to be matched")
+	}
+	if aliceState.GameID != bobState.GameID || len(aliceState.Players) != 2 || len(aliceState.MyHand) != 10 {
+		t.Errorf("Expected Alice and Bob at one started two-player table, got %s and %s with %d players", aliceState.GameID, bobState.GameID, len(aliceState.Players))
+	}
+
+	// Nobody else wants Carol's game, so bots fill it once the backfill timeout passes
+	carolState, ok := readState(carol)
+	if !ok {
+		t.Fatal("Expected Carol's table to be filled with bots")
+	}
+	if len(carolState.Players) != 3 || carolState.Players[0].Name != "Carol" || !carolState.Players[1].IsBot || !carolState.Players[2].IsBot {
+		t.Errorf("Expected Carol and two bots, got %+v", carolState.Players)
+	}
+	if carolState.TieMode != string(models.TieSplit) {
+		t.Errorf("Expected Carol's rules to be played, got tie mode %q", carolState.TieMode)
+	}
+}
@@ -0,0 +1,299 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/sushi-go-game/backend/engine"
+	"github.com/sushi-go-game/backend/models"
+	"github.com/sushi-go-game/backend/scoring"
+)
+
+// Score categories reported per strategy
+const (
+	CategoryMaki     = scoring.CategoryMaki
+	CategoryTempura  = scoring.CategoryTempura
+	CategorySashimi  = scoring.CategorySashimi
+	CategoryDumpling = scoring.CategoryDumpling
+	CategoryNigiri   = scoring.CategoryNigiri // Includes the Wasabi bonus
+	CategoryPudding  = scoring.CategoryPudding
+)
+
+// Categories lists the score categories in report order
+var Categories = []string{CategoryMaki, CategoryTempura, CategorySashimi, CategoryDumpling, CategoryNigiri, CategoryPudding}
+
+// Config configures a batch of bot-vs-bot games
+type Config struct {
+	Games        int      // Number of games to play
+	Seed         int64    // Seed for the engine, so a batch is reproducible
+	Strategies   []string // One bot per entry; the player count is len(Strategies)
+	NumRounds    int      // Rounds per game (0: engine default)
+	CardsPerHand int      // Fixed hand size as a house rule (0: official size for the player count)
+}
+
+// Report summarizes a batch of games
+type Report struct {
+	Games        int              `json:"games"`
+	Seed         int64            `json:"seed"`
+	NumRounds    int              `json:"numRounds"`
+	CardsPerHand int              `json:"cardsPerHand"`
+	Strategies   []*StrategyStats `json:"strategies"`
+}
+
+// StrategyStats aggregates the results of every seat played by one strategy
+type StrategyStats struct {
+	Strategy     string             `json:"strategy"`
+	Seats        int                `json:"seats"` // Games played, counting each seat separately
+	Wins         float64            `json:"wins"`  // Shared wins are split between the tied players
+	WinRate      float64            `json:"winRate"`
+	AvgScore     float64            `json:"avgScore"`
+	StdDev       float64            `json:"stdDev"`
+	MinScore     int                `json:"minScore"`
+	MaxScore     int                `json:"maxScore"`
+	AvgCategory  map[string]float64 `json:"avgCategory"`
+	Distribution []ScoreCount       `json:"distribution"` // Final scores, ascending
+
+	scores        []int
+	categoryTotal map[string]int
+}
+
+// ScoreCount is the number of times a final score occurred
+type ScoreCount struct {
+	Score int `json:"score"`
+	Count int `json:"count"`
+}
+
+// Run plays cfg.Games engine-only games between bots and aggregates the results
+// Seating rotates every game so no strategy keeps the same position
+func Run(cfg Config) (*Report, error) {
+	if cfg.Games <= 0 {
+		return nil, errors.New("number of games must be positive")
+	}
+	limits := engine.DefaultPlayerLimits
+	if len(cfg.Strategies) < limits.Min || len(cfg.Strategies) > limits.Max {
+		return nil, fmt.Errorf("need %d-%d bots, got %d", limits.Min, limits.Max, len(cfg.Strategies))
+	}
+	for _, name := range cfg.Strategies {
+		if _, err := engine.NewBotStrategy(name); err != nil {
+			return nil, err
+		}
+	}
+
+	gameEngine := engine.NewEngineWithConfig(nil, cfg.NumRounds, cfg.CardsPerHand)
+	gameEngine.SetSeed(cfg.Seed)
+	// Every game is dealt from one deck, so check it covers every round at this table before playing
+	if err := gameEngine.SetPlayerLimits(engine.PlayerLimits{Min: len(cfg.Strategies), Max: len(cfg.Strategies), PartyMax: limits.PartyMax}); err != nil {
+		return nil, err
+	}
+	if err := gameEngine.CheckDeck(); err != nil {
+		return nil, fmt.Errorf("these house rules don't fit %d bots: %w", len(cfg.Strategies), err)
+	}
+	// Nothing outlives the run, so skip serializing every move
+	if err := gameEngine.SetStore(engine.NopStore{}); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Games:        cfg.Games,
+		Seed:         cfg.Seed,
+		NumRounds:    cfg.NumRounds,
+		CardsPerHand: cfg.CardsPerHand,
+	}
+	stats := make(map[string]*StrategyStats)
+	for _, name := range cfg.Strategies {
+		if _, exists := stats[name]; !exists {
+			stats[name] = &StrategyStats{Strategy: name, categoryTotal: make(map[string]int)}
+			report.Strategies = append(report.Strategies, stats[name])
+		}
+	}
+
+	seating := make([]string, len(cfg.Strategies))
+	for i := 0; i < cfg.Games; i++ {
+		for seat := range seating {
+			seating[seat] = cfg.Strategies[(seat+i)%len(cfg.Strategies)]
+		}
+		if err := playGame(gameEngine, seating, stats); err != nil {
+			return nil, fmt.Errorf("game %d: %w", i+1, err)
+		}
+	}
+
+	for _, s := range report.Strategies {
+		s.finish()
+	}
+	return report, nil
+}
+
+// playGame plays one game to the end and records each seat's result
+func playGame(gameEngine *engine.Engine, seating []string, stats map[string]*StrategyStats) error {
+	game, err := gameEngine.CreateGame(nil)
+	if err != nil {
+		return err
+	}
+	defer gameEngine.DeleteGame(game.ID)
+
+	strategyOf := make(map[string]string)
+	for _, name := range seating {
+		bot, err := gameEngine.AddBot(game.ID, name)
+		if err != nil {
+			return err
+		}
+		strategyOf[bot.ID] = name
+	}
+
+	if err := gameEngine.StartGame(game.ID); err != nil {
+		return err
+	}
+
+	for {
+		if err := gameEngine.StartRound(game.ID); err != nil {
+			return err
+		}
+		for {
+			if _, err := gameEngine.PlayBots(game.ID); err != nil {
+				return err
+			}
+			if err := gameEngine.RevealCards(game.ID); err != nil {
+				return err
+			}
+			if err := gameEngine.PassHands(game.ID); err != nil {
+				return err
+			}
+
+			game, err = gameEngine.GetGame(game.ID)
+			if err != nil {
+				return err
+			}
+			if game.RoundPhase == models.PhaseScoring {
+				break
+			}
+		}
+
+		if err := gameEngine.ScoreRound(game.ID); err != nil {
+			return err
+		}
+		game, err = gameEngine.GetGame(game.ID)
+		if err != nil {
+			return err
+		}
+		if game.RoundPhase == models.PhaseGameEnd {
+			break
+		}
+	}
+
+	result, err := gameEngine.EndGame(game.ID)
+	if err != nil {
+		return err
+	}
+
+	// Players tied on score and pudding share the win
+	top := result.Rankings[0]
+	winners := 0
+	for _, ranking := range result.Rankings {
+		if ranking.FinalScore == top.FinalScore && ranking.PuddingCount == top.PuddingCount {
+			winners++
+		}
+	}
+
+	for _, ranking := range result.Rankings {
+		categories := make(map[string]int)
+		for _, category := range ranking.Breakdown.Categories {
+			categories[category.Category] = category.Points
+		}
+
+		won := 0.0
+		if ranking.FinalScore == top.FinalScore && ranking.PuddingCount == top.PuddingCount {
+			won = 1 / float64(winners)
+		}
+		stats[strategyOf[ranking.PlayerID]].add(ranking.FinalScore, won, categories)
+	}
+
+	return nil
+}
+
+// add records one seat's final result
+func (s *StrategyStats) add(score int, won float64, categories map[string]int) {
+	s.Seats++
+	s.Wins += won
+	s.scores = append(s.scores, score)
+	for category, points := range categories {
+		s.categoryTotal[category] += points
+	}
+}
+
+// finish computes the averages and distribution from the recorded results
+func (s *StrategyStats) finish() {
+	s.AvgCategory = make(map[string]float64)
+	if s.Seats == 0 {
+		return
+	}
+
+	sort.Ints(s.scores)
+	s.MinScore = s.scores[0]
+	s.MaxScore = s.scores[len(s.scores)-1]
+
+	total := 0
+	for _, score := range s.scores {
+		total += score
+		if n := len(s.Distribution); n > 0 && s.Distribution[n-1].Score == score {
+			s.Distribution[n-1].Count++
+		} else {
+			s.Distribution = append(s.Distribution, ScoreCount{Score: score, Count: 1})
+		}
+	}
+	s.AvgScore = float64(total) / float64(s.Seats)
+	s.WinRate = s.Wins / float64(s.Seats)
+
+	variance := 0.0
+	for _, score := range s.scores {
+		variance += math.Pow(float64(score)-s.AvgScore, 2)
+	}
+	s.StdDev = math.Sqrt(variance / float64(s.Seats))
+
+	for _, category := range Categories {
+		s.AvgCategory[category] = float64(s.categoryTotal[category]) / float64(s.Seats)
+	}
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the report as long-format rows of strategy, metric, key, value
+// Category averages use the category as key; the distribution uses the score as key
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	formatFloat := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+
+	rows := [][]string{{"strategy", "metric", "key", "value"}}
+	for _, s := range r.Strategies {
+		rows = append(rows,
+			[]string{s.Strategy, "seats", "", strconv.Itoa(s.Seats)},
+			[]string{s.Strategy, "wins", "", formatFloat(s.Wins)},
+			[]string{s.Strategy, "win_rate", "", formatFloat(s.WinRate)},
+			[]string{s.Strategy, "avg_score", "", formatFloat(s.AvgScore)},
+			[]string{s.Strategy, "std_dev", "", formatFloat(s.StdDev)},
+			[]string{s.Strategy, "min_score", "", strconv.Itoa(s.MinScore)},
+			[]string{s.Strategy, "max_score", "", strconv.Itoa(s.MaxScore)},
+		)
+		for _, category := range Categories {
+			rows = append(rows, []string{s.Strategy, "avg_category", category, formatFloat(s.AvgCategory[category])})
+		}
+		for _, bucket := range s.Distribution {
+			rows = append(rows, []string{s.Strategy, "score_count", strconv.Itoa(bucket.Score), strconv.Itoa(bucket.Count)})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,160 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+)
+
+// TestRunIsReproducible tests that the same seed produces the same report
+func TestRunIsReproducible(t *testing.T) {
+	cfg := Config{Games: 20, Seed: 42, Strategies: []string{"greedy", "random", "set_completion"}}
+
+	first, err := Run(cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	second, _ := Run(cfg)
+
+	var a, b bytes.Buffer
+	first.WriteJSON(&a)
+	second.WriteJSON(&b)
+	if a.String() != b.String() {
+		t.Error("Expected identical reports for the same seed")
+	}
+}
+
+// TestRunTotals tests that wins, seats and category averages add up
+func TestRunTotals(t *testing.T) {
+	report, err := Run(Config{Games: 30, Seed: 7, Strategies: []string{"greedy", "greedy", "random", "set_completion"}})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(report.Strategies) != 3 {
+		t.Fatalf("Expected 3 strategies, got %d", len(report.Strategies))
+	}
+
+	seats := 0
+	wins := 0.0
+	for _, s := range report.Strategies {
+		seats += s.Seats
+		wins += s.Wins
+
+		categorySum := 0.0
+		for _, category := range Categories {
+			categorySum += s.AvgCategory[category]
+		}
+		if math.Abs(categorySum-s.AvgScore) > 1e-9 {
+			t.Errorf("%s: category averages sum to %.2f, expected average score %.2f", s.Strategy, categorySum, s.AvgScore)
+		}
+
+		counted := 0
+		for _, bucket := range s.Distribution {
+			counted += bucket.Count
+		}
+		if counted != s.Seats {
+			t.Errorf("%s: distribution covers %d results, expected %d", s.Strategy, counted, s.Seats)
+		}
+	}
+
+	if seats != 30*4 {
+		t.Errorf("Expected %d seats, got %d", 30*4, seats)
+	}
+	if math.Abs(wins-30) > 1e-9 {
+		t.Errorf("Expected 30 wins in total, got %.2f", wins)
+	}
+}
+
+// TestRunHouseRules tests that the round and hand size settings reach the engine
+func TestRunHouseRules(t *testing.T) {
+	report, err := Run(Config{Games: 5, Seed: 1, Strategies: []string{"greedy", "random"}, NumRounds: 1, CardsPerHand: 4})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// One round of four picks each caps the score well below a full game
+	for _, s := range report.Strategies {
+		if s.MaxScore > 4*10+6 {
+			t.Errorf("%s: max score %d is too high for one round of four cards", s.Strategy, s.MaxScore)
+		}
+	}
+}
+
+// TestRunRejectsBadConfig tests configuration validation
+func TestRunRejectsBadConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"no games", Config{Games: 0, Strategies: []string{"greedy", "random"}}},
+		{"one bot", Config{Games: 1, Strategies: []string{"greedy"}}},
+		{"six bots", Config{Games: 1, Strategies: []string{"random", "random", "random", "random", "random", "random"}}},
+		{"unknown strategy", Config{Games: 1, Strategies: []string{"greedy", "psychic"}}},
+		{"ten cards for four", Config{Games: 1, Strategies: []string{"greedy", "greedy", "random", "random"}, CardsPerHand: 10}},
+		{"four rounds for five", Config{Games: 1, Strategies: []string{"greedy", "greedy", "greedy", "random", "random"}, NumRounds: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.cfg); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+// TestRunHouseRulesFullDeck tests house rules that deal out most of the deck across several rounds
+func TestRunHouseRulesFullDeck(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"ten cards for three", Config{Games: 20, Seed: 5, Strategies: []string{"greedy", "set_completion", "random"}, CardsPerHand: 10}},
+		{"four rounds for three", Config{Games: 20, Seed: 5, Strategies: []string{"greedy", "set_completion", "random"}, NumRounds: 4}},
+		{"five rounds for two", Config{Games: 20, Seed: 5, Strategies: []string{"greedy", "random"}, NumRounds: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Run(tt.cfg)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			for _, s := range report.Strategies {
+				if s.Seats != tt.cfg.Games {
+					t.Errorf("%s: expected %d seats, got %d", s.Strategy, tt.cfg.Games, s.Seats)
+				}
+			}
+		})
+	}
+}
+
+// TestWriteCSV tests the long-format CSV output
+func TestWriteCSV(t *testing.T) {
+	report, _ := Run(Config{Games: 3, Seed: 3, Strategies: []string{"greedy", "random"}})
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(rows) < 2 || rows[0][0] != "strategy" || rows[0][3] != "value" {
+		t.Fatalf("Unexpected CSV header: %v", rows[0])
+	}
+
+	found := false
+	for _, row := range rows[1:] {
+		if row[0] == "greedy" && row[1] == "avg_category" && row[2] == CategoryPudding {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a pudding category average for greedy")
+	}
+}
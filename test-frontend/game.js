@@ -15,6 +15,12 @@ const connectionStatus = document.getElementById('connectionStatus');
 const createBtn = document.getElementById('createBtn');
 const joinBtn = document.getElementById('joinBtn');
 const startBtn = document.getElementById('startBtn');
+const addBotBtn = document.getElementById('addBotBtn');
+const readyBtn = document.getElementById('readyBtn');
+const findMatchBtn = document.getElementById('findMatchBtn');
+const cancelMatchBtn = document.getElementById('cancelMatchBtn');
+let searchingMatch = false;
+const deleteBtn = document.getElementById('deleteBtn');
 const handDiv = document.getElementById('hand');
 const playersListDiv = document.getElementById('playersList');
 const collectionDiv = document.getElementById('collection');
@@ -58,6 +64,7 @@ function connect() {
             connectionStatus.style.display = 'none';
             createBtn.disabled = false;
             joinBtn.disabled = false;
+            findMatchBtn.disabled = false;
             
             // Request list of games
             requestGamesList();
@@ -71,7 +78,9 @@ function connect() {
             connectionStatus.className = 'connection-status status-connecting';
             createBtn.disabled = true;
             joinBtn.disabled = true;
+            findMatchBtn.disabled = true;
             startBtn.disabled = true;
+            addBotBtn.disabled = true;
             
             // Try to reconnect after 2 seconds
             setTimeout(() => {
@@ -169,8 +178,16 @@ function handleMessage(data) {
         
         switch (message.type) {
             case 'game_state':
+                // A match was found, so the game has already started
+                if (searchingMatch) {
+                    handleMatchStatus({ searching: false });
+                    switchToPlayingScreen();
+                }
                 handleGameState(message.payload);
                 break;
+            case 'match_status':
+                handleMatchStatus(message.payload);
+                break;
             case 'card_revealed':
                 log(`Cards revealed: ${JSON.stringify(message.payload)}`, 'received');
                 break;
@@ -274,6 +291,11 @@ function continueStateUpdate(payload, animationType) {
     // Get my player ID from the payload
     if (payload.myPlayerId) {
         myPlayerId = payload.myPlayerId;
+
+        // Remember the reconnect token so this seat can be reclaimed later
+        if (payload.reconnectToken) {
+            saveSession(payload.gameId, payload.myPlayerId, payload.reconnectToken);
+        }
         
         // Update player name in the UI if it was randomly generated
         const myPlayer = payload.players?.find(p => p.id === myPlayerId);
@@ -289,6 +311,12 @@ function continueStateUpdate(payload, animationType) {
     
     // Update game ID display
     if (payload.gameId) {
+        // The host is only shown a private game's invite code when creating it, so keep it for that game
+        const inviteCodeValue = document.getElementById('inviteCodeValue');
+        if (payload.inviteCode || gameIdDisplay.textContent !== payload.gameId) {
+            inviteCodeValue.textContent = payload.inviteCode || '';
+        }
+        document.getElementById('inviteCodeDisplay').style.display = inviteCodeValue.textContent ? '' : 'none';
         document.getElementById('gameId').value = payload.gameId;
         gameIdDisplay.textContent = payload.gameId;
         currentGameIdDiv.style.display = 'block';
@@ -319,22 +347,52 @@ function continueStateUpdate(payload, animationType) {
     updateHand(animationType);
     updatePlayersList();
     updateCollection();
+    updateUndo();
     
+    // Only the host can start the game, fill empty seats with bots, kick or delete
+    const isHost = gameState.hostId === myPlayerId;
+    deleteBtn.style.display = isHost ? '' : 'none';
+
+    // Players declare themselves ready in the lobby
+    const me = gameState.players?.find(p => p.id === myPlayerId);
+    readyBtn.style.display = gameState.phase === 'waiting' && me ? '' : 'none';
+    readyBtn.textContent = me?.ready ? 'Not Ready' : "I'm Ready";
+    const readyCheck = gameState.readyCheck && gameState.readyCheck !== 'off';
+    const everyoneReady = !readyCheck || (gameState.players || []).every(p => p.ready);
+
     // Enable start button if we're waiting for players and have at least 2 players
-    if (gameState.phase === 'waiting' && gameState.players && gameState.players.length >= 2) {
+    const minPlayers = gameState.minPlayers || 2;
+    if (gameState.phase === 'waiting' && isHost && gameState.players && gameState.players.length >= minPlayers && everyoneReady) {
         startBtn.disabled = false;
     } else if (gameState.phase === 'waiting') {
         startBtn.disabled = true;
-        log(`Waiting for more players (${gameState.players ? gameState.players.length : 0}/2)`, 'info');
+        log(`Waiting for more players (${gameState.players ? gameState.players.length : 0}/${minPlayers})`, 'info');
     } else {
         startBtn.disabled = true;
     }
+
+    addBotBtn.disabled = !(gameState.phase === 'waiting' && isHost && gameState.players.length < (gameState.maxPlayers || 5));
+}
+
+// Render a score breakdown as one line per category
+function breakdownHTML(breakdown, color) {
+    if (!breakdown?.categories?.length) return '';
+    return `
+        <div style="font-size: 11px; color: ${color}; margin-top: 4px; text-align: left;">
+            ${breakdown.categories.map(c => `
+                <div title="${c.detail || ''}">
+                    ${c.category.replace(/_/g, ' ')}: <strong>${c.points > 0 ? '+' : ''}${c.points}</strong>${c.detail ? ` <span style="opacity: 0.8;">(${c.detail})</span>` : ''}
+                </div>
+            `).join('')}
+        </div>
+    `;
 }
 
 // Handle round end message
 function handleRoundEnd(payload) {
     // payload.round is the round that just completed
     const round = payload.round || 0;
+    const breakdowns = Object.fromEntries((payload.scores || []).map(s => [s.playerId, s.breakdown]));
     
     // Wait a bit for game state to update, then show the overlay
     setTimeout(() => {
@@ -345,7 +403,8 @@ function handleRoundEnd(payload) {
         const puddingCount = myPlayer?.puddingCards?.length || 0;
         
         // Sort all players by score
-        const sortedPlayers = [...(gameState?.players || [])].sort((a, b) => b.score - a.score);
+        // The dummy of the two-player variant is scored but can't win
+    const sortedPlayers = [...(gameState?.players || [])].filter(p => !p.isDummy).sort((a, b) => b.score - a.score);
         
         // Create round end overlay with all players' scores
         const overlay = document.createElement('div');
@@ -378,6 +437,7 @@ function handleRoundEnd(payload) {
                                         <div style="font-size: 16px; font-weight: bold; color: white;">
                                             ${player.name}${isMe ? ' (You)' : ''}
                                         </div>
+                                        ${breakdownHTML(breakdowns[player.id], 'rgba(255,255,255,0.85)')}
                                     </div>
                                 </div>
                                 <div style="text-align: right;">
@@ -429,6 +489,7 @@ function handleGameEnd(payload) {
     const topScore = sortedPlayers[0]?.score || 0;
     const winners = sortedPlayers.filter(p => p.score === topScore);
     const isTie = winners.length > 1;
+    const breakdowns = Object.fromEntries((payload?.rankings || []).map(r => [r.playerId, r.breakdown]));
     
     const finalScoresHTML = `
         <div style="text-align: center; padding: 20px;">
@@ -463,15 +524,19 @@ function handleGameEnd(payload) {
                         ">
                             <div style="display: flex; align-items: center; gap: 10px;">
                                 <span style="font-size: 24px; font-weight: bold; color: #666;">#${rank}</span>
-                                <span style="font-size: 18px; font-weight: ${isWinner ? 'bold' : '600'}; color: #333;">
-                                    ${player.name}${isMe ? ' (You)' : ''}
-                                    ${isWinner ? ' 👑' : ''}
-                                </span>
+                                <div>
+                                    <span style="font-size: 18px; font-weight: ${isWinner ? 'bold' : '600'}; color: #333;">
+                                        ${player.name}${isMe ? ' (You)' : ''}
+                                        ${isWinner ? ' 👑' : ''}
+                                    </span>
+                                    ${breakdownHTML(breakdowns[player.id], '#555')}
+                                </div>
                             </div>
                             <span style="font-size: 24px; font-weight: bold; color: #333;">${player.score}</span>
                         </div>
                     `;
                 }).join('')}
+                ${payload?.dummy ? `<div style="color: #666; margin-top: 10px;">🤖 Dummy: ${payload.dummy.finalScore}</div>` : ''}
             </div>
             
             <button onclick="location.reload()" style="
@@ -556,15 +621,83 @@ function createGame() {
     }
     
     // Send join_game with empty gameId to create a new game
-    sendMessage('join_game', {
-        gameId: '',
-        playerName: playerName
-    });
+    const payload = { gameId: '', playerName: playerName };
+    if (document.getElementById('partyMenu').checked) {
+        payload.menu = {
+            roll: 'maki_roll',
+            appetizers: ['tempura', 'sashimi', 'miso_soup'],
+            specials: ['wasabi', 'tea'],
+            dessert: 'green_tea_ice_cream'
+        };
+    }
+    if (document.getElementById('splitTies').checked) {
+        payload.tieMode = 'split';
+    }
+    if (document.getElementById('dummyVariant').checked) {
+        payload.dummy = true;
+    }
+    const passDirection = document.getElementById('passDirection').value;
+    if (passDirection !== 'left') {
+        payload.passPolicy = { direction: passDirection };
+    }
+    // A password makes the game private; without one the server generates an invite code
+    const password = document.getElementById('inviteCode').value;
+    if (password) {
+        payload.password = password;
+    } else if (document.getElementById('privateGame').checked) {
+        payload.private = true;
+    }
+    sendMessage('join_game', payload);
     
     // Switch to playing screen
     switchToPlayingScreen();
 }
 
+// Wait in the matchmaking queue for a table of the chosen size and rules
+function findMatch() {
+    const rules = {};
+    if (document.getElementById('partyMenu').checked) {
+        rules.menu = {
+            roll: 'maki_roll',
+            appetizers: ['tempura', 'sashimi', 'miso_soup'],
+            specials: ['wasabi', 'tea'],
+            dessert: 'green_tea_ice_cream'
+        };
+    }
+    if (document.getElementById('splitTies').checked) {
+        rules.tieMode = 'split';
+    }
+    const passDirection = document.getElementById('passDirection').value;
+    if (passDirection !== 'left') {
+        rules.passDirection = passDirection;
+    }
+
+    sendMessage('find_match', {
+        playerName: document.getElementById('playerName').value,
+        players: parseInt(document.getElementById('matchPlayers').value, 10),
+        rules: rules
+    });
+}
+
+function cancelMatch() {
+    sendMessage('cancel_match', {});
+}
+
+function handleMatchStatus(payload) {
+    searchingMatch = payload.searching;
+    findMatchBtn.style.display = searchingMatch ? 'none' : '';
+    cancelMatchBtn.style.display = searchingMatch ? '' : 'none';
+
+    let status = '';
+    if (searchingMatch) {
+        status = `🔎 Waiting for players: ${payload.waiting}/${payload.players}`;
+        if (payload.backfillSeconds) {
+            status += ` (bots take empty seats after ${payload.backfillSeconds}s)`;
+        }
+    }
+    document.getElementById('matchStatus').textContent = status;
+}
+
 function joinGame() {
     const playerName = document.getElementById('playerName').value;
     const gameId = document.getElementById('gameId').value;
@@ -583,13 +716,28 @@ function joinGame() {
     
     sendMessage('join_game', {
         gameId: gameId,
-        playerName: playerName
+        playerName: playerName,
+        inviteCode: document.getElementById('inviteCode').value,
+        ...savedSession(gameId)
     });
     
     // Switch to playing screen
     switchToPlayingScreen();
 }
 
+// Reconnect tokens are kept per game for the lifetime of the tab
+function saveSession(gameId, playerId, token) {
+    sessionStorage.setItem(`session:${gameId}`, JSON.stringify({ playerId, token }));
+}
+
+function savedSession(gameId) {
+    try {
+        return JSON.parse(sessionStorage.getItem(`session:${gameId}`)) || {};
+    } catch (e) {
+        return {};
+    }
+}
+
 function joinGameById(gameId) {
     const playerName = document.getElementById('playerName').value;
     
@@ -602,7 +750,8 @@ function joinGameById(gameId) {
     
     sendMessage('join_game', {
         gameId: gameId,
-        playerName: playerName
+        playerName: playerName,
+        ...savedSession(gameId)
     });
     
     // Switch to playing screen
@@ -617,6 +766,24 @@ function startGame() {
     sendMessage('start_game', { gameId: gameState.gameId });
 }
 
+// Tell the table whether we're ready for the game to start
+function toggleReady() {
+    const me = gameState?.players?.find(p => p.id === myPlayerId);
+    if (!me) {
+        log('No active game', 'error');
+        return;
+    }
+    sendMessage('set_ready', { ready: !me.ready });
+}
+
+function addBot() {
+    if (!gameState || !gameState.gameId) {
+        log('No active game', 'error');
+        return;
+    }
+    sendMessage('add_bot', { gameId: gameState.gameId, strategy: 'greedy' });
+}
+
 function copyGameId() {
     const gameId = gameIdDisplay.textContent;
     navigator.clipboard.writeText(gameId).then(() => {
@@ -645,6 +812,14 @@ function requestGamesList() {
     sendMessage('list_games', {});
 }
 
+function deleteCurrentGame() {
+    if (!gameState || !gameState.gameId) {
+        log('No active game', 'error');
+        return;
+    }
+    deleteGame(gameState.gameId);
+}
+
 function deleteGame(gameId) {
     if (confirm(`Are you sure you want to delete game ${gameId}?`)) {
         sendMessage('delete_game', { gameId: gameId });
@@ -672,7 +847,6 @@ function handleGamesList(payload) {
             </div>
             <div style="display: flex; gap: 8px;">
                 <button onclick="joinGameById('${game.id}')" style="padding: 6px 12px; font-size: 12px; background: #667eea; color: white; border: none; border-radius: 4px; cursor: pointer;">Join</button>
-                <button onclick="deleteGame('${game.id}')" style="padding: 6px 10px; font-size: 12px; background: #dc3545; color: white; border: none; border-radius: 4px; cursor: pointer;">✕</button>
             </div>
         `;
         
@@ -924,6 +1098,13 @@ function updatePhaseIndicator() {
     }
     roundDots.innerHTML = roundNumbersArray.join(' ');
     
+    // Show which way hands go this round
+    const passArrows = { left: '← pass left', right: 'pass right →', custom: '⇄ custom passing' };
+    const passLabel = passArrows[gameState.passDirection];
+    if (passLabel) {
+        roundDots.innerHTML += `<div style="font-size: 11px; color: #666; margin-top: 2px;">${passLabel}</div>`;
+    }
+    
     // Create turn numbers (10 turns per round, based on cards remaining)
     if (round > 0 && round <= 3) {
         const currentTurn = handSize > 0 ? 11 - handSize : 10;
@@ -964,7 +1145,68 @@ function updateStatusMessage(text, bgColor = '#e3f2fd', textColor = '#1565c0') {
     }
 }
 
+// Show the dummy's hand to the player picking for it this turn
+function updateDummyHand() {
+    const dummyDiv = document.getElementById('dummyHand');
+    const dummyHand = gameState?.dummyHand || [];
+    if (!dummyDiv) return;
+    if (gameState?.phase !== 'selecting' || dummyHand.length === 0) {
+        dummyDiv.style.display = 'none';
+        return;
+    }
+    
+    const dummy = gameState.players?.find(p => p.isDummy);
+    dummyDiv.style.display = 'block';
+    dummyDiv.innerHTML = `<div style="font-weight: 600; color: #666; margin-bottom: 8px;">${dummy?.hasSelected ? '🤖 Dummy card picked (click to withdraw)' : '🤖 Your turn to pick a card for the Dummy'}</div>`;
+    
+    const cardsContainer = document.createElement('div');
+    cardsContainer.className = 'hand';
+    dummyHand.forEach((card, index) => {
+        const cardEl = document.createElement('div');
+        cardEl.className = 'card';
+        if (dummy?.hasSelected) {
+            cardEl.style.opacity = '0.5';
+            cardEl.onclick = () => sendMessage('withdraw_dummy_card', { gameId: gameState.gameId });
+        } else {
+            cardEl.onclick = () => sendMessage('play_dummy_card', { gameId: gameState.gameId, cardIndex: index });
+        }
+        cardEl.innerHTML = `
+            <div class="card-type">${formatCardType(card.type)}</div>
+            ${card.variant ? `<div class="card-variant">${card.variant}</div>` : ''}
+            ${card.value ? `<div class="card-variant">${card.value}</div>` : ''}
+        `;
+        cardsContainer.appendChild(cardEl);
+    });
+    dummyDiv.appendChild(cardsContainer);
+}
+
+// Offer to take back the last reveal, or show the open vote on it
+function updateUndo() {
+    const undoDiv = document.getElementById('undoPanel');
+    const undo = gameState?.undo;
+    if (!undoDiv) return;
+    if (!undo) {
+        undoDiv.style.display = 'none';
+        return;
+    }
+
+    undoDiv.style.display = 'block';
+    const vote = undo.vote;
+    if (!vote) {
+        undoDiv.innerHTML = '<button onclick="sendMessage(\'request_undo\', {})">↩️ Take back last reveal</button>';
+        return;
+    }
+
+    const nameOf = id => gameState.players?.find(p => p.id === id)?.name || id;
+    const waitingOnMe = vote.waiting.includes(myPlayerId);
+    undoDiv.innerHTML = `<div style="font-weight: 600; color: #666; margin-bottom: 8px;">↩️ ${nameOf(vote.requestedBy)} asked to take back the last reveal</div>` +
+        (waitingOnMe
+            ? '<button onclick="sendMessage(\'vote_undo\', { agree: true })">Agree</button> <button onclick="sendMessage(\'vote_undo\', { agree: false })" style="background: #dc3545;">Decline</button>'
+            : `<div style="color: #666;">Waiting for ${vote.waiting.map(nameOf).join(', ')}</div>`);
+}
+
 function updateHand(animationType = null) {
+    updateDummyHand();
     handDiv.innerHTML = '';
     
     // Remove any existing animation classes
@@ -1260,7 +1502,9 @@ function updatePlayersList() {
         li.className = 'player-item';
         
         const isMe = player.id === myPlayerId;
-        const selectedIndicator = player.hasSelected ? '✓' : '○';
+        const selectedIndicator = gameState.phase === 'waiting'
+            ? (player.ready ? '✅' : '⏳')
+            : (player.hasSelected ? '✓' : '○');
         
         // Count card types for this player
         let makiCount = 0;
@@ -1288,11 +1532,11 @@ function updatePlayersList() {
         const hasActiveWasabi = wasabiCount > nigiriCount;
         
         // Show kick button only in waiting phase and for other players
-        const canKick = gameState.phase === 'waiting' && !isMe;
+        const canKick = gameState.phase === 'waiting' && !isMe && gameState.hostId === myPlayerId;
         
         li.innerHTML = `
             <div style="display: flex; justify-content: space-between; align-items: center;">
-                <div class="player-name">${player.name}${isMe ? ' (You)' : ''} ${selectedIndicator}</div>
+                <div class="player-name">${player.name}${isMe ? ' (You)' : ''}${player.isHost ? ' 👑' : ''}${player.isBot ? ' 🤖' : ''} ${selectedIndicator}</div>
                 ${canKick ? `<button onclick="kickPlayer('${player.id}')" style="padding: 4px 8px; font-size: 12px; background: #dc3545; color: white; border: none; border-radius: 4px; cursor: pointer;">Kick</button>` : ''}
             </div>
             <div class="player-stats">
@@ -1465,7 +1709,24 @@ function getCardColor(cardType) {
         'nigiri': 'linear-gradient(135deg, #26c6da 0%, #00acc1 100%)', // Cyan
         'wasabi': 'linear-gradient(135deg, #66bb6a 0%, #43a047 100%)', // Green
         'chopsticks': 'linear-gradient(135deg, #ffd54f 0%, #ffb300 100%)', // Yellow
-        'pudding': 'linear-gradient(135deg, #ffb74d 0%, #f57c00 100%)' // Amber
+        'pudding': 'linear-gradient(135deg, #ffb74d 0%, #f57c00 100%)', // Amber
+        // Sushi Go Party!
+        'temaki': 'linear-gradient(135deg, #ef5350 0%, #c62828 100%)', // Deep red
+        'uramaki': 'linear-gradient(135deg, #ff8a65 0%, #e64a19 100%)', // Coral
+        'onigiri': 'linear-gradient(135deg, #b0bec5 0%, #78909c 100%)', // Slate
+        'edamame': 'linear-gradient(135deg, #9ccc65 0%, #7cb342 100%)', // Light green
+        'eel': 'linear-gradient(135deg, #8d6e63 0%, #5d4037 100%)', // Brown
+        'tofu': 'linear-gradient(135deg, #e6ee9c 0%, #c0ca33 100%)', // Lime
+        'miso_soup': 'linear-gradient(135deg, #ffcc80 0%, #ef6c00 100%)', // Tan
+        'soy_sauce': 'linear-gradient(135deg, #a1887f 0%, #4e342e 100%)', // Dark brown
+        'tea': 'linear-gradient(135deg, #aed581 0%, #689f38 100%)', // Olive
+        'spoon': 'linear-gradient(135deg, #cfd8dc 0%, #90a4ae 100%)', // Silver
+        'menu': 'linear-gradient(135deg, #fff59d 0%, #fbc02d 100%)', // Pale gold
+        'special_order': 'linear-gradient(135deg, #ce93d8 0%, #7b1fa2 100%)', // Violet
+        'takeout_box': 'linear-gradient(135deg, #bcaaa4 0%, #795548 100%)', // Cardboard
+        'face_down': 'linear-gradient(135deg, #616161 0%, #212121 100%)', // Charcoal
+        'green_tea_ice_cream': 'linear-gradient(135deg, #c5e1a5 0%, #8bc34a 100%)', // Matcha
+        'fruit': 'linear-gradient(135deg, #f48fb1 0%, #ec407a 100%)' // Rose
     };
     return colors[cardType] || 'linear-gradient(135deg, #78909c 0%, #546e7a 100%)'; // Default gray
 }